@@ -11,34 +11,79 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
+// alertLiteFields is the projection for the 'lite' form of News Alerts that
+// leaves out the raw and scrubbed bodies.
+var alertLiteFields = bson.M{"raw_body": 0, "body": 0, "sentences": 0}
+
+// AlertQuery holds the optional filters and paging for News Alert lookups.
+type AlertQuery struct {
+	// Senders limits alerts to those from any of the senders.
+	Senders []string
+	// Tags limits alerts to those with all of the tags.
+	Tags []string
+	// HasArticleURL, if set, limits alerts to those with or without an article URL.
+	HasArticleURL *bool
+
+	PageQuery
+}
+
+func (q AlertQuery) filter(start, end time.Time) bson.M {
+	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}}
+	if len(q.Senders) > 0 {
+		query["sender"] = bson.M{"$in": q.Senders}
+	}
+	if len(q.Tags) > 0 {
+		query["tags"] = bson.M{"$all": q.Tags}
+	}
+	if q.HasArticleURL != nil {
+		query["article_url"] = hasValue(*q.HasArticleURL)
+	}
+	if q.After != nil {
+		query["$or"] = afterKeys(q.sortKeys(*q.After))
+	}
+	return query
+}
+
+func (q AlertQuery) sortKeys(c cursor) []sortKey {
+	return []sortKey{{"timestamp", false, c.Time}, {"_id", false, c.ID}}
+}
+
 // FindByDate will accept a date range and return any News Alerts that occured within it. News Alert information
-// returned will be of the 'lite' form without the raw and scrubbed bodies.
-func FindAlertsByDate(ctx context.Context, db *mgo.Database, start time.Time, end time.Time) ([]newshound.NewsAlertLite, error) {
+// returned will be of the 'lite' form without the raw and scrubbed bodies. If the query is paged, a cursor for
+// the next page will be returned unless this is the last one.
+func FindAlertsByDate(ctx context.Context, db *mgo.Database, start time.Time, end time.Time, q AlertQuery) ([]newshound.NewsAlertLite, string, error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-alerts-by-date")
 	defer span.End()
 
 	c := getNA(db)
+	find := c.Find(q.filter(start, end)).Select(alertLiteFields).Sort(sortFields(q.sortKeys(cursor{}))...)
+	if q.Paged() {
+		// grab an extra alert to see if there's another page
+		find = find.Limit(q.Limit + 1)
+	}
 	var alerts []newshound.NewsAlertLite
-	if err := c.Find(bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}}).All(&alerts); err != nil {
-		return alerts, err
+	if err := find.All(&alerts); err != nil {
+		return alerts, "", err
 	}
 
-	return alerts, nil
+	var next string
+	if q.Paged() && len(alerts) > q.Limit {
+		alerts = alerts[:q.Limit]
+		last := alerts[len(alerts)-1]
+		next = cursor{Time: last.Timestamp, ID: last.ID}.encode()
+	}
+	return alerts, next, nil
 }
 
 // FindByDate accepts a slice of News Alert IDs and returns a chronologically ordered list of
 // the 'lite' version of News Alerts.
-func FindOrderedAlerts(ctx context.Context, db *mgo.Database, alertIDs []string) ([]newshound.NewsAlertLite, error) {
+func FindOrderedAlerts(ctx context.Context, db *mgo.Database, alertIDs []bson.ObjectId) ([]newshound.NewsAlertLite, error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-ordered-alerts")
 	defer span.End()
 
-	var alertObjectIDs []bson.ObjectId
 	var alerts []newshound.NewsAlertLite
-	for _, alertID := range alertIDs {
-		alertObjectIDs = append(alertObjectIDs, bson.ObjectIdHex(alertID))
-	}
 	c := getNA(db)
-	if err := c.Find(bson.M{"_id": bson.M{"$in": alertObjectIDs}}).Sort("timestamp").All(&alerts); err != nil {
+	if err := c.Find(bson.M{"_id": bson.M{"$in": alertIDs}}).Select(alertLiteFields).Sort("timestamp").All(&alerts); err != nil {
 		return alerts, err
 	}
 
@@ -46,21 +91,49 @@ func FindOrderedAlerts(ctx context.Context, db *mgo.Database, alertIDs []string)
 }
 
 // FindAlertByID accepts a News Alert ID and returns the full version of that New Alert's information.
-func FindAlertByID(ctx context.Context, db *mgo.Database, alertID string) (newshound.NewsAlert, error) {
+// A NotFoundError is returned if the alert does not exist.
+func FindAlertByID(ctx context.Context, db *mgo.Database, alertID bson.ObjectId) (newshound.NewsAlert, error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-alerts-by-id")
 	defer span.End()
 
 	c := getNA(db)
 	var alert newshound.NewsAlert
-	if err := c.Find(bson.M{"_id": bson.ObjectIdHex(alertID)}).One(&alert); err != nil {
-		return alert, err
+	if err := c.FindId(alertID).One(&alert); err != nil {
+		return alert, notFound(err, "alert", alertID.Hex())
 	}
 
 	return alert, nil
 }
 
+// FindAlertRevisions accepts a News Alert ID and returns the chronologically ordered chain of
+// resends the alert is a part of, starting with the original alert. A NotFoundError is returned
+// if the alert does not exist.
+func FindAlertRevisions(ctx context.Context, db *mgo.Database, alertID bson.ObjectId) ([]newshound.NewsAlertLite, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-alert-revisions")
+	defer span.End()
+
+	c := getNA(db)
+	var alert newshound.NewsAlertLite
+	if err := c.FindId(alertID).Select(bson.M{"revision_of": 1}).One(&alert); err != nil {
+		return nil, notFound(err, "alert", alertID.Hex())
+	}
+
+	orig := alert.ID
+	if alert.RevisionOf != "" {
+		orig = alert.RevisionOf
+	}
+
+	var alerts []newshound.NewsAlertLite
+	query := bson.M{"$or": []bson.M{{"_id": orig}, {"revision_of": orig}}}
+	if err := c.Find(query).Select(alertLiteFields).Sort("timestamp").All(&alerts); err != nil {
+		return alerts, err
+	}
+
+	return alerts, nil
+}
+
 // FindAlertHtmlByID accepts a News Alert ID and just the body of the given News Alert.
-func FindAlertHtmlByID(ctx context.Context, db *mgo.Database, alertID string) (string, error) {
+func FindAlertHtmlByID(ctx context.Context, db *mgo.Database, alertID bson.ObjectId) (string, error) {
 	alert, err := FindAlertByID(ctx, db, alertID)
 	if err != nil {
 		return "", err
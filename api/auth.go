@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/export"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// client is who a request is made by.
+type client struct {
+	// id is the API key's ID or, for anonymous
+	// clients, their IP address.
+	id     string
+	scopes []string
+	limits Limits
+}
+
+func (c *client) can(scope string) bool {
+	for _, s := range c.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type clientKey struct{}
+
+// requestClient returns the client authorize found for a request, if any.
+func requestClient(r *http.Request) *client {
+	c, _ := r.Context().Value(clientKey{}).(*client)
+	return c
+}
+
+// credentials are what a request identifies its client with.
+type credentials struct {
+	adminKey string
+	apiKey   string
+	ip       string
+}
+
+func (s *service) requestCredentials(r *http.Request) credentials {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		key = r.URL.Query().Get("api_key")
+	}
+	return credentials{adminKey: r.Header.Get("X-Admin-Key"), apiKey: key, ip: clientIP(r, s.proxies)}
+}
+
+// initAuth sets up the API keys, anonymous client and rate limiter.
+func (s *service) initAuth(cfg newshound.AuthConfig) error {
+	for _, scope := range cfg.AnonymousScopes {
+		if !validScopes[scope] {
+			return errors.Errorf("invalid anonymous scope: %q", scope)
+		}
+	}
+
+	switch cfg.RateLimitStore {
+	case "memory":
+		s.limiter = NewMemoryLimiter()
+	case "mongo":
+		var err error
+		if s.limiter, err = NewMongoLimiter(s.sess); err != nil {
+			return errors.Wrap(err, "unable to init rate limiter")
+		}
+	default:
+		return errors.Errorf("unknown rate limit store: %q", cfg.RateLimitStore)
+	}
+
+	s.adminKey = cfg.AdminKey
+	s.requireKey = cfg.RequireAPIKey
+	if cfg.TrustedProxies < 0 {
+		return errors.Errorf("invalid trusted proxies: %d", cfg.TrustedProxies)
+	}
+	s.proxies = cfg.TrustedProxies
+	s.anonymous = client{
+		scopes: cfg.AnonymousScopes,
+		limits: Limits{PerMinute: cfg.AnonymousPerMinute, PerDay: cfg.AnonymousPerDay},
+	}
+	s.keys = newLRUCache(keyCacheTTL)
+	return nil
+}
+
+// Authorizer returns middleware that authorizes requests the same way the API
+// does for endpoints served elsewhere, like fetchd's event stream.
+func Authorizer(cfg newshound.AuthConfig, sess *mgo.Session) (func(http.Handler) http.Handler, error) {
+	s := &service{sess: sess}
+	if err := s.initAuth(cfg); err != nil {
+		return nil, err
+	}
+	return s.authorize, nil
+}
+
+// authorize identifies the client making each request by its API key, checks
+// it has the scope the request needs and counts the request against its
+// limits. Requests without a key are anonymous and limited by IP address
+// unless a key is required.
+func (s *service) authorize(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		c, err := s.findClient(r.Context(), s.requestCredentials(r))
+		if err != nil {
+			status, body, _ := failed(err, "find api key")
+			writeError(w, status, body)
+			return
+		}
+		if scope := requiredScope(r); !c.can(scope) {
+			writeError(w, http.StatusForbidden, scopeError(scope))
+			return
+		}
+		if d, ok := s.checkLimits(r.Context(), c); ok && !limit(w, c.limits, d) {
+			return
+		}
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, c)))
+	})
+}
+
+// findClient returns the client with the given credentials. An Error is
+// returned for unknown keys or a missing one if keys are required.
+func (s *service) findClient(ctx context.Context, creds credentials) (*client, error) {
+	if s.isAdminKey(creds.adminKey) {
+		return &client{id: "admin", scopes: []string{ScopeReadPublic, ScopeReadBodies, ScopeAdmin}}, nil
+	}
+
+	if creds.apiKey == "" {
+		if s.requireKey {
+			return nil, &Error{Status: http.StatusUnauthorized, Code: CodeUnauthorized,
+				Message: "an X-API-Key header or 'api_key' parameter is required"}
+		}
+		anon := s.anonymous
+		anon.id = "ip:" + creds.ip
+		return &anon, nil
+	}
+
+	k, err := s.findKey(ctx, creds.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, &Error{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Message: "invalid API key"}
+	}
+	return &client{
+		id:     k.ID.Hex(),
+		scopes: k.Scopes,
+		limits: Limits{PerMinute: k.PerMinute, PerDay: k.PerDay},
+	}, nil
+}
+
+// checkLimits counts a request against the client's limits. It returns false
+// if the limiter failed, in which case the request is let through so the
+// limiter can't take the API down with it.
+func (s *service) checkLimits(ctx context.Context, c *client) (Decision, bool) {
+	if s.limiter == nil {
+		return Decision{}, false
+	}
+	d, err := s.limiter.Allow(ctx, c.id, c.limits)
+	if err != nil {
+		log.Printf("unable to check rate limits - %s", err)
+		return Decision{}, false
+	}
+	return d, true
+}
+
+func scopeError(scope string) *Error {
+	return &Error{Status: http.StatusForbidden, Code: CodeForbidden, Message: "the " + scope + " scope is required"}
+}
+
+// limit sets the rate limit headers for a request and rejects it if the
+// client is over its limits. It returns whether the request may continue.
+func limit(w http.ResponseWriter, l Limits, d Decision) bool {
+	if l.PerMinute > 0 {
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(l.PerMinute))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(d.RemainingMinute))
+	}
+	if l.PerDay > 0 {
+		w.Header().Set("X-Quota-Limit", strconv.Itoa(l.PerDay))
+		w.Header().Set("X-Quota-Remaining", strconv.Itoa(d.RemainingDay))
+	}
+	if d.Allowed {
+		return true
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.RetryAfter.Seconds()))))
+	e := limitError(d)
+	writeError(w, e.Status, e)
+	return false
+}
+
+func limitError(d Decision) *Error {
+	if d.QuotaExceeded {
+		return &Error{Status: http.StatusTooManyRequests, Code: CodeQuotaExceeded, Message: "the daily request quota has been used up"}
+	}
+	return &Error{Status: http.StatusTooManyRequests, Code: CodeRateLimited, Message: "too many requests, slow down"}
+}
+
+// requiredScope returns the scope needed to make a request.
+func requiredScope(r *http.Request) string {
+	route, _ := apiRoute(r.URL.Path)
+	return routeScope(route, r.URL.Query())
+}
+
+// routeScope returns the scope needed to call a route, relative to the API's
+// version prefix, with the given query. Anything that returns the full body
+// of an alert needs read-bodies.
+func routeScope(route string, query url.Values) string {
+	switch {
+	case strings.HasPrefix(route, "/admin/"):
+		return ScopeAdmin
+	case strings.HasPrefix(route, "/alert_html/"):
+		return ScopeReadBodies
+	case strings.HasPrefix(route, "/alert/") && !strings.Contains(strings.TrimPrefix(route, "/alert/"), "/"):
+		return ScopeReadBodies
+	case strings.HasPrefix(route, "/export/"):
+		// bodies can be exported by default or asked for by name
+		opts := export.Options{Kind: strings.Split(route, "/")[2], Fields: exportFields(query)}
+		opts.Bodies, _ = strconv.ParseBool(query.Get("bodies"))
+		if opts.IncludesBodies() {
+			return ScopeReadBodies
+		}
+	}
+	return ScopeReadPublic
+}
+
+// clientIP returns the address a request came from. Behind the given number
+// of trusted proxies, that is the address that many entries from the end of
+// X-Forwarded-For since each proxy adds the one it got the request from.
+// Clients can set the earlier ones to anything, so without trusted proxies
+// the header is ignored.
+func clientIP(r *http.Request, proxies int) string {
+	if addrs := strings.Split(r.Header.Get("X-Forwarded-For"), ","); proxies > 0 && len(addrs) >= proxies {
+		if ip := strings.TrimSpace(addrs[len(addrs)-proxies]); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (s *service) isAdminKey(key string) bool {
+	return s.adminKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.adminKey)) == 1
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestDecide(t *testing.T) {
+	now := time.Date(2019, 6, 3, 14, 5, 30, 0, time.UTC)
+	tests := []struct {
+		limits      Limits
+		minute, day int
+		want        Decision
+	}{
+		{Limits{}, 100, 100, Decision{Allowed: true, RemainingMinute: -1, RemainingDay: -1}},
+		{Limits{PerMinute: 10}, 3, 3, Decision{Allowed: true, RemainingMinute: 7, RemainingDay: -1}},
+		{Limits{PerMinute: 10}, 10, 10, Decision{Allowed: true, RemainingMinute: 0, RemainingDay: -1}},
+		{Limits{PerMinute: 10}, 11, 11, Decision{RemainingMinute: 0, RemainingDay: -1, RetryAfter: 30 * time.Second}},
+		{Limits{PerMinute: 10, PerDay: 20}, 11, 21, Decision{QuotaExceeded: true, RetryAfter: 9*time.Hour + 54*time.Minute + 30*time.Second}},
+		{Limits{PerDay: 20}, 1, 5, Decision{Allowed: true, RemainingMinute: -1, RemainingDay: 15}},
+	}
+	for _, test := range tests {
+		if got := decide(test.limits, test.minute, test.day, now); got != test.want {
+			t.Errorf("decide(%+v, %d, %d) got:%+v want:%+v", test.limits, test.minute, test.day, got, test.want)
+		}
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2019, 6, 3, 23, 59, 0, 0, time.UTC)
+	l := &memoryLimiter{now: func() time.Time { return now }, usage: map[string]*usage{}}
+	ctx := context.Background()
+	limits := Limits{PerMinute: 2, PerDay: 3}
+
+	allow := func(client string) Decision {
+		d, err := l.Allow(ctx, client, limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	for i := 0; i < 2; i++ {
+		if d := allow("a"); !d.Allowed {
+			t.Fatalf("request %d got:%+v want allowed", i, d)
+		}
+	}
+	if d := allow("a"); d.Allowed || d.QuotaExceeded {
+		t.Errorf("third request got:%+v want rate limited", d)
+	}
+	if d := allow("b"); !d.Allowed {
+		t.Errorf("other client got:%+v want allowed", d)
+	}
+
+	now = now.Add(30 * time.Second)
+	if d := allow("a"); d.Allowed || !d.QuotaExceeded {
+		t.Errorf("next minute got:%+v want quota exceeded", d)
+	}
+
+	// a new day starts over
+	now = now.Add(time.Minute)
+	if d := allow("a"); !d.Allowed || d.RemainingDay != 2 || d.RemainingMinute != 1 {
+		t.Errorf("next day got:%+v want allowed with 2 left today", d)
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	const v1 = "/svc/newshound-api/v1"
+	tests := []struct {
+		path string
+		want string
+	}{
+		{v1 + "/find_alerts/2019-01-01/2019-01-31", ScopeReadPublic},
+		{v1 + "/alert/5b1d2d8e1c9d440000a1b2c3", ScopeReadBodies},
+		{v1 + "/alert/5b1d2d8e1c9d440000a1b2c3/revisions", ScopeReadPublic},
+		{v1 + "/alert_html/5b1d2d8e1c9d440000a1b2c3", ScopeReadBodies},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31", ScopeReadPublic},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31?bodies=true", ScopeReadBodies},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31?fields=id,body", ScopeReadBodies},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31?fields=id&fields=sentences", ScopeReadBodies},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31?fields=id,subject", ScopeReadPublic},
+		{v1 + "/export/events/2019-01-01/2019-01-31?bodies=true", ScopeReadPublic},
+		{v1 + "/admin/synonyms", ScopeAdmin},
+		{v1 + "/admin/keys/5b1d2d8e1c9d440000a1b2c3", ScopeAdmin},
+		{v2Prefix + "/alert/5b1d2d8e1c9d440000a1b2c3", ScopeReadBodies},
+		{v2Prefix + "/admin/synonyms", ScopeAdmin},
+		{v2Prefix + "/openapi.json", ScopeReadPublic},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", test.path, nil)
+		if got := requiredScope(r); got != test.want {
+			t.Errorf("requiredScope(%q) got:%q want:%q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		forwarded string
+		proxies   int
+		want      string
+	}{
+		// without trusted proxies the header is the client's to set
+		{"", 0, "192.0.2.1"},
+		{"10.0.0.1", 0, "192.0.2.1"},
+		{"1.2.3.4, 10.0.0.1", 0, "192.0.2.1"},
+
+		{"", 1, "192.0.2.1"},
+		{"10.0.0.1", 1, "10.0.0.1"},
+		{"1.2.3.4, 10.0.0.1", 1, "10.0.0.1"},
+		{"1.2.3.4,", 1, "192.0.2.1"},
+		{"6.6.6.6, 1.2.3.4, 10.0.0.1", 2, "1.2.3.4"},
+		{"1.2.3.4, 10.0.0.1", 2, "1.2.3.4"},
+		// fewer entries than proxies means the request skipped one
+		{"10.0.0.1", 2, "192.0.2.1"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		if test.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+		if got := clientIP(r, test.proxies); got != test.want {
+			t.Errorf("clientIP(%q, %d) got:%q want:%q", test.forwarded, test.proxies, got, test.want)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	now := time.Date(2019, 6, 3, 14, 5, 0, 0, time.UTC)
+	s := &service{
+		adminKey: "secret",
+		anonymous: client{
+			scopes: []string{ScopeReadPublic},
+			limits: Limits{PerMinute: 1},
+		},
+		proxies: 1,
+		keys:    newLRUCache(time.Minute),
+		limiter: &memoryLimiter{now: func() time.Time { return now }, usage: map[string]*usage{}},
+	}
+	s.keys.set(HashKey("reader"), &newshound.APIKey{
+		ID:     bson.NewObjectId(),
+		Scopes: []string{ScopeReadPublic, ScopeReadBodies},
+		PerDay: 1,
+	})
+	s.keys.set(HashKey("public"), &newshound.APIKey{ID: bson.NewObjectId(), Scopes: []string{ScopeReadPublic}})
+	s.keys.set(HashKey("admin"), &newshound.APIKey{ID: bson.NewObjectId(), Scopes: []string{ScopeAdmin}})
+	s.keys.set(HashKey("revoked"), (*newshound.APIKey)(nil))
+
+	var reached *client
+	h := s.authorize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = requestClient(r)
+	}))
+
+	const v1 = "/svc/newshound-api/v1"
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+		code    string
+	}{
+		{"anonymous", v1 + "/trending", nil, 200, ""},
+		{"anonymous bodies", v1 + "/alert_html/5b1d2d8e1c9d440000a1b2c3", map[string]string{"X-Forwarded-For": "10.0.0.1"}, 403, CodeForbidden},
+		{"anonymous rate limited", v1 + "/trending", nil, 429, CodeRateLimited},
+		{"anonymous other ip", v1 + "/trending", map[string]string{"X-Forwarded-For": "10.0.0.2, 10.0.0.3"}, 200, ""},
+		{"anonymous spoofed ip", v1 + "/trending", map[string]string{"X-Forwarded-For": "10.0.0.4, 10.0.0.3"}, 429, CodeRateLimited},
+		{"unknown key", v1 + "/trending", map[string]string{"X-API-Key": "revoked"}, 401, CodeUnauthorized},
+		{"unknown key param", v1 + "/trending?api_key=revoked", nil, 401, CodeUnauthorized},
+		{"key bodies", v1 + "/alert/5b1d2d8e1c9d440000a1b2c3", map[string]string{"X-API-Key": "reader"}, 200, ""},
+		{"public key bodies", v1 + "/export/alerts/2019-01-01/2019-01-31?fields=body", map[string]string{"X-API-Key": "public"}, 403, CodeForbidden},
+		{"public key export", v1 + "/export/alerts/2019-01-01/2019-01-31?fields=subject", map[string]string{"X-API-Key": "public"}, 200, ""},
+		{"key out of quota", v1 + "/trending?api_key=reader", nil, 429, CodeQuotaExceeded},
+		{"key without admin", v1 + "/admin/synonyms", map[string]string{"X-API-Key": "reader"}, 403, CodeForbidden},
+		{"admin key", v1 + "/admin/synonyms", map[string]string{"X-API-Key": "admin"}, 200, ""},
+		{"admin header", v1 + "/admin/synonyms", map[string]string{"X-Admin-Key": "secret"}, 200, ""},
+	}
+	for _, test := range tests {
+		reached = nil
+		r := httptest.NewRequest("GET", test.path, nil)
+		for k, v := range test.headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Errorf("%s got status:%d want:%d", test.name, w.Code, test.status)
+			continue
+		}
+		if test.status == http.StatusOK {
+			if reached == nil {
+				t.Errorf("%s never reached the endpoint with a client", test.name)
+			}
+			continue
+		}
+		var body Error
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil || body.Code != test.code {
+			t.Errorf("%s got body:%#v err:%v want code:%s", test.name, body, err, test.code)
+		}
+		if test.status == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Errorf("%s is missing Retry-After", test.name)
+		}
+	}
+
+	// without a trusted proxy, X-Forwarded-For can't get around the limits
+	s.proxies = 0
+	r := httptest.NewRequest("GET", v1+"/trending", nil)
+	r.Header.Set("X-Forwarded-For", "10.0.0.5")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("untrusted X-Forwarded-For got status:%d want:%d", w.Code, http.StatusTooManyRequests)
+	}
+
+	s.requireKey = true
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", v1+"/trending", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("required key got status:%d want:%d", w.Code, http.StatusUnauthorized)
+	}
+}
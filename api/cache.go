@@ -0,0 +1,71 @@
+package api
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries is the number of entries an lruCache will hold before it
+// starts evicting the least recently used ones.
+var maxCacheEntries = 500
+
+// lruCache holds values for a short time so repeated requests for the same
+// thing don't recompute them. Once full, the least recently used values
+// are evicted.
+type lruCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func newLRUCache(ttl time.Duration) *lruCache {
+	return &lruCache{ttl: ttl, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *lruCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *lruCache) set(key string, value interface{}) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := time.Now().Add(c.ttl)
+	if el, ok := c.entries[key]; ok {
+		el.Value = &cacheEntry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(el)
+		return
+	}
+	for len(c.entries) >= maxCacheEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"log"
+	"time"
 
+	"github.com/jprobinson/newshound"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/mgo.v2"
 )
@@ -11,6 +13,28 @@ type Config struct {
 	DBURL      string `envconfig:"DB_URL"`
 	DBUser     string `envconfig:"DB_USER"`
 	DBPassword string `envconfig:"DB_PASSWORD"`
+
+	// AuthConfig is how API keys are checked. fetchd's
+	// stream loads its own.
+	newshound.AuthConfig
+
+	// ReportCacheTTL is how long reports computed for custom
+	// date ranges are cached for.
+	ReportCacheTTL time.Duration `envconfig:"REPORT_CACHE_TTL" default:"10m"`
+
+	// ResponseCacheTTL is how long responses are kept in memory. They
+	// are only served while the data they were built from is unchanged.
+	ResponseCacheTTL time.Duration `envconfig:"RESPONSE_CACHE_TTL" default:"1h"`
+
+	// Timezone is the IANA time zone dates in requests are in
+	// unless a 'tz' query parameter is given. It should match fetchd's
+	// TIMEZONE, which stored reports are in. Defaults to UTC.
+	Timezone string `envconfig:"TIMEZONE"`
+
+	// EventsSubscription is a GCP Pub/Sub subscription to the fetch
+	// pipeline's events topic. It feeds the gRPC event stream, which is
+	// unavailable without it.
+	EventsSubscription string `envconfig:"EVENTS_SUBSCRIPTION"`
 }
 
 func NewConfig() *Config {
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// EntityInfo holds the usage of a single typed entity over a timeframe.
+type EntityInfo struct {
+	Name    string               `json:"name"`
+	Type    newshound.EntityType `json:"type"`
+	Alerts  int                  `json:"alerts"`
+	Senders []string             `json:"senders"`
+}
+
+// FindEntitiesByDate will accept a date range and return all entities mentioned in News Alerts
+// within it, ordered by the number of alerts mentioning them. If entityType is not empty, only
+// entities of that type will be returned.
+func FindEntitiesByDate(ctx context.Context, db *mgo.Database, start, end time.Time, entityType newshound.EntityType) ([]EntityInfo, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-entities-by-date")
+	defer span.End()
+
+	// resent alerts would double count their entities
+	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}, "revision_of": bson.M{"$exists": false}}
+	if entityType != "" {
+		query["entities.type"] = entityType
+	}
+
+	var alerts []newshound.NewsAlertLite
+	err := getNA(db).Find(query).Select(bson.M{"sender": 1, "entities": 1}).All(&alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := map[newshound.Entity]*EntityInfo{}
+	senders := map[newshound.Entity]map[string]struct{}{}
+	for _, alert := range alerts {
+		for _, e := range alert.Entities {
+			if entityType != "" && e.Type != entityType {
+				continue
+			}
+			info, ok := infos[e]
+			if !ok {
+				info = &EntityInfo{Name: e.Name, Type: e.Type}
+				infos[e] = info
+				senders[e] = map[string]struct{}{}
+			}
+			info.Alerts++
+			if _, seen := senders[e][alert.Sender]; !seen {
+				senders[e][alert.Sender] = struct{}{}
+				info.Senders = append(info.Senders, alert.Sender)
+			}
+		}
+	}
+
+	results := make([]EntityInfo, 0, len(infos))
+	for _, info := range infos {
+		sort.Strings(info.Senders)
+		results = append(results, *info)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Alerts == results[j].Alerts {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Alerts > results[j].Alerts
+	})
+	return results, nil
+}
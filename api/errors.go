@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound/search"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// The codes given in the body of failed requests.
+const (
+	CodeBadRequest    = "bad_request"
+	CodeInvalidID     = "invalid_id"
+	CodeInvalidDate   = "invalid_date"
+	CodeUnauthorized  = "unauthorized"
+	CodeForbidden     = "forbidden"
+	CodeNotFound      = "not_found"
+	CodeRateLimited   = "rate_limited"
+	CodeQuotaExceeded = "quota_exceeded"
+	CodeServerError   = "server_error"
+)
+
+// Error is the JSON body of every failed request.
+type Error struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// InvalidIDError is returned for alert, event and storyline IDs that are not
+// valid ObjectIds.
+type InvalidIDError struct {
+	Kind string
+	ID   string
+}
+
+func (e *InvalidIDError) Error() string {
+	return fmt.Sprintf("invalid %s id: %q", e.Kind, e.ID)
+}
+
+// InvalidDateError is returned for dates and date ranges that can't be used.
+type InvalidDateError struct {
+	Reason string
+}
+
+func (e *InvalidDateError) Error() string {
+	return e.Reason
+}
+
+// NotFoundError is returned by the query functions when the record being
+// looked up does not exist.
+type NotFoundError struct {
+	Kind string
+	ID   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %q not found", e.Kind, e.ID)
+}
+
+// parseID returns the ObjectId for the hex ID of a kind of record.
+func parseID(kind, id string) (bson.ObjectId, error) {
+	if !bson.IsObjectIdHex(id) {
+		return "", &InvalidIDError{Kind: kind, ID: id}
+	}
+	return bson.ObjectIdHex(id), nil
+}
+
+// notFound turns mgo.ErrNotFound into a NotFoundError for the record.
+// Other errors are returned as is.
+func notFound(err error, kind, id string) error {
+	if err == mgo.ErrNotFound {
+		return &NotFoundError{Kind: kind, ID: id}
+	}
+	return err
+}
+
+// apiError converts err into the Error that will be returned to the client.
+// Anything that isn't the client's fault becomes a generic server error.
+func apiError(err error) *Error {
+	switch e := err.(type) {
+	case *Error:
+		return e
+	case *InvalidIDError:
+		return &Error{Status: http.StatusBadRequest, Code: CodeInvalidID, Message: e.Error()}
+	case *InvalidDateError:
+		return &Error{Status: http.StatusBadRequest, Code: CodeInvalidDate, Message: e.Error()}
+	case *NotFoundError:
+		return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: e.Error()}
+	}
+	switch err {
+	case mgo.ErrNotFound:
+		return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: "not found"}
+	case search.ErrBadQuery:
+		return &Error{Status: http.StatusBadRequest, Code: CodeBadRequest, Message: err.Error()}
+	}
+	return &Error{Status: http.StatusInternalServerError, Code: CodeServerError, Message: "server error"}
+}
+
+// failed returns the JSON endpoint response for an error. Server errors are
+// logged along with what was being done when they happened.
+func failed(err error, doing string) (int, interface{}, error) {
+	e := apiError(err)
+	if e.Status == http.StatusInternalServerError {
+		log.Printf("unable to %s - %s", doing, err)
+	}
+	return e.Status, e, nil
+}
+
+// badRequest returns the JSON endpoint response for a request that could not
+// be parsed.
+func badRequest(err error) (int, interface{}, error) {
+	e := apiError(err)
+	if e.Status == http.StatusInternalServerError {
+		e = &Error{Status: http.StatusBadRequest, Code: CodeBadRequest, Message: err.Error()}
+	}
+	return e.Status, e, nil
+}
+
+// errorStatus returns the JSON endpoint response for a failure without an error to convert.
+func errorStatus(status int, code, message string) (int, interface{}, error) {
+	return status, &Error{Status: status, Code: code, Message: message}, nil
+}
+
+// writeError writes the JSON error response for plain http.Handlers.
+func writeError(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", server.JSONContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("unable to write error response - %s", err)
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound/search"
+	"gopkg.in/mgo.v2"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{&InvalidIDError{Kind: "alert", ID: "nope"}, http.StatusBadRequest, CodeInvalidID},
+		{&InvalidDateError{Reason: "bad date"}, http.StatusBadRequest, CodeInvalidDate},
+		{&NotFoundError{Kind: "event", ID: "5b1d2d8e1c9d440000a1b2c3"}, http.StatusNotFound, CodeNotFound},
+		{notFound(mgo.ErrNotFound, "sender", "cnn"), http.StatusNotFound, CodeNotFound},
+		{mgo.ErrNotFound, http.StatusNotFound, CodeNotFound},
+		{search.ErrBadQuery, http.StatusBadRequest, CodeBadRequest},
+		{&Error{Status: http.StatusForbidden, Code: CodeForbidden}, http.StatusForbidden, CodeForbidden},
+		{errors.New("no reachable servers"), http.StatusInternalServerError, CodeServerError},
+	}
+
+	for _, test := range tests {
+		got := apiError(test.err)
+		if got.Status != test.status || got.Code != test.code {
+			t.Errorf("apiError(%q) got:%d %s want:%d %s", test.err, got.Status, got.Code, test.status, test.code)
+		}
+		if got.Status == http.StatusInternalServerError && got.Message != "server error" {
+			t.Errorf("apiError(%q) leaked the error message: %q", test.err, got.Message)
+		}
+	}
+
+	if err := notFound(nil, "alert", "x"); err != nil {
+		t.Errorf("notFound(nil) got:%v want:nil", err)
+	}
+}
+
+// testRouter registers the service's endpoints the same way gizmo does.
+func testRouter(s *service) server.Router {
+	router := server.NewRouter(&server.Config{RouterType: "gorilla"})
+	for path, methods := range s.Endpoints() {
+		for method, ep := range methods {
+			router.Handle(method, path, ep)
+		}
+	}
+	for path, methods := range s.JSONEndpoints() {
+		for method, ep := range methods {
+			router.Handle(method, path, server.JSONToHTTP(s.JSONMiddleware(ep)))
+		}
+	}
+	return router
+}
+
+// missingEvents is a search.Backend stub where no events exist.
+type missingEvents struct{}
+
+func (missingEvents) Search(ctx context.Context, q search.Query) (search.Results, error) {
+	if q.EventID != "" {
+		return search.Results{}, mgo.ErrNotFound
+	}
+	return search.Results{Hits: []search.Hit{}}, nil
+}
+
+// TestEndpointErrors sends bad requests to every endpoint. They are all
+// rejected before touching the database, which the test service doesn't have,
+// or fail in the stub search backend.
+func TestEndpointErrors(t *testing.T) {
+	const (
+		v1      = "/svc/newshound-api/v1"
+		v2      = "/svc/newshound-api/v2"
+		badID   = "not-an-id"
+		eventID = "5b1d2d8e1c9d440000a1b2c3"
+	)
+	// these endpoints take no input so they can only fail in the database
+	noInput := map[string]bool{
+		v1 + "/trending":     true,
+		v1 + "/jobs":         true,
+		v2 + "/trending":     true,
+		v2 + "/jobs":         true,
+		v2 + "/openapi.json": true,
+	}
+
+	tests := []struct {
+		route  string
+		method string
+		path   string
+		body   string
+		admin  bool
+		status int
+		code   string
+	}{
+		{"/find_alerts/{start}/{end}", "GET", "/find_alerts/2019-13-01/2019-01-31", "", false, 400, CodeInvalidDate},
+		{"/find_alerts/{start}/{end}", "GET", "/find_alerts/2019-02-01/2019-01-31", "", false, 400, CodeInvalidDate},
+		{"/find_alerts/{start}/{end}", "GET", "/find_alerts/2019-01-01/2019-01-31?cursor=nope", "", false, 400, CodeBadRequest},
+		{"/ordered_alerts/{alert_ids}", "GET", "/ordered_alerts/" + eventID + "," + badID, "", false, 400, CodeInvalidID},
+		{"/alert/{alert_id}", "GET", "/alert/" + badID, "", false, 400, CodeInvalidID},
+		{"/alert/{alert_id}/revisions", "GET", "/alert/" + badID + "/revisions", "", false, 400, CodeInvalidID},
+		{"/alert_html/{alert_id}", "GET", "/alert_html/" + badID, "", false, 400, CodeInvalidID},
+		{"/export/{kind}/{start}/{end}", "GET", "/export/alerts/2019-01-01/tomorrow", "", false, 400, CodeInvalidDate},
+		{"/export/{kind}/{start}/{end}", "GET", "/export/widgets/2019-01-01/2019-01-31", "", false, 400, CodeBadRequest},
+		{"/feed/{format}/events", "GET", "/feed/html/events", "", false, 400, CodeBadRequest},
+		{"/feed/{format}/events", "GET", "/feed/rss/events?min_importance=high", "", false, 400, CodeBadRequest},
+		{"/feed/{format}/sender/{sender}", "GET", "/feed/xml/sender/cnn", "", false, 400, CodeBadRequest},
+		{"/feed/{format}/tag/{tag}", "GET", "/feed/atom/tag/obama?has_article_url=sometimes", "", false, 400, CodeBadRequest},
+		{"/find_events/{start}/{end}", "GET", "/find_events/yesterday/2019-01-31", "", false, 400, CodeInvalidDate},
+		{"/find_events/{start}/{end}", "GET", "/find_events/2019-01-01/2019-01-31?min_alerts=lots", "", false, 400, CodeBadRequest},
+		{"/event_feed/{start}/{end}", "GET", "/event_feed/2019-01-01/2019-01-31?sort=random", "", false, 400, CodeBadRequest},
+		{"/event/{event_id}", "GET", "/event/" + badID, "", false, 400, CodeInvalidID},
+		{"/event/{event_id}/storyline", "GET", "/event/" + badID + "/storyline", "", false, 400, CodeInvalidID},
+		{"/find_storylines/{start}/{end}", "GET", "/find_storylines/2019-01-01/2019-1-31", "", false, 400, CodeInvalidDate},
+		{"/storyline/{storyline_id}", "GET", "/storyline/" + badID, "", false, 400, CodeInvalidID},
+		{"/find_entities/{start}/{end}", "GET", "/find_entities/2019-01-01/2019-01-31?type=planet", "", false, 400, CodeBadRequest},
+		{"/search/{kind}", "GET", "/search/alerts", "", false, 400, CodeBadRequest},
+		{"/search/{kind}", "GET", "/search/alerts?q=obama&event=" + badID, "", false, 400, CodeInvalidID},
+		{"/search/{kind}", "GET", "/search/alerts?q=obama&event=" + eventID, "", false, 404, CodeNotFound},
+		{"/report/alerts_per_week", "GET", "/report/alerts_per_week?start=2019-01-01&end=soon", "", false, 400, CodeInvalidDate},
+		{"/report/events_per_week", "GET", "/report/events_per_week?tz=Mars/Olympus_Mons", "", false, 400, CodeBadRequest},
+		{"/report/event_attendance", "GET", "/report/event_attendance?start=2019-01-01", "", false, 400, CodeInvalidDate},
+		{"/report/event_attendance", "GET", "/report/event_attendance?tz=America/New_York", "", false, 400, CodeInvalidDate},
+		{"/report/sender_info/{sender}", "GET", "/report/sender_info/cnn?start=2019-02-01&end=2019-01-01", "", false, 400, CodeInvalidDate},
+		{"/report/sender_heatmap/{sender}", "GET", "/report/sender_heatmap/cnn?tz=Nowhere", "", false, 400, CodeBadRequest},
+		{"/report/tag_trend/{tag}", "GET", "/report/tag_trend/obama?period=month", "", false, 400, CodeBadRequest},
+		{"/report/tag_movers", "GET", "/report/tag_movers?limit=0", "", false, 400, CodeBadRequest},
+		{"/report/tag_cooccurrence/{tag}", "GET", "/report/tag_cooccurrence/obama?entities=maybe", "", false, 400, CodeBadRequest},
+		{"/report/compare/{start}/{end}", "GET", "/report/compare/2019-01-01/2019-01-31?sender=cnn", "", false, 400, CodeBadRequest},
+		{"/report/compare/{start}/{end}", "GET", "/report/compare/2019-01-01/later?sender=cnn&sender=fox", "", false, 400, CodeInvalidDate},
+		{"/report/scoop_leaderboard/{timeframe}", "GET", "/report/scoop_leaderboard/forever", "", false, 400, CodeBadRequest},
+		{"/report/event_scoop/{event_id}", "GET", "/report/event_scoop/" + badID, "", false, 400, CodeInvalidID},
+		{"/jobs/{job}/runs", "GET", "/jobs/rebuild_reports/runs?limit=1000", "", false, 400, CodeBadRequest},
+		{"/admin/synonyms", "GET", "/admin/synonyms", "", false, 403, CodeForbidden},
+		{"/admin/synonyms/{tag}", "PUT", "/admin/synonyms/potus", `{"canonical":"president"}`, false, 403, CodeForbidden},
+		{"/admin/synonyms/{tag}", "PUT", "/admin/synonyms/potus", `{"canonical":`, true, 400, CodeBadRequest},
+		{"/admin/synonyms/{tag}", "PUT", "/admin/synonyms/potus", `{"canonical":"POTUS"}`, true, 400, CodeBadRequest},
+		{"/admin/synonyms/{tag}", "DELETE", "/admin/synonyms/potus", "", false, 403, CodeForbidden},
+		{"/admin/keys", "GET", "/admin/keys", "", false, 403, CodeForbidden},
+		{"/admin/keys", "POST", "/admin/keys", `{"name":"app","scopes":["read-public"]}`, false, 403, CodeForbidden},
+		{"/admin/keys", "POST", "/admin/keys", `{"name":"app"}`, true, 400, CodeBadRequest},
+		{"/admin/keys", "POST", "/admin/keys", `{"name":"app","scopes":["write"]}`, true, 400, CodeBadRequest},
+		{"/admin/keys", "POST", "/admin/keys", `{"name":"app","scopes":["read-public"],"per_day":-1}`, true, 400, CodeBadRequest},
+		{"/admin/keys/{key_id}", "DELETE", "/admin/keys/" + eventID, "", false, 403, CodeForbidden},
+		{"/admin/keys/{key_id}", "DELETE", "/admin/keys/" + badID, "", true, 400, CodeInvalidID},
+	}
+
+	s := &service{loc: time.Local, adminKey: "secret", searcher: missingEvents{}}
+	router := testRouter(s)
+	jsonRoutes := s.v1JSONEndpoints()
+	tested := map[string]bool{}
+	for _, prefix := range []string{v1, v2} {
+		for _, test := range tests {
+			tested[test.method+" "+prefix+test.route] = true
+
+			r := httptest.NewRequest(test.method, prefix+test.path, strings.NewReader(test.body))
+			if test.admin {
+				r.Header.Set("X-Admin-Key", "secret")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != test.status {
+				t.Errorf("%s %s%s got status:%d want:%d", test.method, prefix, test.path, w.Code, test.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != server.JSONContentType {
+				t.Errorf("%s %s%s got content type:%q want:%q", test.method, prefix, test.path, ct, server.JSONContentType)
+			}
+
+			// v2 JSON endpoints wrap their errors in an Envelope
+			var (
+				body Error
+				env              = Envelope{Error: &body}
+				dst  interface{} = &body
+			)
+			if _, ok := jsonRoutes[v1+test.route]; ok && prefix == v2 {
+				dst = &env
+			}
+			if err := json.NewDecoder(w.Body).Decode(dst); err != nil {
+				t.Errorf("%s %s%s returned an invalid error body: %s", test.method, prefix, test.path, err)
+				continue
+			}
+			if body.Status != test.status || body.Code != test.code || body.Message == "" {
+				t.Errorf("%s %s%s got body:%#v want status:%d code:%s", test.method, prefix, test.path, body, test.status, test.code)
+			}
+		}
+	}
+
+	var routes []string
+	for path, methods := range s.Endpoints() {
+		for method := range methods {
+			routes = append(routes, method+" "+path)
+		}
+	}
+	for path, methods := range s.JSONEndpoints() {
+		for method := range methods {
+			routes = append(routes, method+" "+path)
+		}
+	}
+	for _, route := range routes {
+		if !tested[route] && !noInput[strings.SplitN(route, " ", 2)[1]] {
+			t.Errorf("%s has no error tests", route)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/jprobinson/newshound"
@@ -11,43 +12,129 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
+// EventQuery holds the optional filters, sorting and paging for News Event lookups.
+type EventQuery struct {
+	// MinImportance will exclude any events with a lower importance score.
+	MinImportance float64
+	// ByImportance will order events by importance desc before their usual time ordering.
+	ByImportance bool
+	// Senders limits events to those including an alert from any of the senders.
+	Senders []string
+	// Tags limits events to those with all of the tags.
+	Tags []string
+	// MinAlerts will exclude any events with fewer alerts.
+	MinAlerts int
+	// HasArticleURL, if set, limits events to those with or without an
+	// alert linking to an article.
+	HasArticleURL *bool
+
+	PageQuery
+}
+
+// importanceSort is the cursor sort name for events ordered by importance.
+const importanceSort = "importance"
+
+func (q EventQuery) filter(start, end time.Time, reverse bool) bson.M {
+	query := q.match()
+	query["event_start"] = bson.M{"$gte": start, "$lte": end}
+	if q.After != nil {
+		query["$or"] = afterKeys(q.sortKeys(*q.After, reverse))
+	}
+	return query
+}
+
+// match returns the query for the filters alone, without any dates or paging.
+func (q EventQuery) match() bson.M {
+	query := bson.M{}
+	if q.MinImportance > 0 {
+		query["importance"] = bson.M{"$gte": q.MinImportance}
+	}
+	if len(q.Senders) > 0 {
+		query["news_alerts.sender"] = bson.M{"$in": q.Senders}
+	}
+	if len(q.Tags) > 0 {
+		query["tags"] = bson.M{"$all": q.Tags}
+	}
+	if q.MinAlerts > 1 {
+		// the event has at least as many alerts if the last one we need exists
+		query["news_alerts."+strconv.Itoa(q.MinAlerts-1)] = bson.M{"$exists": true}
+	}
+	if q.HasArticleURL != nil {
+		query["news_alerts.article_url"] = hasValue(*q.HasArticleURL)
+	}
+	return query
+}
+
+func (q EventQuery) sortKeys(c cursor, reverse bool) []sortKey {
+	keys := []sortKey{{"event_start", reverse, c.Time}, {"_id", reverse, c.ID}}
+	if q.ByImportance {
+		return append([]sortKey{{"importance", true, c.Importance}}, keys...)
+	}
+	return keys
+}
+
+// validCursor reports whether the query's cursor came from a query with the same sort.
+func (q EventQuery) validCursor() bool {
+	return q.After == nil || (q.After.Sort == importanceSort) == q.ByImportance
+}
+
+func (q EventQuery) cursor(event newshound.NewsEvent) cursor {
+	c := cursor{Time: event.EventStart, ID: event.ID}
+	if q.ByImportance {
+		c.Sort = importanceSort
+		c.Importance = event.Importance
+	}
+	return c
+}
+
 // FindByDate accepts a start and end date and returns all the News Events that occured in that timeframe.
-func FindEventsByDate(ctx context.Context, db *mgo.Database, start time.Time, end time.Time) (events []newshound.NewsEvent, err error) {
+// If the query is paged, a cursor for the next page will be returned unless this is the last one.
+func FindEventsByDate(ctx context.Context, db *mgo.Database, start time.Time, end time.Time, q EventQuery) ([]newshound.NewsEvent, string, error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-events-by-date")
 	defer span.End()
 
-	c := getNE(db)
-	err = c.Find(bson.M{"event_start": bson.M{"$gte": start, "$lte": end}}).All(&events)
-	if err != nil {
-		return
-	}
-
-	return
+	return findEvents(ctx, db, start, end, q, false)
 }
 
 // FindByDateReverse accepts a start and end date and returns all the News Events that occured in that timeframe order by time desc.
-func FindEventsByDateReverse(ctx context.Context, db *mgo.Database, start time.Time, end time.Time) (events []newshound.NewsEvent, err error) {
+// If the query is paged, a cursor for the next page will be returned unless this is the last one.
+func FindEventsByDateReverse(ctx context.Context, db *mgo.Database, start time.Time, end time.Time, q EventQuery) ([]newshound.NewsEvent, string, error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-events-by-date-reverse")
 	defer span.End()
 
+	return findEvents(ctx, db, start, end, q, true)
+}
+
+func findEvents(ctx context.Context, db *mgo.Database, start, end time.Time, q EventQuery, reverse bool) ([]newshound.NewsEvent, string, error) {
 	c := getNE(db)
-	err = c.Find(bson.M{"event_start": bson.M{"$gte": start, "$lte": end}}).Sort("-event_start").All(&events)
-	if err != nil {
-		return
+	find := c.Find(q.filter(start, end, reverse)).Sort(sortFields(q.sortKeys(cursor{}, reverse))...)
+	if q.Paged() {
+		// grab an extra event to see if there's another page
+		find = find.Limit(q.Limit + 1)
+	}
+	var events []newshound.NewsEvent
+	if err := find.All(&events); err != nil {
+		return events, "", err
 	}
 
-	return
+	var next string
+	if q.Paged() && len(events) > q.Limit {
+		events = events[:q.Limit]
+		next = q.cursor(events[len(events)-1]).encode()
+	}
+	return events, next, nil
 }
 
 // FindEventByID accepts a News Event ID and returns the full information for that Event.
-func FindEventByID(ctx context.Context, db *mgo.Database, eventID string) (event newshound.NewsEvent, err error) {
+// A NotFoundError is returned if the event does not exist.
+func FindEventByID(ctx context.Context, db *mgo.Database, eventID bson.ObjectId) (event newshound.NewsEvent, err error) {
 	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-events-by-id")
 	defer span.End()
 
 	c := getNE(db)
-	err = c.Find(bson.M{"_id": bson.ObjectIdHex(eventID)}).One(&event)
+	err = c.FindId(eventID).One(&event)
 	if err != nil {
-		return
+		return event, notFound(err, "event", eventID.Hex())
 	}
 
 	return
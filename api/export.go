@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound/export"
+)
+
+// exportRecords is an http.Handler that expects a kind ('alerts', 'events' or 'memberships')
+// and a 'start' and 'end' date in the URL and will stream every record in that timeframe.
+// The optional 'format' ('csv', 'ndjson' or 'parquet'), 'fields' and 'bodies' query
+// parameters control the output.
+func (s *service) exportRecords(w http.ResponseWriter, r *http.Request) {
+	vars := server.Vars(r)
+	opts, err := s.parseExportOptions(r, vars)
+	if err != nil {
+		status, body, _ := badRequest(err)
+		writeError(w, status, body)
+		return
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	w.Header().Set("Content-Type", export.ContentType(opts.Format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q",
+		fmt.Sprintf("newshound-%s-%s-%s.%s", opts.Kind, vars["start"], vars["end"], opts.Format)))
+
+	// headers are already sent, so a failure can only cut the export short
+	if _, err = export.Export(r.Context(), db, opts, w); err != nil {
+		log.Printf("unable to export %s - %s", opts.Kind, err)
+	}
+}
+
+// parseExportOptions pulls the export's kind and date range from the URL along with
+// its optional format (CSV by default), comma separated fields and bodies flag.
+func (s *service) parseExportOptions(r *http.Request, vars map[string]string) (export.Options, error) {
+	qs := r.URL.Query()
+	opts := export.Options{Kind: vars["kind"], Format: qs.Get("format")}
+	if opts.Format == "" {
+		opts.Format = export.CSV
+	}
+
+	start, end, err := s.parseDateRange(r, vars)
+	if err != nil {
+		return opts, err
+	}
+	opts.Start = start
+	// the end date is inclusive, so run through to midnight
+	opts.End = end.Add(time.Millisecond)
+
+	opts.Fields = exportFields(qs)
+	if b := qs.Get("bodies"); b != "" {
+		if opts.Bodies, err = strconv.ParseBool(b); err != nil {
+			return opts, err
+		}
+	}
+	return opts, opts.Validate()
+}
+
+// exportFields returns the fields from the repeated or comma separated 'fields'
+// query parameters.
+func exportFields(qs url.Values) []string {
+	var fields []string
+	for _, param := range qs["fields"] {
+		for _, field := range strings.Split(param, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
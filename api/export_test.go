@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound/export"
+)
+
+func TestParseExportOptions(t *testing.T) {
+	tests := []struct {
+		kind    string
+		query   string
+		want    export.Options
+		wantErr bool
+	}{
+		{
+			"alerts", "",
+			export.Options{
+				Kind:   export.Alerts,
+				Format: export.CSV,
+				Start:  time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+			false,
+		},
+		{
+			"memberships", "?format=parquet&fields=event_id,sender&fields=order",
+			export.Options{
+				Kind:   export.Memberships,
+				Format: export.Parquet,
+				Start:  time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+				Fields: []string{"event_id", "sender", "order"},
+			},
+			false,
+		},
+		{
+			"alerts", "?format=ndjson&bodies=true",
+			export.Options{
+				Kind:   export.Alerts,
+				Format: export.NDJSON,
+				Start:  time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+				Bodies: true,
+			},
+			false,
+		},
+		{"storylines", "", export.Options{}, true},
+		{"alerts", "?format=xml", export.Options{}, true},
+		{"events", "?fields=body", export.Options{}, true},
+		{"alerts", "?bodies=please", export.Options{}, true},
+	}
+
+	s := &service{loc: time.UTC}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/export/"+test.kind+"/2018-06-01/2018-06-30"+test.query, nil)
+		vars := map[string]string{"kind": test.kind, "start": "2018-06-01", "end": "2018-06-30"}
+		got, err := s.parseExportOptions(r, vars)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseExportOptions(%s%s) expected an error", test.kind, test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseExportOptions(%s%s) returned an error: %s", test.kind, test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseExportOptions(%s%s) got:%#v want:%#v", test.kind, test.query, got, test.want)
+		}
+	}
+}
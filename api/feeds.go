@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/feed"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// feedItems is the number of the newest alerts or events included in a feed.
+var feedItems = 50
+
+// FindFeedAlerts returns the newest alerts from the sender, newest first.
+// A NotFoundError is returned if the sender has never sent an alert.
+func FindFeedAlerts(ctx context.Context, db *mgo.Database, sender string, limit int) ([]newshound.NewsAlertLite, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-feed-alerts")
+	defer span.End()
+
+	var alerts []newshound.NewsAlertLite
+	err := getNA(db).Find(bson.M{"sender": sender}).Select(alertLiteFields).
+		Sort("-timestamp", "-_id").Limit(limit).All(&alerts)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return nil, &NotFoundError{Kind: "sender", ID: sender}
+	}
+	return alerts, nil
+}
+
+// FindFeedEvents returns the newest events matching the query's filters, newest
+// first. The query's sorting and paging are ignored.
+func FindFeedEvents(ctx context.Context, db *mgo.Database, q EventQuery, limit int) ([]newshound.NewsEvent, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-feed-events")
+	defer span.End()
+
+	var events []newshound.NewsEvent
+	err := getNE(db).Find(q.match()).Sort("-event_start", "-_id").Limit(limit).All(&events)
+	return events, err
+}
+
+// eventsFeed is an http.Handler that expects a feed format ('rss', 'atom' or 'json')
+// in the URL and will return a feed of the newest News Events. It accepts the
+// same filters as eventFeed.
+func (s *service) eventsFeed(w http.ResponseWriter, r *http.Request) {
+	format := server.Vars(r)["format"]
+	q, err := parseEventQuery(r)
+	if err != nil || !feed.ValidFormat(format) {
+		feedError(w, err, format)
+		return
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	events, err := FindFeedEvents(r.Context(), db, q, feedItems)
+	if err != nil {
+		status, body, _ := failed(err, "access events feed")
+		writeError(w, status, body)
+		return
+	}
+
+	f := feed.Feed{
+		Title:       "Newshound: News Events",
+		Description: "News Events detected across breaking news alerts",
+		Link:        newshound.WebURL + "/#/calendar?display=events",
+		Self:        requestURL(r),
+	}
+	for _, event := range events {
+		f.Items = append(f.Items, feed.EventItem(event))
+	}
+	writeFeed(w, r, f, format)
+}
+
+// senderFeed is an http.Handler that expects a feed format and a sender in the URL
+// and will return a feed of the sender's newest News Alerts.
+func (s *service) senderFeed(w http.ResponseWriter, r *http.Request) {
+	vars := server.Vars(r)
+	format, sender := vars["format"], vars["sender"]
+	if !feed.ValidFormat(format) {
+		feedError(w, nil, format)
+		return
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	alerts, err := FindFeedAlerts(r.Context(), db, sender, feedItems)
+	if err != nil {
+		status, body, _ := failed(err, "access sender feed")
+		writeError(w, status, body)
+		return
+	}
+
+	f := feed.Feed{
+		Title:       "Newshound: " + sender,
+		Description: "Breaking news alerts from " + sender,
+		Link:        newshound.WebURL + "/#/sender/" + url.PathEscape(sender),
+		Self:        requestURL(r),
+	}
+	for _, alert := range alerts {
+		f.Items = append(f.Items, feed.AlertItem(alert))
+	}
+	writeFeed(w, r, f, format)
+}
+
+// tagFeed is an http.Handler that expects a feed format and a tag in the URL and
+// will return a feed of the newest News Events with the tag. It accepts the same
+// filters as eventFeed.
+func (s *service) tagFeed(w http.ResponseWriter, r *http.Request) {
+	vars := server.Vars(r)
+	format, tag := vars["format"], vars["tag"]
+	q, err := parseEventQuery(r)
+	if err != nil || !feed.ValidFormat(format) {
+		feedError(w, err, format)
+		return
+	}
+	q.Tags = append(q.Tags, tag)
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	events, err := FindFeedEvents(r.Context(), db, q, feedItems)
+	if err != nil {
+		status, body, _ := failed(err, "access tag feed")
+		writeError(w, status, body)
+		return
+	}
+
+	f := feed.Feed{
+		Title:       "Newshound: " + tag,
+		Description: "News Events about " + tag,
+		Link:        newshound.WebURL + "/#/calendar?display=events",
+		Self:        requestURL(r),
+	}
+	for _, event := range events {
+		f.Items = append(f.Items, feed.EventItem(event))
+	}
+	writeFeed(w, r, f, format)
+}
+
+// feedError writes the response for a feed request with a bad format or query.
+func feedError(w http.ResponseWriter, err error, format string) {
+	if err == nil {
+		err = fmt.Errorf("invalid feed format: %q", format)
+	}
+	status, body, _ := badRequest(err)
+	writeError(w, status, body)
+}
+
+// writeFeed renders the feed and serves it with an ETag and a Last-Modified
+// time so feed readers can make conditional requests.
+func writeFeed(w http.ResponseWriter, r *http.Request, f feed.Feed, format string) {
+	var buf bytes.Buffer
+	if err := feed.Write(&buf, f, format); err != nil {
+		status, body, _ := failed(err, "render "+format+" feed")
+		writeError(w, status, body)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.ContentType(format))
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sha1.Sum(buf.Bytes())))
+	// ServeContent answers If-None-Match and If-Modified-Since with a 304
+	http.ServeContent(w, r, "", f.Updated(), bytes.NewReader(buf.Bytes()))
+}
+
+// requestURL rebuilds the absolute URL the request was made to.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound/feed"
+)
+
+func TestWriteFeed(t *testing.T) {
+	updated := time.Date(2019, 6, 3, 14, 25, 0, 0, time.UTC)
+	f := feed.Feed{
+		Title: "Newshound: cnn.com",
+		Items: []feed.Item{{ID: "urn:newshound:alert:1", Title: "Breaking", Published: updated, Updated: updated}},
+	}
+	path := "/svc/newshound-api/v1/feed/atom/sender/cnn.com"
+
+	w := httptest.NewRecorder()
+	writeFeed(w, httptest.NewRequest("GET", path, nil), f, feed.Atom)
+	if w.Code != http.StatusOK {
+		t.Fatalf("writeFeed got status:%d want:%d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != feed.ContentType(feed.Atom) {
+		t.Errorf("writeFeed got content type:%q want:%q", ct, feed.ContentType(feed.Atom))
+	}
+	if lm := w.Header().Get("Last-Modified"); lm != "Mon, 03 Jun 2019 14:25:00 GMT" {
+		t.Errorf("writeFeed got Last-Modified:%q", lm)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("writeFeed did not set an ETag")
+	}
+
+	tests := []struct {
+		header, value string
+		want          int
+	}{
+		{"If-None-Match", etag, http.StatusNotModified},
+		{"If-None-Match", `"stale"`, http.StatusOK},
+		{"If-Modified-Since", "Mon, 03 Jun 2019 14:25:00 GMT", http.StatusNotModified},
+		{"If-Modified-Since", "Mon, 03 Jun 2019 14:24:59 GMT", http.StatusOK},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set(test.header, test.value)
+		w := httptest.NewRecorder()
+		writeFeed(w, r, f, feed.Atom)
+		if w.Code != test.want {
+			t.Errorf("writeFeed with %s: %s got status:%d want:%d", test.header, test.value, w.Code, test.want)
+		}
+	}
+}
+
+func TestRequestURL(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://newshound.email/svc/newshound-api/v1/feed/rss/events?min_importance=50", nil)
+	if got, want := requestURL(r), "http://newshound.email/svc/newshound-api/v1/feed/rss/events?min_importance=50"; got != want {
+		t.Errorf("requestURL got:%q want:%q", got, want)
+	}
+	r.Header.Set("X-Forwarded-Proto", "https")
+	if got, want := requestURL(r), "https://newshound.email/svc/newshound-api/v1/feed/rss/events?min_importance=50"; got != want {
+		t.Errorf("requestURL got:%q want:%q", got, want)
+	}
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+)
+
+// versionTTL is how long the data versions are cached before checking for
+// new ones. It's the longest a cached response can outlive its data.
+var versionTTL = 10 * time.Second
+
+// settleAfter is how long after a date range ends that its alerts, events and
+// reports are considered settled. Responses for settled ranges rarely change,
+// though re-canonicalized tags and rebuilt reports can still change them.
+var settleAfter = 72 * time.Hour
+
+// settledMaxAge is how long responses for settled ranges can be cached.
+var settledMaxAge = time.Hour
+
+// maxCachedBody is the largest response that will be kept in memory.
+var maxCachedBody = 1 << 20
+
+// cachePolicy is how responses from an endpoint can be cached.
+type cachePolicy struct {
+	// versions are the data versions the responses are built from.
+	versions []string
+	maxAge   time.Duration
+	// endSegment is the index of the path segment holding the
+	// end date of the range the endpoint covers, if it has one.
+	endSegment int
+	// bodies is set when responses depend on whether
+	// the client can read alert bodies.
+	bodies bool
+}
+
+var (
+	alertVersions  = []string{newshound.AlertsVersion}
+	eventVersions  = []string{newshound.EventsVersion}
+	recordVersions = []string{newshound.AlertsVersion, newshound.EventsVersion}
+	reportVersions = []string{newshound.AlertsVersion, newshound.EventsVersion, newshound.ReportsVersion}
+)
+
+// cachePolicies are keyed by the first path segment after the API prefix.
+// Job status and admin endpoints are never cached. Feeds are left out since
+// writeFeed sets validators from the feed itself for feed readers.
+var cachePolicies = map[string]cachePolicy{
+	"find_alerts":     {alertVersions, time.Minute, 2, false},
+	"find_entities":   {alertVersions, time.Minute, 2, false},
+	"ordered_alerts":  {alertVersions, 5 * time.Minute, 0, false},
+	"alert":           {alertVersions, 5 * time.Minute, 0, false},
+	"alert_html":      {alertVersions, 5 * time.Minute, 0, false},
+	"trending":        {alertVersions, time.Minute, 0, false},
+	"find_events":     {eventVersions, time.Minute, 2, false},
+	"event_feed":      {eventVersions, time.Minute, 2, false},
+	"find_storylines": {eventVersions, time.Minute, 2, false},
+	"event":           {eventVersions, time.Minute, 0, false},
+	"storyline":       {eventVersions, time.Minute, 0, false},
+	"export":          {recordVersions, time.Minute, 3, false},
+	"search":          {recordVersions, time.Minute, 0, true},
+	"report":          {reportVersions, 5 * time.Minute, 0, false},
+}
+
+// findCachePolicy returns the cache policy for a request along with the end
+// date of the range it covers, if any.
+func findCachePolicy(r *http.Request) (cachePolicy, string, bool) {
+	route, ok := apiRoute(r.URL.Path)
+	if !ok {
+		return cachePolicy{}, "", false
+	}
+	segments := strings.Split(strings.TrimPrefix(route, "/"), "/")
+	policy, ok := cachePolicies[segments[0]]
+	if !ok {
+		return policy, "", false
+	}
+
+	var end string
+	switch {
+	case policy.endSegment > 0 && policy.endSegment < len(segments):
+		end = segments[policy.endSegment]
+	case segments[0] == "report" && len(segments) > 3 && segments[1] == "compare":
+		end = segments[3]
+	case segments[0] == "report" && r.URL.Query().Get("start") != "":
+		end = r.URL.Query().Get("end")
+	}
+	return policy, end, true
+}
+
+// settled reports whether the YYYY-MM-DD end date is far enough in the past
+// that nothing in its range will change.
+func settled(end string, now time.Time) bool {
+	t, err := time.Parse(dateLayout, end)
+	if err != nil {
+		return false
+	}
+	return now.Sub(t) > settleAfter
+}
+
+// cache adds ETag, Last-Modified and Cache-Control headers to the responses of
+// cacheable endpoints, answers conditional requests with 304s and keeps recent
+// responses in memory. ETags are built from the data versions fetchd bumps as
+// it saves alerts, events and reports, so they change along with the data.
+func (s *service) cache(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, end, ok := findCachePolicy(r)
+		if !ok || r.Method != http.MethodGet {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		visibility := "public"
+		if requiredScope(r) != ScopeReadPublic {
+			visibility = "private"
+		}
+		key := r.URL.RequestURI()
+		if policy.bodies {
+			// keep shared caches from handing one client's
+			// alert bodies to another
+			visibility = "private"
+			w.Header().Add("Vary", "X-API-Key")
+			if canReadBodies(r) {
+				key += " bodies"
+			} else {
+				key += " no-bodies"
+			}
+		}
+
+		versions, err := s.findVersions(r.Context())
+		if err != nil {
+			log.Printf("unable to find data versions - %s", err)
+			h.ServeHTTP(w, r)
+			return
+		}
+		var (
+			version  string
+			modified time.Time
+		)
+		for _, name := range policy.versions {
+			v := versions[name]
+			version += v.Version.Hex()
+			if v.Updated.After(modified) {
+				modified = v.Updated
+			}
+		}
+		maxAge := policy.maxAge
+		if settled(end, time.Now()) {
+			maxAge = settledMaxAge
+		}
+		control := fmt.Sprintf("%s, max-age=%d", visibility, int(maxAge.Seconds()))
+		sum := sha1.Sum([]byte(version + " " + key))
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", control)
+		if !modified.IsZero() {
+			w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+		}
+		if notModified(r, etag, modified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if cached, ok := s.responses.get(etag); ok {
+			cached.(*cachedResponse).write(w)
+			return
+		}
+
+		cw := &cacheWriter{ResponseWriter: w}
+		h.ServeHTTP(cw, r)
+		if cw.status == http.StatusOK && !cw.overflow {
+			cached := &cachedResponse{header: http.Header{}, body: cw.body.Bytes()}
+			for _, k := range cachedHeaders {
+				if v := w.Header().Get(k); v != "" {
+					cached.header.Set(k, v)
+				}
+			}
+			s.responses.set(etag, cached)
+		}
+	})
+}
+
+// notModified reports whether the client already has the current response.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.IsZero() {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// cachedHeaders are the response headers kept with cached responses. The rest
+// are set for each request.
+var cachedHeaders = []string{"Content-Type", "Content-Disposition"}
+
+type cachedResponse struct {
+	header http.Header
+	body   []byte
+}
+
+func (c *cachedResponse) write(w http.ResponseWriter) {
+	for k, v := range c.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(c.body)
+}
+
+// cacheWriter holds on to a copy of a response as it's written so it can be
+// cached. Error responses don't get the caching headers.
+type cacheWriter struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *cacheWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if status != http.StatusOK {
+		w.Header().Del("ETag")
+		w.Header().Del("Last-Modified")
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.overflow {
+		if w.body.Len()+len(b) > maxCachedBody {
+			w.overflow = true
+			w.body = bytes.Buffer{}
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// FindDataVersions returns the current data versions keyed by name.
+func FindDataVersions(ctx context.Context, db *mgo.Database) (map[string]newshound.DataVersion, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-data-versions")
+	defer span.End()
+
+	var versions []newshound.DataVersion
+	if err := getDV(db).Find(nil).All(&versions); err != nil {
+		return nil, err
+	}
+	byName := make(map[string]newshound.DataVersion, len(versions))
+	for _, v := range versions {
+		byName[v.Name] = v
+	}
+	return byName, nil
+}
+
+// findVersions returns the data versions, which are cached for versionTTL.
+func (s *service) findVersions(ctx context.Context) (map[string]newshound.DataVersion, error) {
+	if cached, ok := s.versions.get("versions"); ok {
+		return cached.(map[string]newshound.DataVersion), nil
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	versions, err := FindDataVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	s.versions.set("versions", versions)
+	return versions, nil
+}
+
+func getDV(db *mgo.Database) *mgo.Collection {
+	return db.C("data_versions")
+}
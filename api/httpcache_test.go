@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/search"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestFindCachePolicy(t *testing.T) {
+	const v1 = "/svc/newshound-api/v1"
+	tests := []struct {
+		path string
+		ok   bool
+		end  string
+	}{
+		{v1 + "/find_alerts/2019-01-01/2019-01-31", true, "2019-01-31"},
+		{v1 + "/export/alerts/2019-01-01/2019-01-31", true, "2019-01-31"},
+		{v1 + "/alert/5b1d2d8e1c9d440000a1b2c3/revisions", true, ""},
+		{v1 + "/report/alerts_per_week", true, ""},
+		{v1 + "/report/alerts_per_week?start=2019-01-01&end=2019-01-31", true, "2019-01-31"},
+		{v1 + "/report/compare/2019-01-01/2019-01-31?sender=cnn", true, "2019-01-31"},
+		{v1 + "/jobs", false, ""},
+		{v1 + "/admin/synonyms", false, ""},
+		{v2Prefix + "/find_alerts/2019-01-01/2019-01-31", true, "2019-01-31"},
+		{v2Prefix + "/report/compare/2019-01-01/2019-01-31?sender=cnn", true, "2019-01-31"},
+		{v2Prefix + "/openapi.json", false, ""},
+		{"/other/find_alerts/2019-01-01/2019-01-31", false, ""},
+	}
+	for _, test := range tests {
+		_, end, ok := findCachePolicy(httptest.NewRequest("GET", test.path, nil))
+		if ok != test.ok || end != test.end {
+			t.Errorf("findCachePolicy(%q) got:%q, %v want:%q, %v", test.path, end, ok, test.end, test.ok)
+		}
+	}
+}
+
+func TestSettled(t *testing.T) {
+	now := time.Date(2019, 6, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		end  string
+		want bool
+	}{
+		{"2019-06-01", true},
+		{"2019-06-09", false},
+		{"2019-06-10", false},
+		{"", false},
+		{"later", false},
+	}
+	for _, test := range tests {
+		if got := settled(test.end, now); got != test.want {
+			t.Errorf("settled(%q) got:%v want:%v", test.end, got, test.want)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	updated := time.Date(2019, 6, 10, 12, 0, 0, 0, time.UTC)
+	s := &service{
+		versions:  newLRUCache(time.Minute),
+		responses: newLRUCache(time.Minute),
+	}
+	setVersion := func() {
+		s.versions.set("versions", map[string]newshound.DataVersion{
+			newshound.AlertsVersion: {Name: newshound.AlertsVersion, Version: bson.NewObjectId(), Updated: updated},
+		})
+	}
+	setVersion()
+
+	var calls int
+	h := s.cache(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("fail") != "" {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`["alert"]`))
+	}))
+	serve := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1"+path, nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	first := serve("/trending", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Cache-Control") != "public, max-age=60" ||
+		first.Header().Get("Last-Modified") != updated.Format(http.TimeFormat) {
+		t.Fatalf("first request got status:%d headers:%v", first.Code, first.Header())
+	}
+
+	cached := serve("/trending", nil)
+	if calls != 1 || cached.Body.String() != `["alert"]` || cached.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("cached request got calls:%d body:%q headers:%v want a cached response", calls, cached.Body, cached.Header())
+	}
+
+	if w := serve("/trending", map[string]string{"If-None-Match": etag}); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match got status:%d want:%d", w.Code, http.StatusNotModified)
+	}
+	if w := serve("/trending", map[string]string{"If-Modified-Since": updated.Format(http.TimeFormat)}); w.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since got status:%d want:%d", w.Code, http.StatusNotModified)
+	}
+
+	// a new version invalidates the old responses
+	setVersion()
+	if w := serve("/trending", map[string]string{"If-None-Match": etag}); w.Code != http.StatusOK || calls != 2 || w.Header().Get("ETag") == etag {
+		t.Errorf("new version got status:%d calls:%d etag:%s want a fresh response", w.Code, calls, w.Header().Get("ETag"))
+	}
+
+	settledRange := serve("/find_alerts/2019-01-01/2019-01-31", nil)
+	if settledRange.Header().Get("Cache-Control") != "public, max-age=3600" ||
+		settledRange.Header().Get("Last-Modified") != updated.Format(http.TimeFormat) {
+		t.Errorf("settled range got headers:%v want a longer max-age", settledRange.Header())
+	}
+	// settled ranges still change with the data
+	setVersion()
+	if w := serve("/find_alerts/2019-01-01/2019-01-31", nil); w.Header().Get("ETag") == settledRange.Header().Get("ETag") {
+		t.Errorf("settled range kept etag %s after a new version", w.Header().Get("ETag"))
+	}
+	if w := serve("/alert_html/5b1d2d8e1c9d440000a1b2c3", nil); w.Header().Get("Cache-Control") != "private, max-age=300" {
+		t.Errorf("alert body got Cache-Control:%q want private", w.Header().Get("Cache-Control"))
+	}
+
+	calls = 0
+	for i := 0; i < 2; i++ {
+		w := serve("/trending?fail=true", nil)
+		if w.Code != http.StatusBadRequest || w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("failed request got status:%d headers:%v want no caching", w.Code, w.Header())
+		}
+	}
+	if calls != 2 {
+		t.Errorf("failed requests got calls:%d want:2", calls)
+	}
+
+	for _, path := range []string{"/jobs", "/feed/rss/events"} {
+		if w := serve(path, nil); w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "" {
+			t.Errorf("%s got headers:%v want no caching", path, w.Header())
+		}
+	}
+}
+
+// bodyHighlights is a search.Backend stub that highlights alert bodies.
+type bodyHighlights struct{}
+
+func (bodyHighlights) Search(ctx context.Context, q search.Query) (search.Results, error) {
+	return search.Results{Total: 1, Hits: []search.Hit{
+		{Title: "a", Highlights: map[string][]string{"body": {"the <em>fed</em> said"}}},
+	}}, nil
+}
+
+func TestCacheSearchBodies(t *testing.T) {
+	s := &service{
+		loc:       time.UTC,
+		searcher:  bodyHighlights{},
+		versions:  newLRUCache(time.Minute),
+		responses: newLRUCache(time.Minute),
+	}
+	s.versions.set("versions", map[string]newshound.DataVersion{
+		newshound.AlertsVersion: {Name: newshound.AlertsVersion, Version: bson.NewObjectId()},
+		newshound.EventsVersion: {Name: newshound.EventsVersion, Version: bson.NewObjectId()},
+	})
+	h := s.cache(testRouter(s))
+	serve := func(c *client) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/search/alerts?q=fed", nil)
+		if c != nil {
+			r = r.WithContext(context.WithValue(r.Context(), clientKey{}, c))
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	bodies := serve(&client{id: "reader", scopes: []string{ScopeReadPublic, ScopeReadBodies}})
+	if !strings.Contains(bodies.Body.String(), `"body":`) {
+		t.Fatalf("read-bodies search got body:%s want body highlights", bodies.Body)
+	}
+	for _, c := range []*client{nil, {id: "10.0.0.1", scopes: []string{ScopeReadPublic}}} {
+		w := serve(c)
+		if w.Code != http.StatusOK || strings.Contains(w.Body.String(), `"body":`) {
+			t.Errorf("search without read-bodies got status:%d body:%s want no body highlights", w.Code, w.Body)
+		}
+		if w.Header().Get("ETag") == bodies.Header().Get("ETag") {
+			t.Errorf("search without read-bodies got the read-bodies etag %s", w.Header().Get("ETag"))
+		}
+		if w.Header().Get("Cache-Control") != "private, max-age=60" || w.Header().Get("Vary") != "X-API-Key" {
+			t.Errorf("search got headers:%v want a private response that varies by key", w.Header())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxJobRuns is the most runs of a job that can be requested at once.
+const maxJobRuns = 100
+
+// JobStatus describes the current state of one of fetchd's scheduled jobs.
+type JobStatus struct {
+	Job     string `json:"job"`
+	Running bool   `json:"running"`
+	// Owner and RunningSince are only set if the job is running.
+	Owner        string            `json:"owner,omitempty"`
+	RunningSince *time.Time        `json:"running_since,omitempty"`
+	LastRun      *newshound.JobRun `json:"last_run,omitempty"`
+	LastSuccess  *newshound.JobRun `json:"last_success,omitempty"`
+}
+
+// FindJobStatus returns the status of every job that has run or is running.
+func FindJobStatus(ctx context.Context, db *mgo.Database) ([]JobStatus, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-job-status")
+	defer span.End()
+
+	var jobs []string
+	if err := getJR(db).Find(nil).Distinct("job", &jobs); err != nil {
+		return nil, err
+	}
+	var locks []newshound.JobLock
+	if err := getJL(db).Find(bson.M{"expires": bson.M{"$gt": time.Now()}}).All(&locks); err != nil {
+		return nil, err
+	}
+	running := map[string]newshound.JobLock{}
+	for _, lock := range locks {
+		running[lock.Job] = lock
+		if !contains(jobs, lock.Job) {
+			jobs = append(jobs, lock.Job)
+		}
+	}
+	sort.Strings(jobs)
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, job := range jobs {
+		status := JobStatus{Job: job}
+		if lock, ok := running[job]; ok {
+			status.Running = true
+			status.Owner = lock.Owner
+			status.RunningSince = &lock.Acquired
+		}
+		var err error
+		if status.LastRun, err = findLastRun(db, bson.M{"job": job}); err != nil {
+			return nil, err
+		}
+		if status.LastSuccess, err = findLastRun(db, bson.M{"job": job, "succeeded": true}); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// FindJobRuns returns the most recent runs of the job, newest first.
+func FindJobRuns(ctx context.Context, db *mgo.Database, job string, limit int) ([]newshound.JobRun, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-job-runs")
+	defer span.End()
+
+	runs := []newshound.JobRun{}
+	err := getJR(db).Find(bson.M{"job": job}).Sort("-start").Limit(limit).All(&runs)
+	return runs, err
+}
+
+func findLastRun(db *mgo.Database, query bson.M) (*newshound.JobRun, error) {
+	var run newshound.JobRun
+	err := getJR(db).Find(query).Sort("-start").One(&run)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func getJR(db *mgo.Database) *mgo.Collection {
+	return db.C("job_runs")
+}
+
+func getJL(db *mgo.Database) *mgo.Collection {
+	return db.C("job_locks")
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// The scopes an API key can be granted.
+const (
+	// ScopeReadPublic allows reading alerts without their bodies, events,
+	// storylines, feeds and reports.
+	ScopeReadPublic = "read-public"
+	// ScopeReadBodies allows reading the full bodies of alerts.
+	ScopeReadBodies = "read-bodies"
+	// ScopeAdmin allows using the admin endpoints.
+	ScopeAdmin = "admin"
+)
+
+var validScopes = map[string]bool{
+	ScopeReadPublic: true,
+	ScopeReadBodies: true,
+	ScopeAdmin:      true,
+}
+
+// keyCacheTTL is how long API key lookups are cached for. A deleted key may
+// still work on other instances for this long.
+var keyCacheTTL = time.Minute
+
+// NewAPIKey is a newly created API key along with the key itself, which
+// is only ever returned once.
+type NewAPIKey struct {
+	newshound.APIKey
+	Key string `json:"key"`
+}
+
+// HashKey returns the hash an API key is stored by.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// FindAPIKeys returns every API key, oldest first.
+func FindAPIKeys(ctx context.Context, db *mgo.Database) ([]newshound.APIKey, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-api-keys")
+	defer span.End()
+
+	keys := []newshound.APIKey{}
+	err := getAK(db).Find(nil).Sort("_id").All(&keys)
+	return keys, err
+}
+
+// FindAPIKey returns the API key with the given hash. A NotFoundError is
+// returned if there isn't one.
+func FindAPIKey(ctx context.Context, db *mgo.Database, hash string) (newshound.APIKey, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-api-key")
+	defer span.End()
+
+	var key newshound.APIKey
+	err := getAK(db).Find(bson.M{"hash": hash}).One(&key)
+	return key, notFound(err, "api key", hash)
+}
+
+// CreateAPIKey generates a new API key with the given name, scopes and limits
+// and saves it. The key is only ever returned here since just its hash is
+// stored.
+func CreateAPIKey(ctx context.Context, db *mgo.Database, k newshound.APIKey) (string, newshound.APIKey, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/create-api-key")
+	defer span.End()
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", k, err
+	}
+	key := base64.RawURLEncoding.EncodeToString(b)
+
+	k.ID = bson.NewObjectId()
+	k.Hash = HashKey(key)
+	k.Created = time.Now().UTC()
+
+	c := getAK(db)
+	err := c.EnsureIndex(mgo.Index{Key: []string{"hash"}, Unique: true})
+	if err != nil {
+		return "", k, err
+	}
+	return key, k, c.Insert(k)
+}
+
+// DeleteAPIKey revokes the API key with the given ID and returns it. A
+// NotFoundError is returned if there isn't one.
+func DeleteAPIKey(ctx context.Context, db *mgo.Database, id bson.ObjectId) (newshound.APIKey, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/delete-api-key")
+	defer span.End()
+
+	var key newshound.APIKey
+	_, err := getAK(db).FindId(id).Apply(mgo.Change{Remove: true}, &key)
+	return key, notFound(err, "api key", id.Hex())
+}
+
+// findKey returns the API key for the given key, or nil if there isn't one.
+// Lookups are cached for keyCacheTTL.
+func (s *service) findKey(ctx context.Context, key string) (*newshound.APIKey, error) {
+	hash := HashKey(key)
+	if cached, ok := s.keys.get(hash); ok {
+		return cached.(*newshound.APIKey), nil
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	k, err := FindAPIKey(ctx, db, hash)
+	if _, ok := err.(*NotFoundError); ok {
+		s.keys.set(hash, (*newshound.APIKey)(nil))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.keys.set(hash, &k)
+	return &k, nil
+}
+
+func getAK(db *mgo.Database) *mgo.Collection {
+	return db.C("api_keys")
+}
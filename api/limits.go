@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Limits are the most requests a client can make in a minute and in a day.
+// Zero is unlimited.
+type Limits struct {
+	PerMinute int
+	PerDay    int
+}
+
+// Decision is a Limiter's verdict on a single request.
+type Decision struct {
+	Allowed bool
+	// QuotaExceeded is set if the request was rejected because the
+	// client is out of requests for the day rather than the minute.
+	QuotaExceeded bool
+	// RemainingMinute and RemainingDay are the requests the client has
+	// left. They are -1 when there is no limit.
+	RemainingMinute int
+	RemainingDay    int
+	// RetryAfter is how long a rejected client should wait.
+	RetryAfter time.Duration
+}
+
+// Limiter counts each client's requests against their limits. Minutes and
+// days are fixed UTC windows and every request counts, rejected or not.
+type Limiter interface {
+	Allow(ctx context.Context, client string, l Limits) (Decision, error)
+}
+
+// decide returns the Decision for a client that has made the given number
+// of requests this minute and today, including the current one.
+func decide(l Limits, minute, day int, now time.Time) Decision {
+	d := Decision{Allowed: true, RemainingMinute: -1, RemainingDay: -1}
+	if l.PerDay > 0 {
+		d.RemainingDay = remaining(l.PerDay, day)
+		if day > l.PerDay {
+			d.Allowed = false
+			d.QuotaExceeded = true
+			d.RetryAfter = nextDay(now).Sub(now)
+		}
+	}
+	if l.PerMinute > 0 {
+		d.RemainingMinute = remaining(l.PerMinute, minute)
+		if d.Allowed && minute > l.PerMinute {
+			d.Allowed = false
+			d.RetryAfter = nextMinute(now).Sub(now)
+		}
+	}
+	return d
+}
+
+func remaining(limit, used int) int {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+func nextMinute(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Minute).Add(time.Minute)
+}
+
+func nextDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// NewMemoryLimiter returns a Limiter that keeps its counts in memory. It is
+// only accurate when the API runs as a single instance.
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{now: time.Now, usage: map[string]*usage{}}
+}
+
+type memoryLimiter struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	usage map[string]*usage
+	// day is when the usage was last cleared out
+	day time.Time
+}
+
+type usage struct {
+	minute, day           time.Time
+	minuteCount, dayCount int
+}
+
+func (m *memoryLimiter) Allow(ctx context.Context, client string, l Limits) (Decision, error) {
+	if l.PerMinute == 0 && l.PerDay == 0 {
+		return decide(l, 0, 0, time.Time{}), nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	minute, day := nextMinute(now), nextDay(now)
+	// yesterday's clients don't need to be remembered
+	if !day.Equal(m.day) {
+		m.usage = map[string]*usage{}
+		m.day = day
+	}
+
+	u, ok := m.usage[client]
+	if !ok {
+		u = &usage{}
+		m.usage[client] = u
+	}
+	if !u.minute.Equal(minute) {
+		u.minute, u.minuteCount = minute, 0
+	}
+	if !u.day.Equal(day) {
+		u.day, u.dayCount = day, 0
+	}
+	u.minuteCount++
+	u.dayCount++
+
+	return decide(l, u.minuteCount, u.dayCount, now), nil
+}
+
+// NewMongoLimiter returns a Limiter that keeps its counts in Mongo so they
+// are shared by every instance of the API.
+func NewMongoLimiter(sess *mgo.Session) (Limiter, error) {
+	s := sess.Copy()
+	defer s.Close()
+	// expired counts are cleaned up by Mongo
+	err := getAU(s.DB("newshound")).EnsureIndex(mgo.Index{
+		Key:         []string{"expires"},
+		ExpireAfter: time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mongoLimiter{sess: sess, now: time.Now}, nil
+}
+
+type mongoLimiter struct {
+	sess *mgo.Session
+	now  func() time.Time
+}
+
+func (m *mongoLimiter) Allow(ctx context.Context, client string, l Limits) (Decision, error) {
+	if l.PerMinute == 0 && l.PerDay == 0 {
+		return decide(l, 0, 0, time.Time{}), nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/count-usage")
+	defer span.End()
+
+	sess := m.sess.Copy()
+	defer sess.Close()
+	c := getAU(sess.DB("newshound"))
+
+	now := m.now().UTC()
+	var (
+		minute, day int
+		err         error
+	)
+	if l.PerMinute > 0 {
+		minute, err = countUsage(c, client+"/"+now.Format("200601021504"), nextMinute(now))
+		if err != nil {
+			return Decision{}, err
+		}
+	}
+	if l.PerDay > 0 {
+		day, err = countUsage(c, client+"/"+now.Format("20060102"), nextDay(now))
+		if err != nil {
+			return Decision{}, err
+		}
+	}
+	return decide(l, minute, day, now), nil
+}
+
+// countUsage adds a request to the count with the given ID and returns the
+// new count. Counts are removed once they expire.
+func countUsage(c *mgo.Collection, id string, expires time.Time) (int, error) {
+	var count struct {
+		Count int `bson:"count"`
+	}
+	_, err := c.FindId(id).Apply(mgo.Change{
+		Update: bson.M{
+			"$inc":         bson.M{"count": 1},
+			"$setOnInsert": bson.M{"expires": expires},
+		},
+		Upsert:    true,
+		ReturnNew: true,
+	}, &count)
+	return count.Count, err
+}
+
+func getAU(db *mgo.Database) *mgo.Collection {
+	return db.C("api_usage")
+}
@@ -2,50 +2,89 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NYTimes/gizmo/server"
-	"github.com/jprobinson/go-utils/web"
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 // findAlertsByDate is an http.Handler that will expect a 'start' and 'end' date in the URL
-// and will return a list of News Alerts that occured in that timeframe.
+// and will return a list of News Alerts that occured in that timeframe. Optional 'sender',
+// 'tag' and 'has_article_url' query parameters filter the alerts. If a 'limit' or 'cursor'
+// is given, a page of alerts will be returned along with the cursor for the next page.
 func (s *service) findAlertsByDate(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	startTime, endTime, err := web.ParseDateRangeFullDay(vars)
+	startTime, endTime, err := s.parseDateRange(r, vars)
 	if err != nil {
-		return http.StatusBadRequest, "bad request", nil
+		return badRequest(err)
+	}
+	q, err := parseAlertQuery(r)
+	if err != nil {
+		return badRequest(err)
 	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	alerts, err := FindAlertsByDate(r.Context(), db, startTime, endTime)
+	alerts, next, err := FindAlertsByDate(r.Context(), db, startTime, endTime, q)
 	if err != nil {
-		log.Printf("unable to access alerts by date - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access alerts by date")
 	}
 
-	return http.StatusOK, alerts, nil
+	return http.StatusOK, pageOf(q.PageQuery, alerts, next), nil
+}
+
+// parseAlertQuery will pull any News Alert filters and paging out of the query string.
+func parseAlertQuery(r *http.Request) (AlertQuery, error) {
+	var (
+		q   AlertQuery
+		err error
+	)
+	q.Senders = listParam(r, "sender")
+	q.Tags = listParam(r, "tag")
+	if q.HasArticleURL, err = boolParam(r, "has_article_url"); err != nil {
+		return q, err
+	}
+	q.PageQuery, err = parsePageQuery(r)
+	return q, err
+}
+
+// pageOf wraps paged results in a Page. Unpaged results are returned as is.
+func pageOf(p PageQuery, items interface{}, next string) interface{} {
+	if !p.Paged() {
+		return items
+	}
+	return Page{Items: items, NextCursor: next}
 }
 
 // findOrderedAlerts is an http.Handler that will expect a comma delmited list of News Alert IDs
 // in the URL and will return a chronologically ordered of those News Alerts' information.
 func (s *service) findOrderedAlerts(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	alertIDs := strings.Split(vars["alert_ids"], ",")
+	var alertIDs []bson.ObjectId
+	for _, alertID := range strings.Split(vars["alert_ids"], ",") {
+		id, err := parseID("alert", alertID)
+		if err != nil {
+			return badRequest(err)
+		}
+		alertIDs = append(alertIDs, id)
+	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
 	alerts, err := FindOrderedAlerts(r.Context(), db, alertIDs)
 	if err != nil {
-		log.Printf("unable to access alerts - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access alerts")
 	}
 
 	return http.StatusOK, alerts, nil
@@ -55,32 +94,58 @@ func (s *service) findOrderedAlerts(r *http.Request) (int, interface{}, error) {
 // alert exists, it will return it's information.
 func (s *service) findAlert(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	alertID := vars["alert_id"]
+	alertID, err := parseID("alert", vars["alert_id"])
+	if err != nil {
+		return badRequest(err)
+	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
 	alert, err := FindAlertByID(r.Context(), db, alertID)
 	if err != nil {
-		log.Printf("unable to access alert - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access alert")
 	}
 
 	return http.StatusOK, alert, nil
 }
 
+// findAlertRevisions will return the original News Alert and all resends of it
+// for the alert ID given in the URL.
+func (s *service) findAlertRevisions(r *http.Request) (int, interface{}, error) {
+	alertID, err := parseID("alert", server.Vars(r)["alert_id"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	alerts, err := FindAlertRevisions(r.Context(), db, alertID)
+	if err != nil {
+		return failed(err, "access alert revisions")
+	}
+
+	return http.StatusOK, alerts, nil
+}
+
 // findAlertHTML is an http.Handler that expects a News Alert ID in the URL and if the
 // alert exists, it will return it's HTML with a 'text/html' content-type.
 func (s *service) findAlertHTML(w http.ResponseWriter, r *http.Request) {
-	alertID := server.Vars(r)["alert_id"]
+	alertID, err := parseID("alert", server.Vars(r)["alert_id"])
+	if err != nil {
+		status, body, _ := badRequest(err)
+		writeError(w, status, body)
+		return
+	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
 	alertHtml, err := FindAlertHtmlByID(r.Context(), db, alertID)
 	if err != nil {
-		log.Printf("unable to access alert HTML - %s", err)
-		http.Error(w, "server error", http.StatusInternalServerError)
+		status, body, _ := failed(err, "access alert HTML")
+		writeError(w, status, body)
 		return
 	}
 
@@ -90,120 +155,797 @@ func (s *service) findAlertHTML(w http.ResponseWriter, r *http.Request) {
 
 // eventFeed is an http.Handler that will expect a 'start' and 'end' date in the URL
 // and will return a list of News Events that occured in that timeframe order by time desc.
+// The optional 'min_importance' and 'sort=importance' query parameters will filter
+// and order the events by their importance score. See parseEventQuery for the other
+// filters and paging.
 func (s *service) eventFeed(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	startTime, endTime, err := web.ParseDateRangeFullDay(vars)
+	startTime, endTime, err := s.parseDateRange(r, vars)
 	if err != nil {
-		return http.StatusBadRequest, "bad request", nil
+		return badRequest(err)
+	}
+	q, err := parseEventQuery(r)
+	if err != nil {
+		return badRequest(err)
 	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	events, err := FindEventsByDateReverse(r.Context(), db, startTime, endTime)
+	events, next, err := FindEventsByDateReverse(r.Context(), db, startTime, endTime, q)
 	if err != nil {
-		log.Printf("unable to access events feed: %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access events feed")
 	}
 
-	return http.StatusOK, events, nil
+	return http.StatusOK, pageOf(q.PageQuery, events, next), nil
 }
 
 // findEventsByDate is an http.Handler that will expect a 'start' and 'end' date in the URL
-// and will return a list of News Events that occured in that timeframe.
+// and will return a list of News Events that occured in that timeframe. The optional
+// 'min_importance' and 'sort=importance' query parameters will filter and order the
+// events by their importance score. See parseEventQuery for the other filters and paging.
 func (s *service) findEventsByDate(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	startTime, endTime, err := web.ParseDateRangeFullDay(vars)
+	startTime, endTime, err := s.parseDateRange(r, vars)
+	if err != nil {
+		return badRequest(err)
+	}
+	q, err := parseEventQuery(r)
 	if err != nil {
-		return http.StatusBadRequest, "bad request", nil
+		return badRequest(err)
 	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	events, err := FindEventsByDate(r.Context(), db, startTime, endTime)
+	events, next, err := FindEventsByDate(r.Context(), db, startTime, endTime, q)
 	if err != nil {
-		log.Printf("unable to access events by date: %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access events by date")
 	}
 
-	return http.StatusOK, events, nil
+	return http.StatusOK, pageOf(q.PageQuery, events, next), nil
+}
+
+// parseEventQuery will pull any News Event filters, sorting and paging out of the query
+// string. Along with 'min_importance' and 'sort', events can be filtered by 'sender',
+// 'tag', 'min_alerts' and 'has_article_url'. If a 'limit' or 'cursor' is given, a page
+// of events will be returned along with the cursor for the next page.
+func parseEventQuery(r *http.Request) (EventQuery, error) {
+	var (
+		q   EventQuery
+		err error
+	)
+	params := r.URL.Query()
+	if min := params.Get("min_importance"); min != "" {
+		if q.MinImportance, err = strconv.ParseFloat(min, 64); err != nil {
+			return q, err
+		}
+	}
+	switch params.Get("sort") {
+	case "":
+	case "importance":
+		q.ByImportance = true
+	default:
+		return q, fmt.Errorf("invalid sort: %q", params.Get("sort"))
+	}
+	q.Senders = listParam(r, "sender")
+	q.Tags = listParam(r, "tag")
+	if min := params.Get("min_alerts"); min != "" {
+		if q.MinAlerts, err = strconv.Atoi(min); err != nil || q.MinAlerts < 0 {
+			return q, fmt.Errorf("invalid min_alerts: %q", min)
+		}
+	}
+	if q.HasArticleURL, err = boolParam(r, "has_article_url"); err != nil {
+		return q, err
+	}
+	if q.PageQuery, err = parsePageQuery(r); err != nil {
+		return q, err
+	}
+	if !q.validCursor() {
+		return q, errBadCursor
+	}
+	return q, nil
 }
 
 // findEvent is an http.Handler that expects a News Event ID in the URL and if the
 // event exists, it will return it's information.
 func (s *service) findEvent(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
-	eventID := vars["event_id"]
+	eventID, err := parseID("event", vars["event_id"])
+	if err != nil {
+		return badRequest(err)
+	}
 
 	sess, db := s.getDB()
 	defer sess.Close()
 
 	event, err := FindEventByID(r.Context(), db, eventID)
 	if err != nil {
-		log.Printf("unable to access event by event_id - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "access event by event_id")
 	}
 
 	return http.StatusOK, event, nil
 }
+
+// findStorylinesByDate is an http.Handler that will expect a 'start' and 'end' date in the URL
+// and will return a list of Storylines that were active in that timeframe.
+func (s *service) findStorylinesByDate(r *http.Request) (int, interface{}, error) {
+	vars := server.Vars(r)
+	startTime, endTime, err := s.parseDateRange(r, vars)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	storylines, err := FindStorylinesByDate(r.Context(), db, startTime, endTime)
+	if err != nil {
+		return failed(err, "access storylines by date")
+	}
+
+	return http.StatusOK, storylines, nil
+}
+
+// findStoryline is an http.Handler that expects a Storyline ID in the URL and if the
+// storyline exists, it will return it along with its ordered events and alerts.
+func (s *service) findStoryline(r *http.Request) (int, interface{}, error) {
+	storylineID, err := parseID("storyline", server.Vars(r)["storyline_id"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	storyline, err := FindStorylineByID(r.Context(), db, storylineID)
+	if err != nil {
+		return failed(err, "access storyline")
+	}
+
+	return http.StatusOK, storyline, nil
+}
+
+// findEventStoryline is an http.Handler that expects a News Event ID in the URL and
+// will return the Storyline the event belongs to.
+func (s *service) findEventStoryline(r *http.Request) (int, interface{}, error) {
+	eventID, err := parseID("event", server.Vars(r)["event_id"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	storyline, err := FindStorylineByEvent(r.Context(), db, eventID)
+	if err != nil {
+		return failed(err, "access storyline by event_id")
+	}
+
+	return http.StatusOK, storyline, nil
+}
+
+// findEntitiesByDate is an http.Handler that will expect a 'start' and 'end' date in the URL
+// and will return all entities mentioned by News Alerts in that timeframe. An optional 'type'
+// query parameter will limit the results to a single entity type.
+func (s *service) findEntitiesByDate(r *http.Request) (int, interface{}, error) {
+	vars := server.Vars(r)
+	startTime, endTime, err := s.parseDateRange(r, vars)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	entityType := newshound.EntityType(r.URL.Query().Get("type"))
+	switch entityType {
+	case "", newshound.EntityPerson, newshound.EntityOrganization, newshound.EntityLocation,
+		newshound.EntityEvent, newshound.EntityOther:
+	default:
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("invalid entity type: %q", entityType))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	entities, err := FindEntitiesByDate(r.Context(), db, startTime, endTime, entityType)
+	if err != nil {
+		return failed(err, "access entities by date")
+	}
+
+	return http.StatusOK, entities, nil
+}
+
+func (s *service) findTrending(r *http.Request) (int, interface{}, error) {
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	tags, err := FindTrending(r.Context(), db)
+	if err != nil {
+		return failed(err, "access trending tags")
+	}
+
+	return http.StatusOK, tags, nil
+}
+
+// parseReportQuery pulls the optional 'start' and 'end' dates and 'sender' filters
+// from the query string. Multiple senders can be given as repeated or comma
+// separated 'sender' parameters. Dates are in the request's time zone, which only
+// applies to custom date ranges since stored reports are bucketed when generated.
+func (s *service) parseReportQuery(r *http.Request) (ReportQuery, error) {
+	var q ReportQuery
+	loc, err := s.location(r)
+	if err != nil {
+		return q, err
+	}
+	q.Senders = listParam(r, "sender")
+
+	qs := r.URL.Query()
+	start, end := qs.Get("start"), qs.Get("end")
+	if start == "" && end == "" {
+		return q, nil
+	}
+	startTime, endTime, err := parseDateRange(map[string]string{"start": start, "end": end}, loc)
+	if err != nil {
+		return q, err
+	}
+	q.Start = startTime
+	// the end date is inclusive, so run through to midnight
+	q.End = endTime.Add(time.Millisecond)
+	return q, nil
+}
+
+// parseRecentReportQuery parses the report query like parseReportQuery but, if no 'start'
+// and 'end' dates are given, it will run from the given years, months and days ago
+// through the end of today.
+func (s *service) parseRecentReportQuery(r *http.Request, years, months, days int) (ReportQuery, error) {
+	q, err := s.parseReportQuery(r)
+	if err != nil || q.Custom() {
+		return q, err
+	}
+	loc, err := s.location(r)
+	if err != nil {
+		return q, err
+	}
+	now := time.Now().In(loc)
+	q.End = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	q.Start = q.End.AddDate(-years, -months, -days)
+	return q, nil
+}
+
+func uniqueSorted(values []string) []string {
+	set := map[string]struct{}{}
+	var unique []string
+	for _, v := range values {
+		if _, ok := set[v]; !ok {
+			set[v] = struct{}{}
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// cachedReport will return the results of a custom date range report from the cache
+// or compute and cache them. Stored reports are always read fresh. Date ranges longer
+// than maxReportYears are rejected with an InvalidDateError.
+func (s *service) cachedReport(q ReportQuery, name string, compute func() (interface{}, error)) (interface{}, error) {
+	if !q.Custom() {
+		return compute()
+	}
+	if err := checkReportRange(q.Start, q.End); err != nil {
+		return nil, err
+	}
+	key := q.key(name)
+	if cached, ok := s.reports.get(key); ok {
+		return cached, nil
+	}
+	results, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	s.reports.set(key, results)
+	return results, nil
+}
+
+// getAlertsPerWeek is an http.Handler that will return the average alerts per week report.
+// Optional 'start' and 'end' query parameters will compute the report for that date range and
+// 'sender' parameters will limit the report to those senders.
 func (s *service) getAlertsPerWeek(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseReportQuery(r)
+	if err == nil {
+		err = s.checkStoredZone(r, q)
+	}
+	if err != nil {
+		return badRequest(err)
+	}
+
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	sendersReport, err := GetAlertsPerWeek(db)
+	sendersReport, err := s.cachedReport(q, "alerts_per_week", func() (interface{}, error) {
+		return FindAlertsPerWeek(r.Context(), db, q)
+	})
 	if err != nil {
-		log.Printf("unable to retrieve sender alerts per week - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "retrieve sender alerts per week")
 	}
 
 	return http.StatusOK, sendersReport, nil
 }
 
+// getEventAttendance is an http.Handler that will return the event attendance report.
+// It accepts the same query parameters as getAlertsPerWeek.
 func (s *service) getEventAttendance(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseReportQuery(r)
+	if err == nil {
+		err = s.checkStoredZone(r, q)
+	}
+	if err != nil {
+		return badRequest(err)
+	}
+
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	sendersReport, err := GetEventAttendance(db)
+	sendersReport, err := s.cachedReport(q, "event_attendance", func() (interface{}, error) {
+		return FindEventAttendance(r.Context(), db, q)
+	})
 	if err != nil {
-		log.Printf("unable to retrieve sender event attendance - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "retrieve sender event attendance")
 	}
 
 	return http.StatusOK, sendersReport, nil
 }
+
+// getEventsPerWeek is an http.Handler that will return the average events per week report.
+// It accepts the same query parameters as getAlertsPerWeek.
 func (s *service) getEventsPerWeek(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseReportQuery(r)
+	if err == nil {
+		err = s.checkStoredZone(r, q)
+	}
+	if err != nil {
+		return badRequest(err)
+	}
+
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	sendersReport, err := GetEventsPerWeek(db)
+	sendersReport, err := s.cachedReport(q, "events_per_week", func() (interface{}, error) {
+		return FindEventsPerWeek(r.Context(), db, q)
+	})
 	if err != nil {
-		log.Printf("unable to retrieve sender events per week - %s", err)
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "retrieve sender events per week")
 	}
 
 	return http.StatusOK, sendersReport, nil
 }
 
 // findSenderInfo is an http.Handler that will expect a Sender name in the URL and if
-// the sender exists, it will return the Sender Info report for the past 3 months.
+// the sender exists, it will return the Sender Info report for the past 3 months. Optional
+// 'start' and 'end' query parameters will compute the report for that date range instead.
 func (s *service) findSenderInfo(r *http.Request) (int, interface{}, error) {
 	vars := server.Vars(r)
 	sender := vars["sender"]
 
+	q, err := s.parseReportQuery(r)
+	if err == nil {
+		err = s.checkStoredZone(r, q)
+	}
+	if err != nil {
+		return badRequest(err)
+	}
+	q.Senders = []string{sender}
+
 	sess, db := s.getDB()
 	defer sess.Close()
 
-	senderInfo, err := FindSenderInfo(db, sender)
+	senderInfo, err := s.cachedReport(q, "sender_info", func() (interface{}, error) {
+		if q.Custom() {
+			return FindSenderInfoRange(r.Context(), db, sender, q)
+		}
+		return FindSenderInfo(db, sender)
+	})
 	if err != nil {
-		log.Printf("Unable to retrieve sender info report! - %s", err.Error())
-		return http.StatusInternalServerError, "server error", nil
+		return failed(err, "retrieve sender info report")
 	}
 
 	return http.StatusOK, senderInfo, nil
 }
 
+// getSenderHeatmap is an http.Handler that will expect a Sender name in the URL and return
+// a day of week by hour heatmap of their alerts for the past 12 months. Optional 'start' and
+// 'end' query parameters will compute the heatmap for that date range instead and a 'tz'
+// parameter will bucket the alerts in that time zone.
+func (s *service) getSenderHeatmap(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseRecentReportQuery(r, 1, 0, 0)
+	if err != nil {
+		return badRequest(err)
+	}
+	q.Senders = []string{server.Vars(r)["sender"]}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	heatmap, err := s.cachedReport(q, "sender_heatmap", func() (interface{}, error) {
+		return FindSenderHeatmap(r.Context(), db, q.Senders[0], q)
+	})
+	if err != nil {
+		return failed(err, "compute sender heatmap")
+	}
+
+	return http.StatusOK, heatmap, nil
+}
+
+// compareSenders is an http.Handler that will expect a 'start' and 'end' date in the URL
+// and 2 or more 'sender' query parameters. It will return a head-to-head report on how
+// the senders covered the events in that timeframe.
+func (s *service) compareSenders(r *http.Request) (int, interface{}, error) {
+	startTime, endTime, err := s.parseDateRange(r, server.Vars(r))
+	if err != nil {
+		return badRequest(err)
+	}
+	q, err := s.parseReportQuery(r)
+	if err != nil {
+		return badRequest(err)
+	}
+	q.Senders = uniqueSorted(q.Senders)
+	if len(q.Senders) < 2 {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, "at least 2 senders are required")
+	}
+	q.Start = startTime
+	q.End = endTime.Add(time.Millisecond)
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	comparison, err := s.cachedReport(q, "compare", func() (interface{}, error) {
+		tieThreshold, err := FindTieThreshold(r.Context(), db)
+		if err != nil {
+			return nil, err
+		}
+		return FindComparison(r.Context(), db, q, tieThreshold)
+	})
+	if err != nil {
+		return failed(err, "compare senders")
+	}
+
+	return http.StatusOK, comparison, nil
+}
+
+// parseTermOptions pulls the optional 'period' ('day' or 'week', the default) and
+// 'entities' query parameters for the tag trend reports.
+func parseTermOptions(r *http.Request) (report.TermOptions, error) {
+	qs := r.URL.Query()
+	opts := report.TermOptions{Period: qs.Get("period")}
+	if opts.Period == "" {
+		opts.Period = report.Weekly
+	}
+	if !report.ValidPeriod(opts.Period) {
+		return opts, fmt.Errorf("invalid period: %q", opts.Period)
+	}
+	if e := qs.Get("entities"); e != "" {
+		var err error
+		if opts.Entities, err = strconv.ParseBool(e); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// parseLimit pulls the optional 'limit' query parameter, which must be
+// between 1 and max.
+func parseLimit(r *http.Request, def, max int) (int, error) {
+	l := r.URL.Query().Get("limit")
+	if l == "" {
+		return def, nil
+	}
+	limit, err := strconv.Atoi(l)
+	if err != nil || limit < 1 || limit > max {
+		return 0, fmt.Errorf("invalid limit: %q", l)
+	}
+	return limit, nil
+}
+
+// getTagTrend is an http.Handler that will expect a tag in the URL and return the number
+// of alerts mentioning it per week over the past 12 weeks. Optional 'start' and 'end'
+// parameters change the date range, 'period=day' counts by day, 'entities=true' counts
+// entity names instead of tags, 'sender' parameters limit the senders and 'by_sender=true'
+// adds a trend for each sender.
+func (s *service) getTagTrend(r *http.Request) (int, interface{}, error) {
+	tag := server.Vars(r)["tag"]
+	q, err := s.parseRecentReportQuery(r, 0, 0, 84)
+	if err != nil {
+		return badRequest(err)
+	}
+	opts, err := parseTermOptions(r)
+	if err != nil {
+		return badRequest(err)
+	}
+	var bySender bool
+	if b := r.URL.Query().Get("by_sender"); b != "" {
+		if bySender, err = strconv.ParseBool(b); err != nil {
+			return badRequest(err)
+		}
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	name := fmt.Sprintf("tag_trend|%s|%s|%t|%t", tag, opts.Period, opts.Entities, bySender)
+	trends, err := s.cachedReport(q, name, func() (interface{}, error) {
+		return FindTagTrend(r.Context(), db, tag, q, opts, bySender)
+	})
+	if err != nil {
+		return failed(err, "compute tag trend")
+	}
+
+	return http.StatusOK, trends, nil
+}
+
+// getTagMovers is an http.Handler that will return the top rising and falling tags for
+// each of the past 12 weeks. It accepts the same parameters as getTagTrend along with a
+// 'limit' on the number of tags returned in each direction.
+func (s *service) getTagMovers(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseRecentReportQuery(r, 0, 0, 84)
+	if err != nil {
+		return badRequest(err)
+	}
+	opts, err := parseTermOptions(r)
+	if err != nil {
+		return badRequest(err)
+	}
+	limit, err := parseLimit(r, 10, maxTagResults)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	name := fmt.Sprintf("tag_movers|%s|%t|%d", opts.Period, opts.Entities, limit)
+	movers, err := s.cachedReport(q, name, func() (interface{}, error) {
+		return FindTagMovers(r.Context(), db, q, opts, limit)
+	})
+	if err != nil {
+		return failed(err, "compute tag movers")
+	}
+
+	return http.StatusOK, movers, nil
+}
+
+// getTagCoOccurrences is an http.Handler that will expect a tag in the URL and return the
+// tags most often used alongside it over the past 3 months. It accepts the same parameters
+// as getTagMovers.
+func (s *service) getTagCoOccurrences(r *http.Request) (int, interface{}, error) {
+	tag := server.Vars(r)["tag"]
+	q, err := s.parseRecentReportQuery(r, 0, 3, 0)
+	if err != nil {
+		return badRequest(err)
+	}
+	opts, err := parseTermOptions(r)
+	if err != nil {
+		return badRequest(err)
+	}
+	limit, err := parseLimit(r, 20, maxTagResults)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	name := fmt.Sprintf("tag_cooccurrence|%s|%t|%d", tag, opts.Entities, limit)
+	tags, err := s.cachedReport(q, name, func() (interface{}, error) {
+		return FindTagCoOccurrences(r.Context(), db, tag, q, opts, limit)
+	})
+	if err != nil {
+		return failed(err, "compute tag co-occurrences")
+	}
+
+	return http.StatusOK, tags, nil
+}
+
+// getJobStatus is an http.Handler that will return the status of each of fetchd's
+// scheduled jobs, including whether it's running and its last run.
+func (s *service) getJobStatus(r *http.Request) (int, interface{}, error) {
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	statuses, err := FindJobStatus(r.Context(), db)
+	if err != nil {
+		return failed(err, "access job status")
+	}
+
+	return http.StatusOK, statuses, nil
+}
+
+// findJobRuns is an http.Handler that will expect a job name in the URL and return its
+// most recent runs. An optional 'limit' parameter sets how many runs are returned.
+func (s *service) findJobRuns(r *http.Request) (int, interface{}, error) {
+	job := server.Vars(r)["job"]
+	limit, err := parseLimit(r, 20, maxJobRuns)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	runs, err := FindJobRuns(r.Context(), db, job, limit)
+	if err != nil {
+		return failed(err, "access job runs")
+	}
+
+	return http.StatusOK, runs, nil
+}
+
+// getScoopLeaderboard is an http.Handler that expects a timeframe ('7days', '3months',
+// '6months' or '12months') in the URL and will return the first-to-report leaderboard for it.
+func (s *service) getScoopLeaderboard(r *http.Request) (int, interface{}, error) {
+	timeframe := server.Vars(r)["timeframe"]
+	if _, ok := report.Timeframes(time.Now())[timeframe]; !ok {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("invalid timeframe: %q", timeframe))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	board, err := GetScoopLeaderboard(r.Context(), db, timeframe)
+	if err != nil {
+		return failed(err, "retrieve scoop leaderboard")
+	}
+
+	return http.StatusOK, board, nil
+}
+
+// findEventScoop is an http.Handler that expects a News Event ID in the URL and
+// will return which sender broke the event and by how much.
+func (s *service) findEventScoop(r *http.Request) (int, interface{}, error) {
+	eventID, err := parseID("event", server.Vars(r)["event_id"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	tieThreshold, err := FindTieThreshold(r.Context(), db)
+	if err != nil {
+		return failed(err, "find scoop tie threshold")
+	}
+	scoop, ok, err := FindEventScoop(r.Context(), db, eventID, tieThreshold)
+	if err != nil {
+		return failed(err, "access event scoop")
+	}
+	if !ok {
+		return errorStatus(http.StatusNotFound, CodeNotFound, "event only has a single sender")
+	}
+
+	return http.StatusOK, scoop, nil
+}
+
+// findSynonyms is an http.Handler that will return the full tag synonym dictionary.
+func (s *service) findSynonyms(r *http.Request) (int, interface{}, error) {
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	syns, err := FindSynonyms(r.Context(), db)
+	if err != nil {
+		return failed(err, "access synonyms")
+	}
+
+	return http.StatusOK, syns, nil
+}
+
+// saveSynonym is an http.Handler that expects a tag in the URL and a JSON body
+// with its 'canonical' form. It will add or replace the tag's synonym.
+func (s *service) saveSynonym(r *http.Request) (int, interface{}, error) {
+	var syn newshound.TagSynonym
+	if err := json.NewDecoder(r.Body).Decode(&syn); err != nil {
+		return badRequest(err)
+	}
+	syn.Tag = server.Vars(r)["tag"]
+	if cleanTag(syn.Tag) == "" || cleanTag(syn.Canonical) == "" ||
+		cleanTag(syn.Tag) == cleanTag(syn.Canonical) {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, "a tag and a different canonical tag are required")
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	syn, err := SaveSynonym(r.Context(), db, syn)
+	if err == ErrSynonymChain {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, err.Error())
+	}
+	if err != nil {
+		return failed(err, "save synonym")
+	}
+
+	return http.StatusOK, syn, nil
+}
+
+// deleteSynonym is an http.Handler that expects a tag in the URL and will
+// remove it from the synonym dictionary.
+func (s *service) deleteSynonym(r *http.Request) (int, interface{}, error) {
+	tag := server.Vars(r)["tag"]
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	err := DeleteSynonym(r.Context(), db, tag)
+	if err != nil {
+		return failed(err, "delete synonym")
+	}
+
+	return http.StatusOK, "OK", nil
+}
+
 func (s *service) getDB() (*mgo.Session, *mgo.Database) {
 	sess := s.sess.Copy()
 	return sess, sess.DB("newshound")
 }
+
+// findAPIKeys is an http.Handler that will return every API key. The keys
+// themselves are never returned since only their hashes are stored.
+func (s *service) findAPIKeys(r *http.Request) (int, interface{}, error) {
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	keys, err := FindAPIKeys(r.Context(), db)
+	if err != nil {
+		return failed(err, "access api keys")
+	}
+
+	return http.StatusOK, keys, nil
+}
+
+// createAPIKey is an http.Handler that expects a JSON body with the new key's
+// 'name', 'scopes' and optional 'per_minute' and 'per_day' limits. It will
+// return the key's record along with the key, which can't be retrieved again.
+func (s *service) createAPIKey(r *http.Request) (int, interface{}, error) {
+	var key newshound.APIKey
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		return badRequest(err)
+	}
+	key.Name = strings.TrimSpace(key.Name)
+	if key.Name == "" || len(key.Scopes) == 0 {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, "a name and at least one scope are required")
+	}
+	for _, scope := range key.Scopes {
+		if !validScopes[scope] {
+			return errorStatus(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("invalid scope: %q", scope))
+		}
+	}
+	if key.PerMinute < 0 || key.PerDay < 0 {
+		return errorStatus(http.StatusBadRequest, CodeBadRequest, "limits cannot be negative")
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	raw, key, err := CreateAPIKey(r.Context(), db, key)
+	if err != nil {
+		return failed(err, "create api key")
+	}
+
+	return http.StatusCreated, NewAPIKey{APIKey: key, Key: raw}, nil
+}
+
+// deleteAPIKey is an http.Handler that expects a key ID in the URL and will
+// revoke the key.
+func (s *service) deleteAPIKey(r *http.Request) (int, interface{}, error) {
+	id, err := parseID("api key", server.Vars(r)["key_id"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	key, err := DeleteAPIKey(r.Context(), db, id)
+	if err != nil {
+		return failed(err, "delete api key")
+	}
+	s.keys.set(key.Hash, (*newshound.APIKey)(nil))
+
+	return http.StatusOK, "OK", nil
+}
@@ -0,0 +1,3045 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: newshoundpb/newshound.proto
+
+package newshoundpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type EventUpdate_Kind int32
+
+const (
+	EventUpdate_NEW     EventUpdate_Kind = 0
+	EventUpdate_UPDATED EventUpdate_Kind = 1
+)
+
+var EventUpdate_Kind_name = map[int32]string{
+	0: "NEW",
+	1: "UPDATED",
+}
+
+var EventUpdate_Kind_value = map[string]int32{
+	"NEW":     0,
+	"UPDATED": 1,
+}
+
+func (x EventUpdate_Kind) String() string {
+	return proto.EnumName(EventUpdate_Kind_name, int32(x))
+}
+
+func (EventUpdate_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{8, 0}
+}
+
+type FindEventsRequest_Sort int32
+
+const (
+	FindEventsRequest_START      FindEventsRequest_Sort = 0
+	FindEventsRequest_IMPORTANCE FindEventsRequest_Sort = 1
+)
+
+var FindEventsRequest_Sort_name = map[int32]string{
+	0: "START",
+	1: "IMPORTANCE",
+}
+
+var FindEventsRequest_Sort_value = map[string]int32{
+	"START":      0,
+	"IMPORTANCE": 1,
+}
+
+func (x FindEventsRequest_Sort) String() string {
+	return proto.EnumName(FindEventsRequest_Sort_name, int32(x))
+}
+
+func (FindEventsRequest_Sort) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{16, 0}
+}
+
+type Entity struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type is one of person, organization, location, event or other.
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Entity) Reset()         { *m = Entity{} }
+func (m *Entity) String() string { return proto.CompactTextString(m) }
+func (*Entity) ProtoMessage()    {}
+func (*Entity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{0}
+}
+
+func (m *Entity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Entity.Unmarshal(m, b)
+}
+func (m *Entity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Entity.Marshal(b, m, deterministic)
+}
+func (m *Entity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entity.Merge(m, src)
+}
+func (m *Entity) XXX_Size() int {
+	return xxx_messageInfo_Entity.Size(m)
+}
+func (m *Entity) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entity proto.InternalMessageInfo
+
+func (m *Entity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Entity) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+type Sentence struct {
+	Sentence             string   `protobuf:"bytes,1,opt,name=sentence,proto3" json:"sentence,omitempty"`
+	NounPhrases          []string `protobuf:"bytes,2,rep,name=noun_phrases,json=nounPhrases,proto3" json:"noun_phrases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sentence) Reset()         { *m = Sentence{} }
+func (m *Sentence) String() string { return proto.CompactTextString(m) }
+func (*Sentence) ProtoMessage()    {}
+func (*Sentence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{1}
+}
+
+func (m *Sentence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sentence.Unmarshal(m, b)
+}
+func (m *Sentence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sentence.Marshal(b, m, deterministic)
+}
+func (m *Sentence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sentence.Merge(m, src)
+}
+func (m *Sentence) XXX_Size() int {
+	return xxx_messageInfo_Sentence.Size(m)
+}
+func (m *Sentence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sentence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sentence proto.InternalMessageInfo
+
+func (m *Sentence) GetSentence() string {
+	if m != nil {
+		return m.Sentence
+	}
+	return ""
+}
+
+func (m *Sentence) GetNounPhrases() []string {
+	if m != nil {
+		return m.NounPhrases
+	}
+	return nil
+}
+
+type Alert struct {
+	Id          string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InstanceId  string               `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ArticleUrl  string               `protobuf:"bytes,3,opt,name=article_url,json=articleUrl,proto3" json:"article_url,omitempty"`
+	Sender      string               `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	Timestamp   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Tags        []string             `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Entities    []*Entity            `protobuf:"bytes,7,rep,name=entities,proto3" json:"entities,omitempty"`
+	Subject     string               `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
+	TopSentence string               `protobuf:"bytes,9,opt,name=top_sentence,json=topSentence,proto3" json:"top_sentence,omitempty"`
+	// revision_of is the ID of the original alert if this one is a resend.
+	RevisionOf string `protobuf:"bytes,10,opt,name=revision_of,json=revisionOf,proto3" json:"revision_of,omitempty"`
+	// body and sentences are only set by GetAlert.
+	Body                 string      `protobuf:"bytes,11,opt,name=body,proto3" json:"body,omitempty"`
+	Sentences            []*Sentence `protobuf:"bytes,12,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Alert) Reset()         { *m = Alert{} }
+func (m *Alert) String() string { return proto.CompactTextString(m) }
+func (*Alert) ProtoMessage()    {}
+func (*Alert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{2}
+}
+
+func (m *Alert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert.Unmarshal(m, b)
+}
+func (m *Alert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert.Marshal(b, m, deterministic)
+}
+func (m *Alert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert.Merge(m, src)
+}
+func (m *Alert) XXX_Size() int {
+	return xxx_messageInfo_Alert.Size(m)
+}
+func (m *Alert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert proto.InternalMessageInfo
+
+func (m *Alert) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Alert) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *Alert) GetArticleUrl() string {
+	if m != nil {
+		return m.ArticleUrl
+	}
+	return ""
+}
+
+func (m *Alert) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *Alert) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *Alert) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Alert) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *Alert) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Alert) GetTopSentence() string {
+	if m != nil {
+		return m.TopSentence
+	}
+	return ""
+}
+
+func (m *Alert) GetRevisionOf() string {
+	if m != nil {
+		return m.RevisionOf
+	}
+	return ""
+}
+
+func (m *Alert) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Alert) GetSentences() []*Sentence {
+	if m != nil {
+		return m.Sentences
+	}
+	return nil
+}
+
+type AlertList struct {
+	Alerts               []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertList) Reset()         { *m = AlertList{} }
+func (m *AlertList) String() string { return proto.CompactTextString(m) }
+func (*AlertList) ProtoMessage()    {}
+func (*AlertList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{3}
+}
+
+func (m *AlertList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertList.Unmarshal(m, b)
+}
+func (m *AlertList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertList.Marshal(b, m, deterministic)
+}
+func (m *AlertList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertList.Merge(m, src)
+}
+func (m *AlertList) XXX_Size() int {
+	return xxx_messageInfo_AlertList.Size(m)
+}
+func (m *AlertList) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertList proto.InternalMessageInfo
+
+func (m *AlertList) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type AlertPage struct {
+	Alerts []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	// next_cursor is empty on the last page.
+	NextCursor           string   `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertPage) Reset()         { *m = AlertPage{} }
+func (m *AlertPage) String() string { return proto.CompactTextString(m) }
+func (*AlertPage) ProtoMessage()    {}
+func (*AlertPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{4}
+}
+
+func (m *AlertPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertPage.Unmarshal(m, b)
+}
+func (m *AlertPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertPage.Marshal(b, m, deterministic)
+}
+func (m *AlertPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertPage.Merge(m, src)
+}
+func (m *AlertPage) XXX_Size() int {
+	return xxx_messageInfo_AlertPage.Size(m)
+}
+func (m *AlertPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertPage proto.InternalMessageInfo
+
+func (m *AlertPage) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+func (m *AlertPage) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type EventAlert struct {
+	AlertId              string   `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	InstanceId           string   `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ArticleUrl           string   `protobuf:"bytes,3,opt,name=article_url,json=articleUrl,proto3" json:"article_url,omitempty"`
+	Sender               string   `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	Tags                 []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Subject              string   `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
+	TopSentence          string   `protobuf:"bytes,7,opt,name=top_sentence,json=topSentence,proto3" json:"top_sentence,omitempty"`
+	Order                int64    `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
+	TimeLapsed           int64    `protobuf:"varint,9,opt,name=time_lapsed,json=timeLapsed,proto3" json:"time_lapsed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventAlert) Reset()         { *m = EventAlert{} }
+func (m *EventAlert) String() string { return proto.CompactTextString(m) }
+func (*EventAlert) ProtoMessage()    {}
+func (*EventAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{5}
+}
+
+func (m *EventAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventAlert.Unmarshal(m, b)
+}
+func (m *EventAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventAlert.Marshal(b, m, deterministic)
+}
+func (m *EventAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAlert.Merge(m, src)
+}
+func (m *EventAlert) XXX_Size() int {
+	return xxx_messageInfo_EventAlert.Size(m)
+}
+func (m *EventAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAlert proto.InternalMessageInfo
+
+func (m *EventAlert) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *EventAlert) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *EventAlert) GetArticleUrl() string {
+	if m != nil {
+		return m.ArticleUrl
+	}
+	return ""
+}
+
+func (m *EventAlert) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *EventAlert) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *EventAlert) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *EventAlert) GetTopSentence() string {
+	if m != nil {
+		return m.TopSentence
+	}
+	return ""
+}
+
+func (m *EventAlert) GetOrder() int64 {
+	if m != nil {
+		return m.Order
+	}
+	return 0
+}
+
+func (m *EventAlert) GetTimeLapsed() int64 {
+	if m != nil {
+		return m.TimeLapsed
+	}
+	return 0
+}
+
+type Event struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags                 []string             `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Entities             []*Entity            `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	EventStart           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=event_start,json=eventStart,proto3" json:"event_start,omitempty"`
+	EventEnd             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=event_end,json=eventEnd,proto3" json:"event_end,omitempty"`
+	NewsAlerts           []*EventAlert        `protobuf:"bytes,6,rep,name=news_alerts,json=newsAlerts,proto3" json:"news_alerts,omitempty"`
+	TopSentence          string               `protobuf:"bytes,7,opt,name=top_sentence,json=topSentence,proto3" json:"top_sentence,omitempty"`
+	TopSender            string               `protobuf:"bytes,8,opt,name=top_sender,json=topSender,proto3" json:"top_sender,omitempty"`
+	Headline             string               `protobuf:"bytes,9,opt,name=headline,proto3" json:"headline,omitempty"`
+	Summary              []string             `protobuf:"bytes,10,rep,name=summary,proto3" json:"summary,omitempty"`
+	Importance           float64              `protobuf:"fixed64,11,opt,name=importance,proto3" json:"importance,omitempty"`
+	Major                bool                 `protobuf:"varint,12,opt,name=major,proto3" json:"major,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{6}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Event) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Event) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *Event) GetEventStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.EventStart
+	}
+	return nil
+}
+
+func (m *Event) GetEventEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.EventEnd
+	}
+	return nil
+}
+
+func (m *Event) GetNewsAlerts() []*EventAlert {
+	if m != nil {
+		return m.NewsAlerts
+	}
+	return nil
+}
+
+func (m *Event) GetTopSentence() string {
+	if m != nil {
+		return m.TopSentence
+	}
+	return ""
+}
+
+func (m *Event) GetTopSender() string {
+	if m != nil {
+		return m.TopSender
+	}
+	return ""
+}
+
+func (m *Event) GetHeadline() string {
+	if m != nil {
+		return m.Headline
+	}
+	return ""
+}
+
+func (m *Event) GetSummary() []string {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *Event) GetImportance() float64 {
+	if m != nil {
+		return m.Importance
+	}
+	return 0
+}
+
+func (m *Event) GetMajor() bool {
+	if m != nil {
+		return m.Major
+	}
+	return false
+}
+
+type EventPage struct {
+	Events []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// next_cursor is empty on the last page.
+	NextCursor           string   `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventPage) Reset()         { *m = EventPage{} }
+func (m *EventPage) String() string { return proto.CompactTextString(m) }
+func (*EventPage) ProtoMessage()    {}
+func (*EventPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{7}
+}
+
+func (m *EventPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventPage.Unmarshal(m, b)
+}
+func (m *EventPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventPage.Marshal(b, m, deterministic)
+}
+func (m *EventPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventPage.Merge(m, src)
+}
+func (m *EventPage) XXX_Size() int {
+	return xxx_messageInfo_EventPage.Size(m)
+}
+func (m *EventPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventPage proto.InternalMessageInfo
+
+func (m *EventPage) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *EventPage) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type EventUpdate struct {
+	// id can be given as a StreamEventsRequest's last_id to resume the stream.
+	Id                   uint64           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 EventUpdate_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=newshound.EventUpdate_Kind" json:"kind,omitempty"`
+	Event                *Event           `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *EventUpdate) Reset()         { *m = EventUpdate{} }
+func (m *EventUpdate) String() string { return proto.CompactTextString(m) }
+func (*EventUpdate) ProtoMessage()    {}
+func (*EventUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{8}
+}
+
+func (m *EventUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventUpdate.Unmarshal(m, b)
+}
+func (m *EventUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventUpdate.Marshal(b, m, deterministic)
+}
+func (m *EventUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventUpdate.Merge(m, src)
+}
+func (m *EventUpdate) XXX_Size() int {
+	return xxx_messageInfo_EventUpdate.Size(m)
+}
+func (m *EventUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventUpdate proto.InternalMessageInfo
+
+func (m *EventUpdate) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EventUpdate) GetKind() EventUpdate_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return EventUpdate_NEW
+}
+
+func (m *EventUpdate) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type Storyline struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags                 []string             `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Entities             []*Entity            `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	EventIds             []string             `protobuf:"bytes,6,rep,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
+	Headline             string               `protobuf:"bytes,7,opt,name=headline,proto3" json:"headline,omitempty"`
+	Importance           float64              `protobuf:"fixed64,8,opt,name=importance,proto3" json:"importance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Storyline) Reset()         { *m = Storyline{} }
+func (m *Storyline) String() string { return proto.CompactTextString(m) }
+func (*Storyline) ProtoMessage()    {}
+func (*Storyline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{9}
+}
+
+func (m *Storyline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Storyline.Unmarshal(m, b)
+}
+func (m *Storyline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Storyline.Marshal(b, m, deterministic)
+}
+func (m *Storyline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Storyline.Merge(m, src)
+}
+func (m *Storyline) XXX_Size() int {
+	return xxx_messageInfo_Storyline.Size(m)
+}
+func (m *Storyline) XXX_DiscardUnknown() {
+	xxx_messageInfo_Storyline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Storyline proto.InternalMessageInfo
+
+func (m *Storyline) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Storyline) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Storyline) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *Storyline) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *Storyline) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *Storyline) GetEventIds() []string {
+	if m != nil {
+		return m.EventIds
+	}
+	return nil
+}
+
+func (m *Storyline) GetHeadline() string {
+	if m != nil {
+		return m.Headline
+	}
+	return ""
+}
+
+func (m *Storyline) GetImportance() float64 {
+	if m != nil {
+		return m.Importance
+	}
+	return 0
+}
+
+type StorylineList struct {
+	Storylines           []*Storyline `protobuf:"bytes,1,rep,name=storylines,proto3" json:"storylines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StorylineList) Reset()         { *m = StorylineList{} }
+func (m *StorylineList) String() string { return proto.CompactTextString(m) }
+func (*StorylineList) ProtoMessage()    {}
+func (*StorylineList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{10}
+}
+
+func (m *StorylineList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorylineList.Unmarshal(m, b)
+}
+func (m *StorylineList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorylineList.Marshal(b, m, deterministic)
+}
+func (m *StorylineList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorylineList.Merge(m, src)
+}
+func (m *StorylineList) XXX_Size() int {
+	return xxx_messageInfo_StorylineList.Size(m)
+}
+func (m *StorylineList) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorylineList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorylineList proto.InternalMessageInfo
+
+func (m *StorylineList) GetStorylines() []*Storyline {
+	if m != nil {
+		return m.Storylines
+	}
+	return nil
+}
+
+type StorylineDetail struct {
+	Storyline            *Storyline `protobuf:"bytes,1,opt,name=storyline,proto3" json:"storyline,omitempty"`
+	Events               []*Event   `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Alerts               []*Alert   `protobuf:"bytes,3,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *StorylineDetail) Reset()         { *m = StorylineDetail{} }
+func (m *StorylineDetail) String() string { return proto.CompactTextString(m) }
+func (*StorylineDetail) ProtoMessage()    {}
+func (*StorylineDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{11}
+}
+
+func (m *StorylineDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorylineDetail.Unmarshal(m, b)
+}
+func (m *StorylineDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorylineDetail.Marshal(b, m, deterministic)
+}
+func (m *StorylineDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorylineDetail.Merge(m, src)
+}
+func (m *StorylineDetail) XXX_Size() int {
+	return xxx_messageInfo_StorylineDetail.Size(m)
+}
+func (m *StorylineDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorylineDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorylineDetail proto.InternalMessageInfo
+
+func (m *StorylineDetail) GetStoryline() *Storyline {
+	if m != nil {
+		return m.Storyline
+	}
+	return nil
+}
+
+func (m *StorylineDetail) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *StorylineDetail) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type TrendingTag struct {
+	Tag                  string               `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Type                 string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Alerts               int64                `protobuf:"varint,3,opt,name=alerts,proto3" json:"alerts,omitempty"`
+	Senders              []string             `protobuf:"bytes,4,rep,name=senders,proto3" json:"senders,omitempty"`
+	Expected             float64              `protobuf:"fixed64,5,opt,name=expected,proto3" json:"expected,omitempty"`
+	Score                float64              `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,7,opt,name=since,proto3" json:"since,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TrendingTag) Reset()         { *m = TrendingTag{} }
+func (m *TrendingTag) String() string { return proto.CompactTextString(m) }
+func (*TrendingTag) ProtoMessage()    {}
+func (*TrendingTag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{12}
+}
+
+func (m *TrendingTag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrendingTag.Unmarshal(m, b)
+}
+func (m *TrendingTag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrendingTag.Marshal(b, m, deterministic)
+}
+func (m *TrendingTag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrendingTag.Merge(m, src)
+}
+func (m *TrendingTag) XXX_Size() int {
+	return xxx_messageInfo_TrendingTag.Size(m)
+}
+func (m *TrendingTag) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrendingTag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrendingTag proto.InternalMessageInfo
+
+func (m *TrendingTag) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *TrendingTag) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TrendingTag) GetAlerts() int64 {
+	if m != nil {
+		return m.Alerts
+	}
+	return 0
+}
+
+func (m *TrendingTag) GetSenders() []string {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+func (m *TrendingTag) GetExpected() float64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
+}
+
+func (m *TrendingTag) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *TrendingTag) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *TrendingTag) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+type TrendingList struct {
+	Tags                 []*TrendingTag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TrendingList) Reset()         { *m = TrendingList{} }
+func (m *TrendingList) String() string { return proto.CompactTextString(m) }
+func (*TrendingList) ProtoMessage()    {}
+func (*TrendingList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{13}
+}
+
+func (m *TrendingList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrendingList.Unmarshal(m, b)
+}
+func (m *TrendingList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrendingList.Marshal(b, m, deterministic)
+}
+func (m *TrendingList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrendingList.Merge(m, src)
+}
+func (m *TrendingList) XXX_Size() int {
+	return xxx_messageInfo_TrendingList.Size(m)
+}
+func (m *TrendingList) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrendingList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrendingList proto.InternalMessageInfo
+
+func (m *TrendingList) GetTags() []*TrendingTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type FindAlertsRequest struct {
+	Start *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// senders limits alerts to those from any of the senders.
+	Senders []string `protobuf:"bytes,3,rep,name=senders,proto3" json:"senders,omitempty"`
+	// tags limits alerts to those with all of the tags.
+	Tags          []string            `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	HasArticleUrl *wrappers.BoolValue `protobuf:"bytes,5,opt,name=has_article_url,json=hasArticleUrl,proto3" json:"has_article_url,omitempty"`
+	// limit and cursor page the results. Without either, every alert is returned.
+	Limit                int32    `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor               string   `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindAlertsRequest) Reset()         { *m = FindAlertsRequest{} }
+func (m *FindAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*FindAlertsRequest) ProtoMessage()    {}
+func (*FindAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{14}
+}
+
+func (m *FindAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindAlertsRequest.Unmarshal(m, b)
+}
+func (m *FindAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *FindAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindAlertsRequest.Merge(m, src)
+}
+func (m *FindAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_FindAlertsRequest.Size(m)
+}
+func (m *FindAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindAlertsRequest proto.InternalMessageInfo
+
+func (m *FindAlertsRequest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *FindAlertsRequest) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *FindAlertsRequest) GetSenders() []string {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+func (m *FindAlertsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *FindAlertsRequest) GetHasArticleUrl() *wrappers.BoolValue {
+	if m != nil {
+		return m.HasArticleUrl
+	}
+	return nil
+}
+
+func (m *FindAlertsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *FindAlertsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type GetAlertRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAlertRequest) Reset()         { *m = GetAlertRequest{} }
+func (m *GetAlertRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAlertRequest) ProtoMessage()    {}
+func (*GetAlertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{15}
+}
+
+func (m *GetAlertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAlertRequest.Unmarshal(m, b)
+}
+func (m *GetAlertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAlertRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAlertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAlertRequest.Merge(m, src)
+}
+func (m *GetAlertRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAlertRequest.Size(m)
+}
+func (m *GetAlertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAlertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAlertRequest proto.InternalMessageInfo
+
+func (m *GetAlertRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type FindEventsRequest struct {
+	Start *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// senders limits events to those including an alert from any of the senders.
+	Senders []string `protobuf:"bytes,3,rep,name=senders,proto3" json:"senders,omitempty"`
+	// tags limits events to those with all of the tags.
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	MinImportance float64                `protobuf:"fixed64,5,opt,name=min_importance,json=minImportance,proto3" json:"min_importance,omitempty"`
+	MinAlerts     int32                  `protobuf:"varint,6,opt,name=min_alerts,json=minAlerts,proto3" json:"min_alerts,omitempty"`
+	HasArticleUrl *wrappers.BoolValue    `protobuf:"bytes,7,opt,name=has_article_url,json=hasArticleUrl,proto3" json:"has_article_url,omitempty"`
+	Sort          FindEventsRequest_Sort `protobuf:"varint,8,opt,name=sort,proto3,enum=newshound.FindEventsRequest_Sort" json:"sort,omitempty"`
+	// newest_first returns the newest events first, like the event_feed endpoint.
+	NewestFirst bool `protobuf:"varint,9,opt,name=newest_first,json=newestFirst,proto3" json:"newest_first,omitempty"`
+	// limit and cursor page the results. Without either, every event is returned.
+	Limit                int32    `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor               string   `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindEventsRequest) Reset()         { *m = FindEventsRequest{} }
+func (m *FindEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*FindEventsRequest) ProtoMessage()    {}
+func (*FindEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{16}
+}
+
+func (m *FindEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindEventsRequest.Unmarshal(m, b)
+}
+func (m *FindEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *FindEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindEventsRequest.Merge(m, src)
+}
+func (m *FindEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_FindEventsRequest.Size(m)
+}
+func (m *FindEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindEventsRequest proto.InternalMessageInfo
+
+func (m *FindEventsRequest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *FindEventsRequest) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *FindEventsRequest) GetSenders() []string {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+func (m *FindEventsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *FindEventsRequest) GetMinImportance() float64 {
+	if m != nil {
+		return m.MinImportance
+	}
+	return 0
+}
+
+func (m *FindEventsRequest) GetMinAlerts() int32 {
+	if m != nil {
+		return m.MinAlerts
+	}
+	return 0
+}
+
+func (m *FindEventsRequest) GetHasArticleUrl() *wrappers.BoolValue {
+	if m != nil {
+		return m.HasArticleUrl
+	}
+	return nil
+}
+
+func (m *FindEventsRequest) GetSort() FindEventsRequest_Sort {
+	if m != nil {
+		return m.Sort
+	}
+	return FindEventsRequest_START
+}
+
+func (m *FindEventsRequest) GetNewestFirst() bool {
+	if m != nil {
+		return m.NewestFirst
+	}
+	return false
+}
+
+func (m *FindEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *FindEventsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type GetEventRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEventRequest) Reset()         { *m = GetEventRequest{} }
+func (m *GetEventRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEventRequest) ProtoMessage()    {}
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{17}
+}
+
+func (m *GetEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEventRequest.Unmarshal(m, b)
+}
+func (m *GetEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEventRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEventRequest.Merge(m, src)
+}
+func (m *GetEventRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEventRequest.Size(m)
+}
+func (m *GetEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEventRequest proto.InternalMessageInfo
+
+func (m *GetEventRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	Senders []string `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	Tags    []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// last_id resumes the stream after the last update received.
+	LastId               uint64   `protobuf:"varint,3,opt,name=last_id,json=lastId,proto3" json:"last_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamEventsRequest) Reset()         { *m = StreamEventsRequest{} }
+func (m *StreamEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamEventsRequest) ProtoMessage()    {}
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{18}
+}
+
+func (m *StreamEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamEventsRequest.Unmarshal(m, b)
+}
+func (m *StreamEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamEventsRequest.Merge(m, src)
+}
+func (m *StreamEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamEventsRequest.Size(m)
+}
+func (m *StreamEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamEventsRequest proto.InternalMessageInfo
+
+func (m *StreamEventsRequest) GetSenders() []string {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+func (m *StreamEventsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *StreamEventsRequest) GetLastId() uint64 {
+	if m != nil {
+		return m.LastId
+	}
+	return 0
+}
+
+type FindStorylinesRequest struct {
+	Start                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FindStorylinesRequest) Reset()         { *m = FindStorylinesRequest{} }
+func (m *FindStorylinesRequest) String() string { return proto.CompactTextString(m) }
+func (*FindStorylinesRequest) ProtoMessage()    {}
+func (*FindStorylinesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{19}
+}
+
+func (m *FindStorylinesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindStorylinesRequest.Unmarshal(m, b)
+}
+func (m *FindStorylinesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindStorylinesRequest.Marshal(b, m, deterministic)
+}
+func (m *FindStorylinesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindStorylinesRequest.Merge(m, src)
+}
+func (m *FindStorylinesRequest) XXX_Size() int {
+	return xxx_messageInfo_FindStorylinesRequest.Size(m)
+}
+func (m *FindStorylinesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindStorylinesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindStorylinesRequest proto.InternalMessageInfo
+
+func (m *FindStorylinesRequest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *FindStorylinesRequest) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+type GetStorylineRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStorylineRequest) Reset()         { *m = GetStorylineRequest{} }
+func (m *GetStorylineRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStorylineRequest) ProtoMessage()    {}
+func (*GetStorylineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{20}
+}
+
+func (m *GetStorylineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorylineRequest.Unmarshal(m, b)
+}
+func (m *GetStorylineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorylineRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStorylineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorylineRequest.Merge(m, src)
+}
+func (m *GetStorylineRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStorylineRequest.Size(m)
+}
+func (m *GetStorylineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorylineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorylineRequest proto.InternalMessageInfo
+
+func (m *GetStorylineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetTrendingRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTrendingRequest) Reset()         { *m = GetTrendingRequest{} }
+func (m *GetTrendingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTrendingRequest) ProtoMessage()    {}
+func (*GetTrendingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{21}
+}
+
+func (m *GetTrendingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTrendingRequest.Unmarshal(m, b)
+}
+func (m *GetTrendingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTrendingRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTrendingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTrendingRequest.Merge(m, src)
+}
+func (m *GetTrendingRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTrendingRequest.Size(m)
+}
+func (m *GetTrendingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTrendingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTrendingRequest proto.InternalMessageInfo
+
+// ReportRequest computes a report over a custom time range if both start and
+// end are given. Otherwise the stored report is returned.
+type ReportRequest struct {
+	Start                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Senders              []string             `protobuf:"bytes,3,rep,name=senders,proto3" json:"senders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ReportRequest) Reset()         { *m = ReportRequest{} }
+func (m *ReportRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportRequest) ProtoMessage()    {}
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{22}
+}
+
+func (m *ReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportRequest.Unmarshal(m, b)
+}
+func (m *ReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportRequest.Merge(m, src)
+}
+func (m *ReportRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportRequest.Size(m)
+}
+func (m *ReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportRequest proto.InternalMessageInfo
+
+func (m *ReportRequest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *ReportRequest) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *ReportRequest) GetSenders() []string {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+type SenderInfoRequest struct {
+	Sender               string               `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SenderInfoRequest) Reset()         { *m = SenderInfoRequest{} }
+func (m *SenderInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*SenderInfoRequest) ProtoMessage()    {}
+func (*SenderInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{23}
+}
+
+func (m *SenderInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SenderInfoRequest.Unmarshal(m, b)
+}
+func (m *SenderInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SenderInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *SenderInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SenderInfoRequest.Merge(m, src)
+}
+func (m *SenderInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_SenderInfoRequest.Size(m)
+}
+func (m *SenderInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SenderInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SenderInfoRequest proto.InternalMessageInfo
+
+func (m *SenderInfoRequest) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *SenderInfoRequest) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SenderInfoRequest) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+type AvgAlerts struct {
+	AvgAlerts            float64  `protobuf:"fixed64,1,opt,name=avg_alerts,json=avgAlerts,proto3" json:"avg_alerts,omitempty"`
+	TotalAlerts          int64    `protobuf:"varint,2,opt,name=total_alerts,json=totalAlerts,proto3" json:"total_alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AvgAlerts) Reset()         { *m = AvgAlerts{} }
+func (m *AvgAlerts) String() string { return proto.CompactTextString(m) }
+func (*AvgAlerts) ProtoMessage()    {}
+func (*AvgAlerts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{24}
+}
+
+func (m *AvgAlerts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AvgAlerts.Unmarshal(m, b)
+}
+func (m *AvgAlerts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AvgAlerts.Marshal(b, m, deterministic)
+}
+func (m *AvgAlerts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AvgAlerts.Merge(m, src)
+}
+func (m *AvgAlerts) XXX_Size() int {
+	return xxx_messageInfo_AvgAlerts.Size(m)
+}
+func (m *AvgAlerts) XXX_DiscardUnknown() {
+	xxx_messageInfo_AvgAlerts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AvgAlerts proto.InternalMessageInfo
+
+func (m *AvgAlerts) GetAvgAlerts() float64 {
+	if m != nil {
+		return m.AvgAlerts
+	}
+	return 0
+}
+
+func (m *AvgAlerts) GetTotalAlerts() int64 {
+	if m != nil {
+		return m.TotalAlerts
+	}
+	return 0
+}
+
+type AlertsPerWeekReport struct {
+	Senders              []*AlertsPerWeekReport_Sender `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *AlertsPerWeekReport) Reset()         { *m = AlertsPerWeekReport{} }
+func (m *AlertsPerWeekReport) String() string { return proto.CompactTextString(m) }
+func (*AlertsPerWeekReport) ProtoMessage()    {}
+func (*AlertsPerWeekReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{25}
+}
+
+func (m *AlertsPerWeekReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertsPerWeekReport.Unmarshal(m, b)
+}
+func (m *AlertsPerWeekReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertsPerWeekReport.Marshal(b, m, deterministic)
+}
+func (m *AlertsPerWeekReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertsPerWeekReport.Merge(m, src)
+}
+func (m *AlertsPerWeekReport) XXX_Size() int {
+	return xxx_messageInfo_AlertsPerWeekReport.Size(m)
+}
+func (m *AlertsPerWeekReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertsPerWeekReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertsPerWeekReport proto.InternalMessageInfo
+
+func (m *AlertsPerWeekReport) GetSenders() []*AlertsPerWeekReport_Sender {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+type AlertsPerWeekReport_Sender struct {
+	Sender string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// values are keyed by timeframe.
+	Values               map[string]*AvgAlerts `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *AlertsPerWeekReport_Sender) Reset()         { *m = AlertsPerWeekReport_Sender{} }
+func (m *AlertsPerWeekReport_Sender) String() string { return proto.CompactTextString(m) }
+func (*AlertsPerWeekReport_Sender) ProtoMessage()    {}
+func (*AlertsPerWeekReport_Sender) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{25, 0}
+}
+
+func (m *AlertsPerWeekReport_Sender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertsPerWeekReport_Sender.Unmarshal(m, b)
+}
+func (m *AlertsPerWeekReport_Sender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertsPerWeekReport_Sender.Marshal(b, m, deterministic)
+}
+func (m *AlertsPerWeekReport_Sender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertsPerWeekReport_Sender.Merge(m, src)
+}
+func (m *AlertsPerWeekReport_Sender) XXX_Size() int {
+	return xxx_messageInfo_AlertsPerWeekReport_Sender.Size(m)
+}
+func (m *AlertsPerWeekReport_Sender) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertsPerWeekReport_Sender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertsPerWeekReport_Sender proto.InternalMessageInfo
+
+func (m *AlertsPerWeekReport_Sender) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *AlertsPerWeekReport_Sender) GetValues() map[string]*AvgAlerts {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type AvgEvents struct {
+	AvgEvents            float64  `protobuf:"fixed64,1,opt,name=avg_events,json=avgEvents,proto3" json:"avg_events,omitempty"`
+	TotalEvents          int64    `protobuf:"varint,2,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	TotalRank            int64    `protobuf:"varint,3,opt,name=total_rank,json=totalRank,proto3" json:"total_rank,omitempty"`
+	AvgRank              float64  `protobuf:"fixed64,4,opt,name=avg_rank,json=avgRank,proto3" json:"avg_rank,omitempty"`
+	TotalTimeLapsed      int64    `protobuf:"varint,5,opt,name=total_time_lapsed,json=totalTimeLapsed,proto3" json:"total_time_lapsed,omitempty"`
+	AvgTimeLapsed        float64  `protobuf:"fixed64,6,opt,name=avg_time_lapsed,json=avgTimeLapsed,proto3" json:"avg_time_lapsed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AvgEvents) Reset()         { *m = AvgEvents{} }
+func (m *AvgEvents) String() string { return proto.CompactTextString(m) }
+func (*AvgEvents) ProtoMessage()    {}
+func (*AvgEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{26}
+}
+
+func (m *AvgEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AvgEvents.Unmarshal(m, b)
+}
+func (m *AvgEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AvgEvents.Marshal(b, m, deterministic)
+}
+func (m *AvgEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AvgEvents.Merge(m, src)
+}
+func (m *AvgEvents) XXX_Size() int {
+	return xxx_messageInfo_AvgEvents.Size(m)
+}
+func (m *AvgEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_AvgEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AvgEvents proto.InternalMessageInfo
+
+func (m *AvgEvents) GetAvgEvents() float64 {
+	if m != nil {
+		return m.AvgEvents
+	}
+	return 0
+}
+
+func (m *AvgEvents) GetTotalEvents() int64 {
+	if m != nil {
+		return m.TotalEvents
+	}
+	return 0
+}
+
+func (m *AvgEvents) GetTotalRank() int64 {
+	if m != nil {
+		return m.TotalRank
+	}
+	return 0
+}
+
+func (m *AvgEvents) GetAvgRank() float64 {
+	if m != nil {
+		return m.AvgRank
+	}
+	return 0
+}
+
+func (m *AvgEvents) GetTotalTimeLapsed() int64 {
+	if m != nil {
+		return m.TotalTimeLapsed
+	}
+	return 0
+}
+
+func (m *AvgEvents) GetAvgTimeLapsed() float64 {
+	if m != nil {
+		return m.AvgTimeLapsed
+	}
+	return 0
+}
+
+type EventsPerWeekReport struct {
+	Senders              []*EventsPerWeekReport_Sender `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *EventsPerWeekReport) Reset()         { *m = EventsPerWeekReport{} }
+func (m *EventsPerWeekReport) String() string { return proto.CompactTextString(m) }
+func (*EventsPerWeekReport) ProtoMessage()    {}
+func (*EventsPerWeekReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{27}
+}
+
+func (m *EventsPerWeekReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsPerWeekReport.Unmarshal(m, b)
+}
+func (m *EventsPerWeekReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsPerWeekReport.Marshal(b, m, deterministic)
+}
+func (m *EventsPerWeekReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsPerWeekReport.Merge(m, src)
+}
+func (m *EventsPerWeekReport) XXX_Size() int {
+	return xxx_messageInfo_EventsPerWeekReport.Size(m)
+}
+func (m *EventsPerWeekReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsPerWeekReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsPerWeekReport proto.InternalMessageInfo
+
+func (m *EventsPerWeekReport) GetSenders() []*EventsPerWeekReport_Sender {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+type EventsPerWeekReport_Sender struct {
+	Sender string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// values are keyed by timeframe.
+	Values               map[string]*AvgEvents `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *EventsPerWeekReport_Sender) Reset()         { *m = EventsPerWeekReport_Sender{} }
+func (m *EventsPerWeekReport_Sender) String() string { return proto.CompactTextString(m) }
+func (*EventsPerWeekReport_Sender) ProtoMessage()    {}
+func (*EventsPerWeekReport_Sender) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{27, 0}
+}
+
+func (m *EventsPerWeekReport_Sender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsPerWeekReport_Sender.Unmarshal(m, b)
+}
+func (m *EventsPerWeekReport_Sender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsPerWeekReport_Sender.Marshal(b, m, deterministic)
+}
+func (m *EventsPerWeekReport_Sender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsPerWeekReport_Sender.Merge(m, src)
+}
+func (m *EventsPerWeekReport_Sender) XXX_Size() int {
+	return xxx_messageInfo_EventsPerWeekReport_Sender.Size(m)
+}
+func (m *EventsPerWeekReport_Sender) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsPerWeekReport_Sender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsPerWeekReport_Sender proto.InternalMessageInfo
+
+func (m *EventsPerWeekReport_Sender) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *EventsPerWeekReport_Sender) GetValues() map[string]*AvgEvents {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type EventAttendance struct {
+	Attendance           float64  `protobuf:"fixed64,1,opt,name=attendance,proto3" json:"attendance,omitempty"`
+	TotalEvents          int64    `protobuf:"varint,2,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventAttendance) Reset()         { *m = EventAttendance{} }
+func (m *EventAttendance) String() string { return proto.CompactTextString(m) }
+func (*EventAttendance) ProtoMessage()    {}
+func (*EventAttendance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{28}
+}
+
+func (m *EventAttendance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventAttendance.Unmarshal(m, b)
+}
+func (m *EventAttendance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventAttendance.Marshal(b, m, deterministic)
+}
+func (m *EventAttendance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAttendance.Merge(m, src)
+}
+func (m *EventAttendance) XXX_Size() int {
+	return xxx_messageInfo_EventAttendance.Size(m)
+}
+func (m *EventAttendance) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAttendance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAttendance proto.InternalMessageInfo
+
+func (m *EventAttendance) GetAttendance() float64 {
+	if m != nil {
+		return m.Attendance
+	}
+	return 0
+}
+
+func (m *EventAttendance) GetTotalEvents() int64 {
+	if m != nil {
+		return m.TotalEvents
+	}
+	return 0
+}
+
+type EventAttendanceReport struct {
+	Senders              []*EventAttendanceReport_Sender `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *EventAttendanceReport) Reset()         { *m = EventAttendanceReport{} }
+func (m *EventAttendanceReport) String() string { return proto.CompactTextString(m) }
+func (*EventAttendanceReport) ProtoMessage()    {}
+func (*EventAttendanceReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{29}
+}
+
+func (m *EventAttendanceReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventAttendanceReport.Unmarshal(m, b)
+}
+func (m *EventAttendanceReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventAttendanceReport.Marshal(b, m, deterministic)
+}
+func (m *EventAttendanceReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAttendanceReport.Merge(m, src)
+}
+func (m *EventAttendanceReport) XXX_Size() int {
+	return xxx_messageInfo_EventAttendanceReport.Size(m)
+}
+func (m *EventAttendanceReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAttendanceReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAttendanceReport proto.InternalMessageInfo
+
+func (m *EventAttendanceReport) GetSenders() []*EventAttendanceReport_Sender {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+type EventAttendanceReport_Sender struct {
+	Sender string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// values are keyed by timeframe.
+	Values               map[string]*EventAttendance `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *EventAttendanceReport_Sender) Reset()         { *m = EventAttendanceReport_Sender{} }
+func (m *EventAttendanceReport_Sender) String() string { return proto.CompactTextString(m) }
+func (*EventAttendanceReport_Sender) ProtoMessage()    {}
+func (*EventAttendanceReport_Sender) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{29, 0}
+}
+
+func (m *EventAttendanceReport_Sender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventAttendanceReport_Sender.Unmarshal(m, b)
+}
+func (m *EventAttendanceReport_Sender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventAttendanceReport_Sender.Marshal(b, m, deterministic)
+}
+func (m *EventAttendanceReport_Sender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAttendanceReport_Sender.Merge(m, src)
+}
+func (m *EventAttendanceReport_Sender) XXX_Size() int {
+	return xxx_messageInfo_EventAttendanceReport_Sender.Size(m)
+}
+func (m *EventAttendanceReport_Sender) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAttendanceReport_Sender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAttendanceReport_Sender proto.InternalMessageInfo
+
+func (m *EventAttendanceReport_Sender) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *EventAttendanceReport_Sender) GetValues() map[string]*EventAttendance {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SenderInfo struct {
+	AlertsPerWeek        []*SenderInfo_AlertWeek `protobuf:"bytes,1,rep,name=alerts_per_week,json=alertsPerWeek,proto3" json:"alerts_per_week,omitempty"`
+	EventsPerWeek        []*SenderInfo_EventWeek `protobuf:"bytes,2,rep,name=events_per_week,json=eventsPerWeek,proto3" json:"events_per_week,omitempty"`
+	Tags                 []*SenderInfo_Tag       `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	AlertsPerHour        []int64                 `protobuf:"varint,4,rep,packed,name=alerts_per_hour,json=alertsPerHour,proto3" json:"alerts_per_hour,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *SenderInfo) Reset()         { *m = SenderInfo{} }
+func (m *SenderInfo) String() string { return proto.CompactTextString(m) }
+func (*SenderInfo) ProtoMessage()    {}
+func (*SenderInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{30}
+}
+
+func (m *SenderInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SenderInfo.Unmarshal(m, b)
+}
+func (m *SenderInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SenderInfo.Marshal(b, m, deterministic)
+}
+func (m *SenderInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SenderInfo.Merge(m, src)
+}
+func (m *SenderInfo) XXX_Size() int {
+	return xxx_messageInfo_SenderInfo.Size(m)
+}
+func (m *SenderInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SenderInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SenderInfo proto.InternalMessageInfo
+
+func (m *SenderInfo) GetAlertsPerWeek() []*SenderInfo_AlertWeek {
+	if m != nil {
+		return m.AlertsPerWeek
+	}
+	return nil
+}
+
+func (m *SenderInfo) GetEventsPerWeek() []*SenderInfo_EventWeek {
+	if m != nil {
+		return m.EventsPerWeek
+	}
+	return nil
+}
+
+func (m *SenderInfo) GetTags() []*SenderInfo_Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *SenderInfo) GetAlertsPerHour() []int64 {
+	if m != nil {
+		return m.AlertsPerHour
+	}
+	return nil
+}
+
+type SenderInfo_AlertWeek struct {
+	WeekStart            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	Alerts               int64                `protobuf:"varint,2,opt,name=alerts,proto3" json:"alerts,omitempty"`
+	TagMap               map[string]int64     `protobuf:"bytes,3,rep,name=tag_map,json=tagMap,proto3" json:"tag_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SenderInfo_AlertWeek) Reset()         { *m = SenderInfo_AlertWeek{} }
+func (m *SenderInfo_AlertWeek) String() string { return proto.CompactTextString(m) }
+func (*SenderInfo_AlertWeek) ProtoMessage()    {}
+func (*SenderInfo_AlertWeek) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{30, 0}
+}
+
+func (m *SenderInfo_AlertWeek) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SenderInfo_AlertWeek.Unmarshal(m, b)
+}
+func (m *SenderInfo_AlertWeek) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SenderInfo_AlertWeek.Marshal(b, m, deterministic)
+}
+func (m *SenderInfo_AlertWeek) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SenderInfo_AlertWeek.Merge(m, src)
+}
+func (m *SenderInfo_AlertWeek) XXX_Size() int {
+	return xxx_messageInfo_SenderInfo_AlertWeek.Size(m)
+}
+func (m *SenderInfo_AlertWeek) XXX_DiscardUnknown() {
+	xxx_messageInfo_SenderInfo_AlertWeek.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SenderInfo_AlertWeek proto.InternalMessageInfo
+
+func (m *SenderInfo_AlertWeek) GetWeekStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.WeekStart
+	}
+	return nil
+}
+
+func (m *SenderInfo_AlertWeek) GetAlerts() int64 {
+	if m != nil {
+		return m.Alerts
+	}
+	return 0
+}
+
+func (m *SenderInfo_AlertWeek) GetTagMap() map[string]int64 {
+	if m != nil {
+		return m.TagMap
+	}
+	return nil
+}
+
+type SenderInfo_EventWeek struct {
+	WeekStart            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	TotalEvents          int64                `protobuf:"varint,2,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	TotalRank            int64                `protobuf:"varint,3,opt,name=total_rank,json=totalRank,proto3" json:"total_rank,omitempty"`
+	AvgRank              float64              `protobuf:"fixed64,4,opt,name=avg_rank,json=avgRank,proto3" json:"avg_rank,omitempty"`
+	TotalTimeLapsed      int64                `protobuf:"varint,5,opt,name=total_time_lapsed,json=totalTimeLapsed,proto3" json:"total_time_lapsed,omitempty"`
+	AvgTimeLapsed        float64              `protobuf:"fixed64,6,opt,name=avg_time_lapsed,json=avgTimeLapsed,proto3" json:"avg_time_lapsed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SenderInfo_EventWeek) Reset()         { *m = SenderInfo_EventWeek{} }
+func (m *SenderInfo_EventWeek) String() string { return proto.CompactTextString(m) }
+func (*SenderInfo_EventWeek) ProtoMessage()    {}
+func (*SenderInfo_EventWeek) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{30, 1}
+}
+
+func (m *SenderInfo_EventWeek) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SenderInfo_EventWeek.Unmarshal(m, b)
+}
+func (m *SenderInfo_EventWeek) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SenderInfo_EventWeek.Marshal(b, m, deterministic)
+}
+func (m *SenderInfo_EventWeek) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SenderInfo_EventWeek.Merge(m, src)
+}
+func (m *SenderInfo_EventWeek) XXX_Size() int {
+	return xxx_messageInfo_SenderInfo_EventWeek.Size(m)
+}
+func (m *SenderInfo_EventWeek) XXX_DiscardUnknown() {
+	xxx_messageInfo_SenderInfo_EventWeek.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SenderInfo_EventWeek proto.InternalMessageInfo
+
+func (m *SenderInfo_EventWeek) GetWeekStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.WeekStart
+	}
+	return nil
+}
+
+func (m *SenderInfo_EventWeek) GetTotalEvents() int64 {
+	if m != nil {
+		return m.TotalEvents
+	}
+	return 0
+}
+
+func (m *SenderInfo_EventWeek) GetTotalRank() int64 {
+	if m != nil {
+		return m.TotalRank
+	}
+	return 0
+}
+
+func (m *SenderInfo_EventWeek) GetAvgRank() float64 {
+	if m != nil {
+		return m.AvgRank
+	}
+	return 0
+}
+
+func (m *SenderInfo_EventWeek) GetTotalTimeLapsed() int64 {
+	if m != nil {
+		return m.TotalTimeLapsed
+	}
+	return 0
+}
+
+func (m *SenderInfo_EventWeek) GetAvgTimeLapsed() float64 {
+	if m != nil {
+		return m.AvgTimeLapsed
+	}
+	return 0
+}
+
+type SenderInfo_Tag struct {
+	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Frequency            int64    `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SenderInfo_Tag) Reset()         { *m = SenderInfo_Tag{} }
+func (m *SenderInfo_Tag) String() string { return proto.CompactTextString(m) }
+func (*SenderInfo_Tag) ProtoMessage()    {}
+func (*SenderInfo_Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005737e2673ae234, []int{30, 2}
+}
+
+func (m *SenderInfo_Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SenderInfo_Tag.Unmarshal(m, b)
+}
+func (m *SenderInfo_Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SenderInfo_Tag.Marshal(b, m, deterministic)
+}
+func (m *SenderInfo_Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SenderInfo_Tag.Merge(m, src)
+}
+func (m *SenderInfo_Tag) XXX_Size() int {
+	return xxx_messageInfo_SenderInfo_Tag.Size(m)
+}
+func (m *SenderInfo_Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_SenderInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SenderInfo_Tag proto.InternalMessageInfo
+
+func (m *SenderInfo_Tag) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *SenderInfo_Tag) GetFrequency() int64 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("newshound.EventUpdate_Kind", EventUpdate_Kind_name, EventUpdate_Kind_value)
+	proto.RegisterEnum("newshound.FindEventsRequest_Sort", FindEventsRequest_Sort_name, FindEventsRequest_Sort_value)
+	proto.RegisterType((*Entity)(nil), "newshound.Entity")
+	proto.RegisterType((*Sentence)(nil), "newshound.Sentence")
+	proto.RegisterType((*Alert)(nil), "newshound.Alert")
+	proto.RegisterType((*AlertList)(nil), "newshound.AlertList")
+	proto.RegisterType((*AlertPage)(nil), "newshound.AlertPage")
+	proto.RegisterType((*EventAlert)(nil), "newshound.EventAlert")
+	proto.RegisterType((*Event)(nil), "newshound.Event")
+	proto.RegisterType((*EventPage)(nil), "newshound.EventPage")
+	proto.RegisterType((*EventUpdate)(nil), "newshound.EventUpdate")
+	proto.RegisterType((*Storyline)(nil), "newshound.Storyline")
+	proto.RegisterType((*StorylineList)(nil), "newshound.StorylineList")
+	proto.RegisterType((*StorylineDetail)(nil), "newshound.StorylineDetail")
+	proto.RegisterType((*TrendingTag)(nil), "newshound.TrendingTag")
+	proto.RegisterType((*TrendingList)(nil), "newshound.TrendingList")
+	proto.RegisterType((*FindAlertsRequest)(nil), "newshound.FindAlertsRequest")
+	proto.RegisterType((*GetAlertRequest)(nil), "newshound.GetAlertRequest")
+	proto.RegisterType((*FindEventsRequest)(nil), "newshound.FindEventsRequest")
+	proto.RegisterType((*GetEventRequest)(nil), "newshound.GetEventRequest")
+	proto.RegisterType((*StreamEventsRequest)(nil), "newshound.StreamEventsRequest")
+	proto.RegisterType((*FindStorylinesRequest)(nil), "newshound.FindStorylinesRequest")
+	proto.RegisterType((*GetStorylineRequest)(nil), "newshound.GetStorylineRequest")
+	proto.RegisterType((*GetTrendingRequest)(nil), "newshound.GetTrendingRequest")
+	proto.RegisterType((*ReportRequest)(nil), "newshound.ReportRequest")
+	proto.RegisterType((*SenderInfoRequest)(nil), "newshound.SenderInfoRequest")
+	proto.RegisterType((*AvgAlerts)(nil), "newshound.AvgAlerts")
+	proto.RegisterType((*AlertsPerWeekReport)(nil), "newshound.AlertsPerWeekReport")
+	proto.RegisterType((*AlertsPerWeekReport_Sender)(nil), "newshound.AlertsPerWeekReport.Sender")
+	proto.RegisterMapType((map[string]*AvgAlerts)(nil), "newshound.AlertsPerWeekReport.Sender.ValuesEntry")
+	proto.RegisterType((*AvgEvents)(nil), "newshound.AvgEvents")
+	proto.RegisterType((*EventsPerWeekReport)(nil), "newshound.EventsPerWeekReport")
+	proto.RegisterType((*EventsPerWeekReport_Sender)(nil), "newshound.EventsPerWeekReport.Sender")
+	proto.RegisterMapType((map[string]*AvgEvents)(nil), "newshound.EventsPerWeekReport.Sender.ValuesEntry")
+	proto.RegisterType((*EventAttendance)(nil), "newshound.EventAttendance")
+	proto.RegisterType((*EventAttendanceReport)(nil), "newshound.EventAttendanceReport")
+	proto.RegisterType((*EventAttendanceReport_Sender)(nil), "newshound.EventAttendanceReport.Sender")
+	proto.RegisterMapType((map[string]*EventAttendance)(nil), "newshound.EventAttendanceReport.Sender.ValuesEntry")
+	proto.RegisterType((*SenderInfo)(nil), "newshound.SenderInfo")
+	proto.RegisterType((*SenderInfo_AlertWeek)(nil), "newshound.SenderInfo.AlertWeek")
+	proto.RegisterMapType((map[string]int64)(nil), "newshound.SenderInfo.AlertWeek.TagMapEntry")
+	proto.RegisterType((*SenderInfo_EventWeek)(nil), "newshound.SenderInfo.EventWeek")
+	proto.RegisterType((*SenderInfo_Tag)(nil), "newshound.SenderInfo.Tag")
+}
+
+func init() { proto.RegisterFile("newshoundpb/newshound.proto", fileDescriptor_005737e2673ae234) }
+
+var fileDescriptor_005737e2673ae234 = []byte{
+	// 2042 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xdd, 0x6e, 0xdb, 0xc8,
+	0x15, 0x16, 0x45, 0xfd, 0xf1, 0xc8, 0xbf, 0x13, 0x27, 0xcb, 0x65, 0x1c, 0xc7, 0x21, 0x90, 0xad,
+	0x91, 0x76, 0xe5, 0xac, 0x77, 0xd3, 0xee, 0x26, 0x28, 0x0a, 0x67, 0xe3, 0xb8, 0xee, 0x26, 0x8e,
+	0x41, 0x3b, 0xbb, 0x40, 0x51, 0x40, 0x18, 0x9b, 0x63, 0x99, 0xb1, 0x44, 0xaa, 0xe4, 0xc8, 0x5e,
+	0xbd, 0x41, 0x17, 0xbd, 0xdc, 0x16, 0x45, 0x2f, 0xfb, 0x12, 0xbd, 0xea, 0x5d, 0xfb, 0x0c, 0xbd,
+	0xe9, 0x45, 0x1f, 0xa3, 0x97, 0x2d, 0xe6, 0xcc, 0x90, 0x1c, 0x49, 0xb4, 0xa5, 0xa0, 0x4d, 0xdb,
+	0xbd, 0xd3, 0x9c, 0x39, 0x73, 0x38, 0xf3, 0x7d, 0xe7, 0x6f, 0x46, 0x70, 0x3b, 0x64, 0x97, 0xc9,
+	0x59, 0x34, 0x08, 0xfd, 0xfe, 0xf1, 0x66, 0xf6, 0xbb, 0xd5, 0x8f, 0x23, 0x1e, 0x11, 0x2b, 0x13,
+	0x38, 0x77, 0x3b, 0x51, 0xd4, 0xe9, 0xb2, 0x4d, 0x9c, 0x38, 0x1e, 0x9c, 0x6e, 0xf2, 0xa0, 0xc7,
+	0x12, 0x4e, 0x7b, 0x7d, 0xa9, 0xeb, 0xac, 0x8d, 0x2b, 0x5c, 0xc6, 0xb4, 0xdf, 0x67, 0x71, 0x22,
+	0xe7, 0xdd, 0x87, 0x50, 0xdb, 0x09, 0x79, 0xc0, 0x87, 0x84, 0x40, 0x25, 0xa4, 0x3d, 0x66, 0x1b,
+	0xeb, 0xc6, 0x86, 0xe5, 0xe1, 0x6f, 0x21, 0xe3, 0xc3, 0x3e, 0xb3, 0xcb, 0x52, 0x26, 0x7e, 0xbb,
+	0x7b, 0xd0, 0x38, 0x64, 0x21, 0x67, 0xe1, 0x09, 0x23, 0x0e, 0x34, 0x12, 0xf5, 0x5b, 0xad, 0xcb,
+	0xc6, 0xe4, 0x1e, 0xcc, 0x85, 0xd1, 0x20, 0x6c, 0xf7, 0xcf, 0x62, 0x9a, 0xb0, 0xc4, 0x2e, 0xaf,
+	0x9b, 0x1b, 0x96, 0xd7, 0x14, 0xb2, 0x03, 0x29, 0x72, 0xbf, 0x35, 0xa1, 0xba, 0xdd, 0x65, 0x31,
+	0x27, 0x0b, 0x50, 0x0e, 0x7c, 0x65, 0xa2, 0x1c, 0xf8, 0xe4, 0x2e, 0x34, 0x83, 0x30, 0xe1, 0x34,
+	0x3c, 0x61, 0xed, 0xc0, 0x57, 0xdf, 0x87, 0x54, 0xb4, 0x87, 0x0a, 0x34, 0xe6, 0xc1, 0x49, 0x97,
+	0xb5, 0x07, 0x71, 0xd7, 0x36, 0xa5, 0x82, 0x12, 0xbd, 0x8e, 0xbb, 0xe4, 0x16, 0xd4, 0x12, 0x16,
+	0xfa, 0x2c, 0xb6, 0x2b, 0x38, 0xa7, 0x46, 0xe4, 0x53, 0xb0, 0x32, 0x8c, 0xec, 0xea, 0xba, 0xb1,
+	0xd1, 0xdc, 0x72, 0x5a, 0x12, 0xa4, 0x56, 0x0a, 0x52, 0xeb, 0x28, 0xd5, 0xf0, 0x72, 0x65, 0x04,
+	0x83, 0x76, 0x12, 0xbb, 0x86, 0x07, 0xc1, 0xdf, 0xe4, 0x43, 0x68, 0x30, 0x01, 0x5f, 0xc0, 0x12,
+	0xbb, 0xbe, 0x6e, 0x6e, 0x34, 0xb7, 0x96, 0x5b, 0x39, 0x5d, 0x12, 0x59, 0x2f, 0x53, 0x21, 0x36,
+	0xd4, 0x93, 0xc1, 0xf1, 0x1b, 0x76, 0xc2, 0xed, 0x06, 0xee, 0x2a, 0x1d, 0x0a, 0xb4, 0x78, 0xd4,
+	0x6f, 0x67, 0x68, 0x5a, 0x38, 0xdd, 0xe4, 0x51, 0x3f, 0x03, 0xfb, 0x2e, 0x34, 0x63, 0x76, 0x11,
+	0x24, 0x41, 0x14, 0xb6, 0xa3, 0x53, 0x1b, 0xe4, 0x91, 0x53, 0xd1, 0xab, 0x53, 0xb1, 0xc1, 0xe3,
+	0xc8, 0x1f, 0xda, 0x4d, 0xc9, 0x96, 0xf8, 0x4d, 0x3e, 0x02, 0x2b, 0xb5, 0x99, 0xd8, 0x73, 0xb8,
+	0xc3, 0x1b, 0xda, 0x0e, 0x53, 0xe3, 0x5e, 0xae, 0xe5, 0x3e, 0x02, 0x0b, 0x49, 0x79, 0x11, 0x24,
+	0x9c, 0x6c, 0x40, 0x8d, 0x8a, 0x41, 0x62, 0x1b, 0xb8, 0x78, 0x49, 0x5b, 0x8c, 0x5a, 0x9e, 0x9a,
+	0x77, 0xbf, 0x54, 0xcb, 0x0e, 0x68, 0x87, 0xcd, 0xbe, 0x4c, 0x9c, 0x2a, 0x64, 0x5f, 0xf3, 0xf6,
+	0xc9, 0x20, 0x4e, 0xa2, 0x38, 0x65, 0x5a, 0x88, 0x3e, 0x47, 0x89, 0xfb, 0x4d, 0x19, 0x60, 0xe7,
+	0x82, 0x85, 0x5c, 0x7a, 0xca, 0xfb, 0xd0, 0xc0, 0x95, 0xed, 0xcc, 0x5f, 0xea, 0x38, 0xde, 0x7b,
+	0x97, 0x4e, 0x93, 0x52, 0x5f, 0xd5, 0xa8, 0xd7, 0xb8, 0xac, 0x5d, 0xcf, 0x65, 0x7d, 0x92, 0xcb,
+	0x15, 0xa8, 0x46, 0xb1, 0xf8, 0x8e, 0x70, 0x03, 0xd3, 0x93, 0x03, 0xb1, 0x3f, 0xe1, 0x6e, 0xed,
+	0x2e, 0xed, 0x27, 0xcc, 0x47, 0x1f, 0x30, 0x3d, 0x10, 0xa2, 0x17, 0x28, 0x71, 0xff, 0x68, 0x42,
+	0x15, 0xb1, 0x98, 0x08, 0x98, 0x74, 0x87, 0xe5, 0x2b, 0x9c, 0xd3, 0x9c, 0xee, 0x9c, 0x4f, 0xa0,
+	0xc9, 0x84, 0xed, 0x76, 0xc2, 0x69, 0xcc, 0xed, 0xca, 0xd4, 0xd8, 0x00, 0x54, 0x3f, 0x14, 0xda,
+	0xe4, 0x47, 0x60, 0xc9, 0xc5, 0x2c, 0xf4, 0x67, 0x08, 0xab, 0x06, 0x2a, 0xef, 0x84, 0x3e, 0xf9,
+	0xa1, 0xe0, 0xff, 0x32, 0x69, 0x2b, 0x77, 0xa9, 0xe1, 0x3e, 0x6f, 0xea, 0xfb, 0xcc, 0xb8, 0x17,
+	0x6e, 0x71, 0x99, 0x6c, 0x4b, 0xbf, 0x99, 0x01, 0xe4, 0x3b, 0x00, 0x4a, 0x25, 0x45, 0xda, 0xf2,
+	0x2c, 0xa9, 0x20, 0xd0, 0x76, 0xa0, 0x71, 0xc6, 0xa8, 0xdf, 0x0d, 0xc2, 0x34, 0xdc, 0xb2, 0xb1,
+	0x24, 0xb7, 0xd7, 0xa3, 0xf1, 0xd0, 0x06, 0x44, 0x34, 0x1d, 0x92, 0x35, 0x80, 0xa0, 0xd7, 0x8f,
+	0x62, 0xf4, 0x29, 0x0c, 0x35, 0xc3, 0xd3, 0x24, 0x82, 0xd9, 0x1e, 0x7d, 0x13, 0xc5, 0xf6, 0xdc,
+	0xba, 0xb1, 0xd1, 0xf0, 0xe4, 0x40, 0x04, 0x07, 0x9e, 0x23, 0x0d, 0x0e, 0x3c, 0x7e, 0x51, 0x70,
+	0xa0, 0x96, 0xa7, 0xe6, 0xa7, 0x07, 0xc7, 0x6f, 0x0c, 0x68, 0xe2, 0x92, 0xd7, 0x7d, 0x9f, 0x72,
+	0xa6, 0xb9, 0x45, 0x05, 0xdd, 0x62, 0x13, 0x2a, 0xe7, 0x41, 0x28, 0x63, 0x61, 0x61, 0xeb, 0xf6,
+	0xf8, 0x87, 0xe4, 0xaa, 0xd6, 0x17, 0x41, 0xe8, 0x7b, 0xa8, 0x48, 0x3e, 0x80, 0x2a, 0x7e, 0x1b,
+	0x83, 0xa3, 0x68, 0x6b, 0x72, 0xda, 0x5d, 0x85, 0x8a, 0x58, 0x45, 0xea, 0x60, 0xee, 0xef, 0x7c,
+	0xb5, 0x54, 0x22, 0x4d, 0xa8, 0xbf, 0x3e, 0x78, 0xb6, 0x7d, 0xb4, 0xf3, 0x6c, 0xc9, 0x70, 0x7f,
+	0x57, 0x06, 0xeb, 0x90, 0x47, 0xf1, 0x10, 0xc1, 0x7c, 0x07, 0xbe, 0xfa, 0x10, 0xaa, 0xb3, 0x7a,
+	0xa9, 0x54, 0x24, 0x3f, 0x00, 0x73, 0x36, 0xd7, 0x14, 0x6a, 0xe4, 0x76, 0xea, 0xce, 0x81, 0x9f,
+	0x26, 0x7c, 0xe9, 0xb2, 0x7b, 0x7e, 0x32, 0xe2, 0x38, 0xf5, 0x31, 0xc7, 0x19, 0x75, 0x8f, 0xc6,
+	0xb8, 0x7b, 0xb8, 0x3b, 0x30, 0x9f, 0x01, 0x83, 0x09, 0xf6, 0x13, 0x80, 0x24, 0x15, 0xa4, 0x0e,
+	0xb1, 0xa2, 0x67, 0xe8, 0x74, 0xd2, 0xd3, 0xf4, 0xdc, 0xdf, 0x1b, 0xb0, 0x98, 0xcd, 0x3c, 0x63,
+	0x9c, 0x06, 0x5d, 0xb2, 0x05, 0x56, 0xa6, 0x81, 0x68, 0x5f, 0x65, 0x28, 0x57, 0xd3, 0x5c, 0xb1,
+	0x3c, 0xc5, 0x15, 0xf3, 0x8c, 0x6e, 0x4e, 0x29, 0x04, 0xff, 0x34, 0xa0, 0x79, 0x14, 0xb3, 0xd0,
+	0x0f, 0xc2, 0xce, 0x11, 0xed, 0x90, 0x25, 0x30, 0x39, 0xed, 0x28, 0xfe, 0xc5, 0xcf, 0xa2, 0xb6,
+	0x42, 0xa4, 0xde, 0xcc, 0xbe, 0x48, 0x7b, 0x6a, 0x84, 0x91, 0x88, 0xf1, 0x9a, 0xd8, 0x15, 0x15,
+	0x89, 0x72, 0x28, 0x68, 0x60, 0x5f, 0xf7, 0xd9, 0x09, 0x67, 0x92, 0x56, 0xc3, 0xcb, 0xc6, 0x22,
+	0x0a, 0x93, 0x93, 0x28, 0x66, 0x98, 0x9a, 0x0d, 0x4f, 0x0e, 0xd0, 0x6b, 0x82, 0x34, 0x59, 0x4c,
+	0xf3, 0x1a, 0xa1, 0x48, 0x3e, 0x81, 0xfa, 0x00, 0x63, 0xc4, 0xb7, 0x1b, 0x53, 0xd7, 0xa4, 0xaa,
+	0xee, 0x63, 0x98, 0x4b, 0x01, 0x40, 0x8e, 0x1f, 0x28, 0x87, 0x97, 0xec, 0xde, 0xd2, 0x90, 0xd3,
+	0x70, 0x92, 0x81, 0xe0, 0x7e, 0x5b, 0x86, 0xe5, 0xe7, 0x41, 0xe8, 0xcb, 0x34, 0xe7, 0xb1, 0x5f,
+	0x0e, 0x58, 0xc2, 0x73, 0x7f, 0x37, 0xde, 0xd2, 0xdf, 0xcb, 0xb3, 0xf9, 0xbb, 0x86, 0xb2, 0x39,
+	0x8a, 0x72, 0x1a, 0xac, 0x15, 0x2d, 0x58, 0x9f, 0xc2, 0xe2, 0x19, 0x4d, 0xda, 0x7a, 0x2d, 0xbd,
+	0x2a, 0xae, 0x9e, 0x46, 0x51, 0xf7, 0x4b, 0xda, 0x1d, 0x30, 0x6f, 0xfe, 0x8c, 0x26, 0xdb, 0x79,
+	0xa9, 0x5d, 0x81, 0x6a, 0x37, 0xe8, 0x05, 0xb2, 0x78, 0x56, 0x3d, 0x39, 0x10, 0x5e, 0xa0, 0x72,
+	0x9d, 0x0c, 0x2c, 0x35, 0x72, 0xef, 0xc1, 0xe2, 0x2e, 0x53, 0x55, 0x40, 0x41, 0x32, 0x96, 0x55,
+	0xdc, 0xbf, 0x9a, 0x12, 0x38, 0x74, 0xdb, 0xff, 0x53, 0xe0, 0xee, 0xc3, 0x42, 0x2f, 0x08, 0xdb,
+	0x5a, 0x86, 0x90, 0x8e, 0x3b, 0xdf, 0x0b, 0xc2, 0xbd, 0x4c, 0x28, 0x0a, 0x97, 0x50, 0xcb, 0x4a,
+	0xa2, 0x00, 0xc8, 0xea, 0x05, 0xa1, 0x2a, 0x7d, 0x05, 0xf0, 0xd7, 0xdf, 0x16, 0xfe, 0x47, 0x50,
+	0x49, 0xa2, 0x58, 0xb6, 0xa1, 0x0b, 0x5b, 0xf7, 0x34, 0x97, 0x9c, 0xc0, 0xb0, 0x75, 0x18, 0xc5,
+	0xdc, 0x43, 0x75, 0x6c, 0xea, 0xd9, 0x25, 0x4b, 0x78, 0xfb, 0x34, 0x88, 0x13, 0x8e, 0x75, 0xb3,
+	0xe1, 0x35, 0xa5, 0xec, 0xb9, 0x10, 0xe5, 0xc4, 0x42, 0x31, 0xb1, 0xcd, 0x31, 0x62, 0x2b, 0xc2,
+	0x3c, 0xb1, 0xa0, 0x7a, 0x78, 0xb4, 0xed, 0x1d, 0x2d, 0x95, 0xc8, 0x02, 0xc0, 0xde, 0xcb, 0x83,
+	0x57, 0xde, 0xd1, 0xf6, 0xfe, 0xe7, 0x3b, 0x4b, 0x86, 0xe2, 0x5e, 0x66, 0xa3, 0x2b, 0xb8, 0xff,
+	0x05, 0xdc, 0x38, 0xe4, 0x31, 0xa3, 0xbd, 0x51, 0xf2, 0x35, 0x72, 0x8c, 0x62, 0x72, 0xf4, 0x12,
+	0xf4, 0x1e, 0xd4, 0xbb, 0x34, 0xc1, 0xc6, 0xd2, 0xc4, 0x02, 0x5a, 0x13, 0xc3, 0x3d, 0xdf, 0xbd,
+	0x84, 0x9b, 0x02, 0x94, 0x2c, 0x81, 0xfe, 0xb7, 0x9c, 0xcb, 0xbd, 0x0f, 0x37, 0x76, 0x19, 0xcf,
+	0x13, 0xf7, 0x15, 0xa7, 0x5f, 0x01, 0xb2, 0xcb, 0x78, 0x9a, 0x4a, 0x94, 0x96, 0xfb, 0x8d, 0x01,
+	0xf3, 0x1e, 0x13, 0x3e, 0xf5, 0x3f, 0x8f, 0x05, 0xf7, 0xd7, 0x06, 0x2c, 0xcb, 0xae, 0x6b, 0x2f,
+	0x3c, 0x8d, 0xd2, 0xfd, 0xe4, 0xdd, 0xb6, 0x31, 0xd2, 0x6d, 0x67, 0xfb, 0x2c, 0xbf, 0xe5, 0x3e,
+	0xcd, 0xd9, 0x60, 0x7d, 0x09, 0xd6, 0xf6, 0x45, 0x47, 0x05, 0xd3, 0x1d, 0x00, 0x7a, 0xd1, 0x69,
+	0x67, 0xb7, 0x15, 0x11, 0x8e, 0x16, 0xbd, 0xe8, 0xe8, 0x6d, 0x26, 0xa7, 0xdd, 0x54, 0xa1, 0x8c,
+	0xc5, 0xa9, 0x89, 0x32, 0xa9, 0xe2, 0xfe, 0xb6, 0x0c, 0x37, 0xe4, 0xcf, 0x03, 0x16, 0x7f, 0xc5,
+	0xd8, 0xb9, 0x44, 0x9d, 0xfc, 0x64, 0xd4, 0xfb, 0x9a, 0x5b, 0xf7, 0xc7, 0x4b, 0xe6, 0xe8, 0x82,
+	0x96, 0x44, 0x28, 0x43, 0xcd, 0xf9, 0x93, 0x01, 0x35, 0x29, 0xbb, 0x12, 0xaa, 0x3d, 0xa8, 0x5d,
+	0x88, 0xf0, 0x4e, 0xeb, 0xf7, 0x47, 0x33, 0x7d, 0xa2, 0x85, 0x29, 0x21, 0xd9, 0x09, 0x79, 0x3c,
+	0xf4, 0x94, 0x01, 0xe7, 0x15, 0x34, 0x35, 0xb1, 0xa8, 0xda, 0xe7, 0x6c, 0x98, 0x56, 0xed, 0x73,
+	0x36, 0x24, 0x0f, 0xa0, 0x8a, 0xaa, 0x76, 0x79, 0xa2, 0xb7, 0xc8, 0xe0, 0xf4, 0xa4, 0xca, 0xe3,
+	0xf2, 0xa7, 0x86, 0xfb, 0x37, 0x03, 0x71, 0x96, 0x21, 0x99, 0xe2, 0x9c, 0x35, 0xbe, 0x29, 0xce,
+	0x6a, 0x3a, 0xc3, 0x39, 0x6b, 0x47, 0x72, 0x9c, 0x73, 0x0b, 0x52, 0x25, 0xa6, 0xe1, 0xb9, 0xea,
+	0x12, 0x2c, 0x94, 0x78, 0x34, 0x3c, 0xc7, 0x8b, 0xe1, 0x45, 0x47, 0x4e, 0x56, 0xd0, 0x7c, 0x9d,
+	0x5e, 0x74, 0x70, 0xea, 0x01, 0x2c, 0xcb, 0x95, 0xfa, 0xed, 0xaa, 0x8a, 0x06, 0x16, 0x71, 0xe2,
+	0x28, 0xbb, 0x62, 0x91, 0x0f, 0x60, 0x51, 0x98, 0xd1, 0x35, 0x65, 0x0f, 0x31, 0x4f, 0x2f, 0x3a,
+	0xb9, 0x1e, 0xb2, 0x2e, 0x37, 0xf6, 0x16, 0xac, 0x17, 0x2c, 0xf8, 0x4f, 0xb3, 0x7e, 0xf5, 0x27,
+	0xde, 0x05, 0xeb, 0x2a, 0xdf, 0x6a, 0xac, 0x1f, 0xc1, 0x22, 0x0a, 0xb7, 0x39, 0x67, 0xa1, 0x8f,
+	0xe5, 0x6c, 0x0d, 0x80, 0x66, 0x23, 0x45, 0xbd, 0x26, 0x99, 0x81, 0x7b, 0xf7, 0x0f, 0x65, 0xb8,
+	0x39, 0x66, 0x56, 0xe1, 0xbd, 0x3d, 0x8e, 0xf7, 0xf7, 0x26, 0xee, 0x8e, 0x63, 0x4b, 0x26, 0x10,
+	0xff, 0xcb, 0x74, 0xc4, 0xbf, 0x18, 0x43, 0xfc, 0xe3, 0x19, 0x3f, 0x52, 0x88, 0xf9, 0xeb, 0x69,
+	0x98, 0x3f, 0x1c, 0xc5, 0xdc, 0xb9, 0xe6, 0x63, 0x1a, 0xf2, 0xbf, 0xaa, 0x01, 0xe4, 0x49, 0x96,
+	0xec, 0xc2, 0xa2, 0xcc, 0x59, 0xed, 0x3e, 0x8b, 0xdb, 0x97, 0x8c, 0x9d, 0x2b, 0x80, 0xee, 0x8e,
+	0xbe, 0xff, 0x28, 0x7d, 0x99, 0x2e, 0xd0, 0x69, 0xe6, 0xa9, 0x9e, 0x39, 0x84, 0x21, 0x49, 0x4c,
+	0x6e, 0xa8, 0x7c, 0x9d, 0x21, 0xdc, 0xa2, 0x34, 0xc4, 0x74, 0x67, 0x24, 0x1f, 0xaa, 0xa2, 0x2b,
+	0x2f, 0x10, 0xef, 0x17, 0xaf, 0xce, 0x3a, 0x61, 0x8c, 0xc4, 0xfc, 0x00, 0x67, 0xd1, 0x20, 0xc6,
+	0x5e, 0xca, 0xd4, 0xf6, 0xf7, 0xd3, 0x68, 0x10, 0x3b, 0x7f, 0x37, 0xd4, 0xcb, 0x13, 0x7e, 0xe4,
+	0x33, 0x00, 0xb1, 0xc5, 0xf6, 0xac, 0x95, 0xce, 0x12, 0xda, 0xf2, 0x0d, 0x23, 0xbf, 0x82, 0x94,
+	0x47, 0xae, 0x20, 0xcf, 0xa0, 0xce, 0x69, 0xa7, 0xdd, 0xa3, 0x7d, 0xb5, 0xf5, 0xef, 0x4f, 0x41,
+	0x50, 0x1c, 0xe2, 0x25, 0xed, 0x2b, 0xd6, 0x39, 0x0e, 0x9c, 0xcf, 0xa0, 0xa9, 0x89, 0x0b, 0x58,
+	0x5f, 0xd1, 0x59, 0x37, 0x35, 0x66, 0x9d, 0x7f, 0x18, 0xea, 0xf9, 0xe0, 0xdf, 0x3d, 0xe1, 0x77,
+	0x2d, 0xcb, 0x3a, 0x8f, 0xc0, 0x2c, 0xbe, 0x42, 0xae, 0x82, 0x75, 0x1a, 0x8b, 0x3e, 0x22, 0x3c,
+	0x19, 0xaa, 0x63, 0xe4, 0x82, 0xad, 0x3f, 0xd7, 0xc1, 0xda, 0x4f, 0x29, 0x22, 0x4f, 0x01, 0xf2,
+	0x1b, 0x15, 0x59, 0x1d, 0xeb, 0x75, 0x47, 0x2e, 0x5a, 0xce, 0xca, 0x78, 0x01, 0x15, 0x2f, 0x36,
+	0x6e, 0x89, 0x3c, 0x86, 0x46, 0x7a, 0x01, 0x21, 0x7a, 0x3c, 0x8e, 0xdd, 0x4a, 0x9c, 0x89, 0x6b,
+	0xb1, 0x5b, 0x22, 0xbb, 0xb0, 0x9c, 0xab, 0xc9, 0xd7, 0xda, 0xe4, 0x5a, 0x23, 0x13, 0x9b, 0x10,
+	0xb7, 0x48, 0xb7, 0x94, 0x1e, 0x44, 0x31, 0xb5, 0x7a, 0x5d, 0xd3, 0x3e, 0x62, 0x23, 0x7b, 0x7a,
+	0xca, 0x0e, 0x82, 0x92, 0xf1, 0x3d, 0xe8, 0x2d, 0xb6, 0x33, 0xf1, 0x12, 0xe0, 0x96, 0xc8, 0xcf,
+	0x60, 0x4e, 0x6f, 0xb3, 0xc9, 0xda, 0xc8, 0xf3, 0xc2, 0x44, 0xff, 0xed, 0xdc, 0x2a, 0x7e, 0x6f,
+	0x72, 0x4b, 0x0f, 0x0d, 0xb2, 0x0f, 0x0b, 0xa3, 0x4d, 0x35, 0x59, 0x1f, 0x3b, 0xcf, 0x44, 0xbf,
+	0xed, 0xd8, 0x45, 0xcf, 0x19, 0x0a, 0x9b, 0x17, 0x30, 0xa7, 0xf7, 0xca, 0x23, 0x7b, 0x2b, 0x68,
+	0xa2, 0x1d, 0xa7, 0xc8, 0x96, 0x7c, 0x49, 0x41, 0xca, 0x9a, 0x5a, 0x4b, 0x4d, 0xee, 0x8c, 0x1a,
+	0x1b, 0x6b, 0xb5, 0x9d, 0xf7, 0x0a, 0x6e, 0xf4, 0x6a, 0x5b, 0xfb, 0xb0, 0x94, 0xb2, 0x9b, 0xe5,
+	0x41, 0xfd, 0x18, 0x23, 0x1d, 0xba, 0xb3, 0x76, 0x7d, 0xfb, 0x96, 0xd9, 0x1b, 0x29, 0xf2, 0x33,
+	0xda, 0x2b, 0x68, 0x0c, 0xdc, 0x12, 0xf1, 0xf0, 0xee, 0x30, 0x5e, 0xb1, 0xaf, 0xb6, 0xb8, 0x3e,
+	0xad, 0xf0, 0xb9, 0x25, 0xf2, 0x1c, 0xe6, 0x05, 0xe2, 0x79, 0x29, 0x5a, 0x2d, 0xcc, 0x97, 0xa9,
+	0xc9, 0x9b, 0x85, 0xb3, 0x6e, 0xe9, 0xe9, 0x8f, 0x7f, 0xfe, 0xa4, 0x13, 0xf0, 0xb3, 0xc1, 0x71,
+	0xeb, 0x24, 0xea, 0x6d, 0xbe, 0xe9, 0xc7, 0xd1, 0x71, 0x10, 0x26, 0x51, 0x98, 0xff, 0x21, 0xb6,
+	0x49, 0xfb, 0xc1, 0xa6, 0xf6, 0x57, 0xd9, 0x13, 0xed, 0xf7, 0x71, 0x0d, 0x73, 0xe1, 0xc7, 0xff,
+	0x1a, 0x00, 0x09, 0xb3, 0x02, 0xdd, 0x4c, 0x1b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NewshoundClient is the client API for Newshound service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NewshoundClient interface {
+	// FindAlerts returns the alerts sent within a time range, oldest first.
+	FindAlerts(ctx context.Context, in *FindAlertsRequest, opts ...grpc.CallOption) (*AlertPage, error)
+	// GetAlert returns an alert along with its body.
+	GetAlert(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*Alert, error)
+	// GetAlertRevisions returns an alert's original and every resend of it.
+	GetAlertRevisions(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*AlertList, error)
+	// FindEvents returns the events that started within a time range.
+	FindEvents(ctx context.Context, in *FindEventsRequest, opts ...grpc.CallOption) (*EventPage, error)
+	// GetEvent returns a single event.
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// StreamEvents sends new and updated events as they are detected.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Newshound_StreamEventsClient, error)
+	// FindStorylines returns the storylines active within a time range.
+	FindStorylines(ctx context.Context, in *FindStorylinesRequest, opts ...grpc.CallOption) (*StorylineList, error)
+	// GetStoryline returns a storyline along with its events and alerts.
+	GetStoryline(ctx context.Context, in *GetStorylineRequest, opts ...grpc.CallOption) (*StorylineDetail, error)
+	// GetTrending returns the tags currently trending across senders.
+	GetTrending(ctx context.Context, in *GetTrendingRequest, opts ...grpc.CallOption) (*TrendingList, error)
+	// GetAlertsPerWeek returns the average alerts per week report.
+	GetAlertsPerWeek(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*AlertsPerWeekReport, error)
+	// GetEventsPerWeek returns the average events per week report.
+	GetEventsPerWeek(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*EventsPerWeekReport, error)
+	// GetEventAttendance returns the event attendance report.
+	GetEventAttendance(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*EventAttendanceReport, error)
+	// GetSenderInfo returns the Sender Info report for a single sender.
+	GetSenderInfo(ctx context.Context, in *SenderInfoRequest, opts ...grpc.CallOption) (*SenderInfo, error)
+}
+
+type newshoundClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNewshoundClient(cc *grpc.ClientConn) NewshoundClient {
+	return &newshoundClient{cc}
+}
+
+func (c *newshoundClient) FindAlerts(ctx context.Context, in *FindAlertsRequest, opts ...grpc.CallOption) (*AlertPage, error) {
+	out := new(AlertPage)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/FindAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetAlert(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*Alert, error) {
+	out := new(Alert)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetAlert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetAlertRevisions(ctx context.Context, in *GetAlertRequest, opts ...grpc.CallOption) (*AlertList, error) {
+	out := new(AlertList)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetAlertRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) FindEvents(ctx context.Context, in *FindEventsRequest, opts ...grpc.CallOption) (*EventPage, error) {
+	out := new(EventPage)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/FindEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Newshound_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Newshound_serviceDesc.Streams[0], "/newshound.Newshound/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &newshoundStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Newshound_StreamEventsClient interface {
+	Recv() (*EventUpdate, error)
+	grpc.ClientStream
+}
+
+type newshoundStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *newshoundStreamEventsClient) Recv() (*EventUpdate, error) {
+	m := new(EventUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *newshoundClient) FindStorylines(ctx context.Context, in *FindStorylinesRequest, opts ...grpc.CallOption) (*StorylineList, error) {
+	out := new(StorylineList)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/FindStorylines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetStoryline(ctx context.Context, in *GetStorylineRequest, opts ...grpc.CallOption) (*StorylineDetail, error) {
+	out := new(StorylineDetail)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetStoryline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetTrending(ctx context.Context, in *GetTrendingRequest, opts ...grpc.CallOption) (*TrendingList, error) {
+	out := new(TrendingList)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetTrending", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetAlertsPerWeek(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*AlertsPerWeekReport, error) {
+	out := new(AlertsPerWeekReport)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetAlertsPerWeek", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetEventsPerWeek(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*EventsPerWeekReport, error) {
+	out := new(EventsPerWeekReport)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetEventsPerWeek", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetEventAttendance(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*EventAttendanceReport, error) {
+	out := new(EventAttendanceReport)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetEventAttendance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newshoundClient) GetSenderInfo(ctx context.Context, in *SenderInfoRequest, opts ...grpc.CallOption) (*SenderInfo, error) {
+	out := new(SenderInfo)
+	err := c.cc.Invoke(ctx, "/newshound.Newshound/GetSenderInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NewshoundServer is the server API for Newshound service.
+type NewshoundServer interface {
+	// FindAlerts returns the alerts sent within a time range, oldest first.
+	FindAlerts(context.Context, *FindAlertsRequest) (*AlertPage, error)
+	// GetAlert returns an alert along with its body.
+	GetAlert(context.Context, *GetAlertRequest) (*Alert, error)
+	// GetAlertRevisions returns an alert's original and every resend of it.
+	GetAlertRevisions(context.Context, *GetAlertRequest) (*AlertList, error)
+	// FindEvents returns the events that started within a time range.
+	FindEvents(context.Context, *FindEventsRequest) (*EventPage, error)
+	// GetEvent returns a single event.
+	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	// StreamEvents sends new and updated events as they are detected.
+	StreamEvents(*StreamEventsRequest, Newshound_StreamEventsServer) error
+	// FindStorylines returns the storylines active within a time range.
+	FindStorylines(context.Context, *FindStorylinesRequest) (*StorylineList, error)
+	// GetStoryline returns a storyline along with its events and alerts.
+	GetStoryline(context.Context, *GetStorylineRequest) (*StorylineDetail, error)
+	// GetTrending returns the tags currently trending across senders.
+	GetTrending(context.Context, *GetTrendingRequest) (*TrendingList, error)
+	// GetAlertsPerWeek returns the average alerts per week report.
+	GetAlertsPerWeek(context.Context, *ReportRequest) (*AlertsPerWeekReport, error)
+	// GetEventsPerWeek returns the average events per week report.
+	GetEventsPerWeek(context.Context, *ReportRequest) (*EventsPerWeekReport, error)
+	// GetEventAttendance returns the event attendance report.
+	GetEventAttendance(context.Context, *ReportRequest) (*EventAttendanceReport, error)
+	// GetSenderInfo returns the Sender Info report for a single sender.
+	GetSenderInfo(context.Context, *SenderInfoRequest) (*SenderInfo, error)
+}
+
+// UnimplementedNewshoundServer can be embedded to have forward compatible implementations.
+type UnimplementedNewshoundServer struct {
+}
+
+func (*UnimplementedNewshoundServer) FindAlerts(ctx context.Context, req *FindAlertsRequest) (*AlertPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAlerts not implemented")
+}
+func (*UnimplementedNewshoundServer) GetAlert(ctx context.Context, req *GetAlertRequest) (*Alert, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlert not implemented")
+}
+func (*UnimplementedNewshoundServer) GetAlertRevisions(ctx context.Context, req *GetAlertRequest) (*AlertList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlertRevisions not implemented")
+}
+func (*UnimplementedNewshoundServer) FindEvents(ctx context.Context, req *FindEventsRequest) (*EventPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindEvents not implemented")
+}
+func (*UnimplementedNewshoundServer) GetEvent(ctx context.Context, req *GetEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (*UnimplementedNewshoundServer) StreamEvents(req *StreamEventsRequest, srv Newshound_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (*UnimplementedNewshoundServer) FindStorylines(ctx context.Context, req *FindStorylinesRequest) (*StorylineList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindStorylines not implemented")
+}
+func (*UnimplementedNewshoundServer) GetStoryline(ctx context.Context, req *GetStorylineRequest) (*StorylineDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoryline not implemented")
+}
+func (*UnimplementedNewshoundServer) GetTrending(ctx context.Context, req *GetTrendingRequest) (*TrendingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrending not implemented")
+}
+func (*UnimplementedNewshoundServer) GetAlertsPerWeek(ctx context.Context, req *ReportRequest) (*AlertsPerWeekReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlertsPerWeek not implemented")
+}
+func (*UnimplementedNewshoundServer) GetEventsPerWeek(ctx context.Context, req *ReportRequest) (*EventsPerWeekReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsPerWeek not implemented")
+}
+func (*UnimplementedNewshoundServer) GetEventAttendance(ctx context.Context, req *ReportRequest) (*EventAttendanceReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventAttendance not implemented")
+}
+func (*UnimplementedNewshoundServer) GetSenderInfo(ctx context.Context, req *SenderInfoRequest) (*SenderInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSenderInfo not implemented")
+}
+
+func RegisterNewshoundServer(s *grpc.Server, srv NewshoundServer) {
+	s.RegisterService(&_Newshound_serviceDesc, srv)
+}
+
+func _Newshound_FindAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).FindAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/FindAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).FindAlerts(ctx, req.(*FindAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetAlert(ctx, req.(*GetAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetAlertRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetAlertRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetAlertRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetAlertRevisions(ctx, req.(*GetAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_FindEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).FindEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/FindEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).FindEvents(ctx, req.(*FindEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NewshoundServer).StreamEvents(m, &newshoundStreamEventsServer{stream})
+}
+
+type Newshound_StreamEventsServer interface {
+	Send(*EventUpdate) error
+	grpc.ServerStream
+}
+
+type newshoundStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *newshoundStreamEventsServer) Send(m *EventUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Newshound_FindStorylines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindStorylinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).FindStorylines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/FindStorylines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).FindStorylines(ctx, req.(*FindStorylinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetStoryline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorylineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetStoryline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetStoryline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetStoryline(ctx, req.(*GetStorylineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetTrending",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetTrending(ctx, req.(*GetTrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetAlertsPerWeek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetAlertsPerWeek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetAlertsPerWeek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetAlertsPerWeek(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetEventsPerWeek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetEventsPerWeek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetEventsPerWeek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetEventsPerWeek(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetEventAttendance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetEventAttendance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetEventAttendance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetEventAttendance(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Newshound_GetSenderInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SenderInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewshoundServer).GetSenderInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/newshound.Newshound/GetSenderInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewshoundServer).GetSenderInfo(ctx, req.(*SenderInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Newshound_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "newshound.Newshound",
+	HandlerType: (*NewshoundServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindAlerts",
+			Handler:    _Newshound_FindAlerts_Handler,
+		},
+		{
+			MethodName: "GetAlert",
+			Handler:    _Newshound_GetAlert_Handler,
+		},
+		{
+			MethodName: "GetAlertRevisions",
+			Handler:    _Newshound_GetAlertRevisions_Handler,
+		},
+		{
+			MethodName: "FindEvents",
+			Handler:    _Newshound_FindEvents_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _Newshound_GetEvent_Handler,
+		},
+		{
+			MethodName: "FindStorylines",
+			Handler:    _Newshound_FindStorylines_Handler,
+		},
+		{
+			MethodName: "GetStoryline",
+			Handler:    _Newshound_GetStoryline_Handler,
+		},
+		{
+			MethodName: "GetTrending",
+			Handler:    _Newshound_GetTrending_Handler,
+		},
+		{
+			MethodName: "GetAlertsPerWeek",
+			Handler:    _Newshound_GetAlertsPerWeek_Handler,
+		},
+		{
+			MethodName: "GetEventsPerWeek",
+			Handler:    _Newshound_GetEventsPerWeek_Handler,
+		},
+		{
+			MethodName: "GetEventAttendance",
+			Handler:    _Newshound_GetEventAttendance_Handler,
+		},
+		{
+			MethodName: "GetSenderInfo",
+			Handler:    _Newshound_GetSenderInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _Newshound_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "newshoundpb/newshound.proto",
+}
@@ -0,0 +1,7 @@
+package newshoundpb
+
+import "google.golang.org/grpc"
+
+// ServiceDesc describes the Newshound service for servers, like gizmo's kit
+// server, that register services by their description.
+var ServiceDesc *grpc.ServiceDesc = &_Newshound_serviceDesc
@@ -0,0 +1,549 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"github.com/jprobinson/newshound/search"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// routeDoc documents an endpoint in the OpenAPI document. Its schemas are
+// generated from the types of the examples, which are what the handler
+// returns, so they can't drift from the handlers.
+type routeDoc struct {
+	summary string
+	// query are the names of the query parameters in queryParams the
+	// endpoint accepts.
+	query []string
+	// body is an example of the JSON request body, if there is one.
+	body interface{}
+	// response is an example of the JSON response. Plain endpoints list
+	// the contentTypes they respond with instead.
+	response     interface{}
+	contentTypes []string
+	// status is the status of a successful response if it isn't a 200.
+	status int
+	// paged endpoints return a Page of results from v1 if a 'limit' or
+	// 'cursor' is given.
+	paged bool
+	// v2Only endpoints were never in v1.
+	v2Only bool
+}
+
+var (
+	alertFilters = []string{"sender", "tag", "has_article_url", "limit", "cursor", "tz"}
+	eventFilters = []string{"min_importance", "sort", "sender", "tag", "min_alerts", "has_article_url"}
+	reportParams = []string{"start", "end", "sender", "tz"}
+	termParams   = withParams(reportParams, "period", "entities")
+	feedTypes    = []string{"application/rss+xml", "application/atom+xml", "application/feed+json"}
+)
+
+// withParams returns a copy of params with more added.
+func withParams(params []string, more ...string) []string {
+	return append(append([]string{}, params...), more...)
+}
+
+// routeDocs documents every route, relative to the API's version prefix, by
+// method.
+var routeDocs = map[string]map[string]routeDoc{
+	"/find_alerts/{start}/{end}": {"GET": {
+		summary:  "News Alerts sent between the start and end dates.",
+		query:    alertFilters,
+		response: []newshound.NewsAlertLite{},
+		paged:    true,
+	}},
+	"/ordered_alerts/{alert_ids}": {"GET": {
+		summary:  "The given News Alerts in chronological order.",
+		response: []newshound.NewsAlertLite{},
+	}},
+	"/alert/{alert_id}": {"GET": {
+		summary:  "A News Alert along with its body. Requires the read-bodies scope.",
+		response: newshound.NewsAlert{},
+	}},
+	"/alert/{alert_id}/revisions": {"GET": {
+		summary:  "The original News Alert and every resend of it.",
+		response: []newshound.NewsAlertLite{},
+	}},
+	"/alert_html/{alert_id}": {"GET": {
+		summary:      "The HTML body of a News Alert. Requires the read-bodies scope.",
+		contentTypes: []string{"text/html"},
+	}},
+	"/export/{kind}/{start}/{end}": {"GET": {
+		summary: "Every 'alerts', 'events' or 'memberships' record between the start and end dates. " +
+			"Exporting alert bodies requires the read-bodies scope.",
+		query:        []string{"format", "fields", "bodies", "tz"},
+		contentTypes: []string{"text/csv; charset=utf-8", "application/x-ndjson", "application/vnd.apache.parquet"},
+	}},
+	"/feed/{format}/events": {"GET": {
+		summary:      "A feed of the newest News Events.",
+		query:        eventFilters,
+		contentTypes: feedTypes,
+	}},
+	"/feed/{format}/sender/{sender}": {"GET": {
+		summary:      "A feed of a sender's newest News Alerts.",
+		contentTypes: feedTypes,
+	}},
+	"/feed/{format}/tag/{tag}": {"GET": {
+		summary:      "A feed of the newest News Events with a tag.",
+		query:        eventFilters,
+		contentTypes: feedTypes,
+	}},
+	"/find_events/{start}/{end}": {"GET": {
+		summary:  "News Events that started between the start and end dates.",
+		query:    withParams(eventFilters, "limit", "cursor", "tz"),
+		response: []newshound.NewsEvent{},
+		paged:    true,
+	}},
+	"/event_feed/{start}/{end}": {"GET": {
+		summary:  "News Events that started between the start and end dates, newest first.",
+		query:    withParams(eventFilters, "limit", "cursor", "tz"),
+		response: []newshound.NewsEvent{},
+		paged:    true,
+	}},
+	"/event/{event_id}": {"GET": {
+		summary:  "A News Event.",
+		response: newshound.NewsEvent{},
+	}},
+	"/event/{event_id}/storyline": {"GET": {
+		summary:  "The Storyline a News Event is part of.",
+		response: newshound.Storyline{},
+	}},
+	"/find_storylines/{start}/{end}": {"GET": {
+		summary:  "Storylines that were active between the start and end dates.",
+		query:    []string{"tz"},
+		response: []newshound.Storyline{},
+	}},
+	"/storyline/{storyline_id}": {"GET": {
+		summary:  "A Storyline along with its events and alerts.",
+		response: StorylineDetail{},
+	}},
+	"/find_entities/{start}/{end}": {"GET": {
+		summary:  "Entities mentioned in News Alerts between the start and end dates.",
+		query:    []string{"type", "tz"},
+		response: []EntityInfo{},
+	}},
+	"/search/{kind}": {"GET": {
+		summary:  "A full-text search of 'alerts' or 'events'. Body highlights require the read-bodies scope.",
+		query:    []string{"q", "sender", "start", "end", "tz", "event", "sort", "offset", "limit"},
+		response: search.Results{},
+	}},
+	"/trending": {"GET": {
+		summary:  "Tags being mentioned much more often than usual.",
+		response: []newshound.TrendingTag{},
+	}},
+	"/report/alerts_per_week": {"GET": {
+		summary:  "The average alerts per week of each sender.",
+		query:    reportParams,
+		response: []AvgAlertsReport{},
+	}},
+	"/report/events_per_week": {"GET": {
+		summary:  "The average events per week of each sender.",
+		query:    reportParams,
+		response: []AvgEventsReport{},
+	}},
+	"/report/event_attendance": {"GET": {
+		summary:  "The share of events each sender sent an alert for.",
+		query:    reportParams,
+		response: []EventAttendReport{},
+	}},
+	"/report/sender_info/{sender}": {"GET": {
+		summary:  "A sender's alerts and events per week, top tags and alerts per hour.",
+		query:    []string{"start", "end", "tz"},
+		response: SenderInfo{},
+	}},
+	"/report/sender_heatmap/{sender}": {"GET": {
+		summary:  "A day of week by hour heatmap of a sender's alerts.",
+		query:    []string{"start", "end", "tz"},
+		response: report.Heatmap{},
+	}},
+	"/report/tag_trend/{tag}": {"GET": {
+		summary:  "The number of alerts mentioning a tag per day or week.",
+		query:    withParams(termParams, "by_sender"),
+		response: []report.TagTrend{},
+	}},
+	"/report/tag_movers": {"GET": {
+		summary:  "The top rising and falling tags of each day or week.",
+		query:    withParams(termParams, "limit"),
+		response: []report.TagMovement{},
+	}},
+	"/report/tag_cooccurrence/{tag}": {"GET": {
+		summary:  "The tags most often used alongside a tag.",
+		query:    withParams(termParams, "limit"),
+		response: TagCoOccurrences{},
+	}},
+	"/report/compare/{start}/{end}": {"GET": {
+		summary:  "A head-to-head report on how 2 or more senders covered events.",
+		query:    []string{"sender", "tz"},
+		response: report.Comparison{},
+	}},
+	"/report/scoop_leaderboard/{timeframe}": {"GET": {
+		summary:  "The senders that were first to report the most events.",
+		response: []report.SenderScoops{},
+	}},
+	"/report/event_scoop/{event_id}": {"GET": {
+		summary:  "Which sender broke a News Event and by how much.",
+		response: report.EventScoop{},
+	}},
+	"/jobs": {"GET": {
+		summary:  "The status of each of fetchd's scheduled jobs.",
+		response: []JobStatus{},
+	}},
+	"/jobs/{job}/runs": {"GET": {
+		summary:  "A job's most recent runs.",
+		query:    []string{"limit"},
+		response: []newshound.JobRun{},
+	}},
+	"/admin/synonyms": {"GET": {
+		summary:  "The tag synonym dictionary.",
+		response: []newshound.TagSynonym{},
+	}},
+	"/admin/synonyms/{tag}": {
+		"PUT": {
+			summary:  "Adds or replaces a tag's synonym.",
+			body:     newshound.TagSynonym{},
+			response: newshound.TagSynonym{},
+		},
+		"DELETE": {
+			summary:  "Removes a tag from the synonym dictionary.",
+			response: "OK",
+		},
+	},
+	"/admin/keys": {
+		"GET": {
+			summary:  "Every API key.",
+			response: []newshound.APIKey{},
+		},
+		"POST": {
+			summary:  "Creates an API key. The key is only ever returned here.",
+			body:     newshound.APIKey{},
+			response: NewAPIKey{},
+			status:   http.StatusCreated,
+		},
+	},
+	"/admin/keys/{key_id}": {"DELETE": {
+		summary:  "Revokes an API key.",
+		response: "OK",
+	}},
+	"/openapi.json": {"GET": {
+		summary:      "This document.",
+		contentTypes: []string{"application/json"},
+		v2Only:       true,
+	}},
+}
+
+// param documents a path or query parameter.
+type param struct {
+	description string
+	schema      string
+}
+
+var pathParams = map[string]param{
+	"start":        {"A YYYY-MM-DD date.", "string"},
+	"end":          {"A YYYY-MM-DD date, inclusive.", "string"},
+	"alert_id":     {"A News Alert ID.", "string"},
+	"alert_ids":    {"Comma separated News Alert IDs.", "string"},
+	"event_id":     {"A News Event ID.", "string"},
+	"storyline_id": {"A Storyline ID.", "string"},
+	"key_id":       {"An API key ID.", "string"},
+	"kind":         {"The kind of records.", "string"},
+	"format":       {"The feed format: 'rss', 'atom' or 'json'.", "string"},
+	"sender":       {"A sender's name.", "string"},
+	"tag":          {"A tag.", "string"},
+	"timeframe":    {"'7days', '3months', '6months' or '12months'.", "string"},
+	"job":          {"A job's name.", "string"},
+}
+
+var queryParams = map[string]param{
+	"sender":          {"Senders to limit the results to. Can be repeated or comma separated.", "string"},
+	"tag":             {"Tags to limit the results to. Can be repeated or comma separated.", "string"},
+	"has_article_url": {"Only include results with or without an article URL.", "boolean"},
+	"limit":           {"The most results to return.", "integer"},
+	"cursor":          {"The next_cursor of the previous page.", "string"},
+	"tz":              {"The IANA time zone dates are in. Stored reports only accept it along with start and end dates.", "string"},
+	"min_importance":  {"The lowest importance score to include.", "number"},
+	"sort":            {"'importance' for events or 'relevance' and 'date' for searches.", "string"},
+	"min_alerts":      {"The fewest alerts an event can have.", "integer"},
+	"type":            {"An entity type to limit the results to.", "string"},
+	"q":               {"The text to search for.", "string"},
+	"start":           {"A YYYY-MM-DD date to start from. Requires 'end'.", "string"},
+	"end":             {"A YYYY-MM-DD date to run through. Requires 'start'.", "string"},
+	"event":           {"A News Event ID to limit alerts to.", "string"},
+	"offset":          {"The number of results to skip.", "integer"},
+	"period":          {"Count by 'day' or 'week'.", "string"},
+	"entities":        {"Count entity names instead of tags.", "boolean"},
+	"by_sender":       {"Add a trend for each sender.", "boolean"},
+	"format":          {"'csv', 'ndjson' or 'parquet'.", "string"},
+	"fields":          {"Comma separated fields to export.", "string"},
+	"bodies":          {"Include alert bodies.", "boolean"},
+}
+
+var openAPI struct {
+	once sync.Once
+	doc  []byte
+	err  error
+}
+
+// getOpenAPI is an http.Handler that will return the OpenAPI document for
+// both versions of the API.
+func (s *service) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPI.once.Do(func() {
+		openAPI.doc, openAPI.err = json.MarshalIndent(openAPIDoc(), "", "  ")
+	})
+	if openAPI.err != nil {
+		status, body, _ := failed(openAPI.err, "build openapi document")
+		writeError(w, status, body)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(openAPI.doc)
+}
+
+type object = map[string]interface{}
+
+// openAPIDoc builds the OpenAPI document from routeDocs. v1 routes are
+// marked deprecated and v2 routes respond with an Envelope.
+func openAPIDoc() object {
+	b := newSchemaBuilder()
+	paths := object{}
+	for _, route := range sortedKeys(routeDocs) {
+		for _, method := range sortedKeys(routeDocs[route]) {
+			doc := routeDocs[route][method]
+			if !doc.v2Only {
+				addOperation(paths, v1Prefix+route, method, b.operation(route, method, doc, false))
+			}
+			addOperation(paths, v2Prefix+route, method, b.operation(route, method, doc, true))
+		}
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":       "Newshound API",
+			"version":     "2",
+			"description": "Breaking news alerts and the events, storylines and reports built from them.",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": b.schemas,
+			"securitySchemes": object{
+				"apiKey":      object{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"apiKeyQuery": object{"type": "apiKey", "in": "query", "name": "api_key"},
+				"adminKey":    object{"type": "apiKey", "in": "header", "name": "X-Admin-Key"},
+			},
+		},
+		// a key is optional unless the service requires one
+		"security": []object{{}, {"apiKey": []string{}}, {"apiKeyQuery": []string{}}},
+	}
+}
+
+func addOperation(paths object, p, method string, op object) {
+	item, ok := paths[p].(object)
+	if !ok {
+		item = object{}
+		paths[p] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+var routeParam = regexp.MustCompile(`{([a-z_]+)}`)
+
+func (b *schemaBuilder) operation(route, method string, doc routeDoc, v2 bool) object {
+	params := []object{}
+	for _, m := range routeParam.FindAllStringSubmatch(route, -1) {
+		p := pathParams[m[1]]
+		params = append(params, object{"name": m[1], "in": "path", "required": true,
+			"description": p.description, "schema": object{"type": p.schema}})
+	}
+	for _, name := range doc.query {
+		p := queryParams[name]
+		params = append(params, object{"name": name, "in": "query",
+			"description": p.description, "schema": object{"type": p.schema}})
+	}
+
+	status := doc.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	content := object{}
+	for _, ct := range doc.contentTypes {
+		content[ct] = object{"schema": object{"type": "string"}}
+	}
+	errorSchema := b.schema(reflect.TypeOf(Error{}))
+	if doc.contentTypes == nil {
+		var schema object
+		if v2 {
+			data, _ := v2Data(doc.response)
+			schema = b.envelope("data", data)
+			errorSchema = b.envelope("error", Error{})
+		} else {
+			schema = b.schema(reflect.TypeOf(doc.response))
+			if doc.paged {
+				schema = object{"oneOf": []object{schema, {
+					"type": "object",
+					"properties": object{
+						"items":       schema,
+						"next_cursor": object{"type": "string"},
+					},
+				}}}
+			}
+		}
+		content[server.JSONContentType] = object{"schema": schema}
+	}
+
+	op := object{
+		"summary":    doc.summary,
+		"parameters": params,
+	}
+	op["responses"] = object{
+		strconv.Itoa(status): object{"description": http.StatusText(status), "content": content},
+		"default": object{
+			"description": "An error. Requests over a rate limit or quota get a 429 with a Retry-After header.",
+			"content":     object{server.JSONContentType: object{"schema": errorSchema}},
+		},
+	}
+	if doc.body != nil {
+		op["requestBody"] = object{
+			"required": true,
+			"content":  object{server.JSONContentType: object{"schema": b.schema(reflect.TypeOf(doc.body))}},
+		}
+	}
+	if strings.HasPrefix(route, "/admin/") {
+		op["security"] = []object{{"adminKey": []string{}}, {"apiKey": []string{}}}
+	}
+	if !v2 {
+		op["deprecated"] = true
+	}
+	return op
+}
+
+// envelope returns the schema of an Envelope holding the example in the
+// given field.
+func (b *schemaBuilder) envelope(field string, example interface{}) object {
+	props := object{"meta": b.schema(reflect.TypeOf(Meta{}))}
+	if example != nil {
+		props[field] = b.schema(reflect.TypeOf(example))
+	}
+	return object{"type": "object", "properties": props}
+}
+
+// schemaBuilder generates JSON schemas from Go types the same way
+// encoding/json would encode them. Named structs are added to the document's
+// components and referenced.
+type schemaBuilder struct {
+	schemas object
+	names   map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{schemas: object{}, names: map[reflect.Type]string{}}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(bson.ObjectId(""))
+)
+
+func (b *schemaBuilder) schema(t reflect.Type) object {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return object{"type": "string", "format": "date-time"}
+	case objectIDType:
+		return object{"type": "string", "pattern": "^[0-9a-f]{24}$"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return object{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return object{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return object{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		return object{"$ref": "#/components/schemas/" + b.component(t)}
+	}
+	// interfaces can hold anything
+	return object{}
+}
+
+// component adds a named struct to the components and returns its name. It
+// is named for its type unless that's taken by another package's type.
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := b.schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	b.names[t] = name
+	// set before building so recursive types can reference it
+	b.schemas[name] = object{}
+	b.schemas[name] = b.object(t)
+	return name
+}
+
+func (b *schemaBuilder) object(t reflect.Type) object {
+	props := object{}
+	b.fields(t, props)
+	return object{"type": "object", "properties": props}
+}
+
+func (b *schemaBuilder) fields(t reflect.Type, props object) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		// untagged embedded structs have their fields promoted
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			b.fields(ft, props)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = b.schema(f.Type)
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
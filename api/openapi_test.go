@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRouteDocs checks the OpenAPI document covers exactly the routes the
+// service registers along with all of their parameters.
+func TestRouteDocs(t *testing.T) {
+	s := &service{}
+	registered := map[string]bool{}
+	for path, methods := range s.Endpoints() {
+		for method := range methods {
+			registered[method+" "+path] = true
+		}
+	}
+	for path, methods := range s.JSONEndpoints() {
+		for method := range methods {
+			registered[method+" "+path] = true
+		}
+	}
+
+	documented := map[string]bool{}
+	for route, methods := range routeDocs {
+		for method, doc := range methods {
+			documented[method+" "+v2Prefix+route] = true
+			if !doc.v2Only {
+				documented[method+" "+v1Prefix+route] = true
+			}
+			for _, m := range routeParam.FindAllStringSubmatch(route, -1) {
+				if _, ok := pathParams[m[1]]; !ok {
+					t.Errorf("%s %s has an undocumented path parameter: %s", method, route, m[1])
+				}
+			}
+			for _, name := range doc.query {
+				if _, ok := queryParams[name]; !ok {
+					t.Errorf("%s %s has an undocumented query parameter: %s", method, route, name)
+				}
+			}
+			if (doc.response == nil) == (doc.contentTypes == nil) {
+				t.Errorf("%s %s needs either a response or content types", method, route)
+			}
+		}
+	}
+
+	for route := range registered {
+		if !documented[route] {
+			t.Errorf("%s is not documented", route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			t.Errorf("%s is documented but not registered", route)
+		}
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	w := httptest.NewRecorder()
+	(&service{}).getOpenAPI(w, httptest.NewRequest("GET", v2Prefix+"/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("getOpenAPI got status:%d want:%d", w.Code, http.StatusOK)
+	}
+	var doc map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("getOpenAPI returned invalid JSON: %s", err)
+	}
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	// every reference resolves and the v2 responses have no '_id' fields
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if k == "$ref" {
+					name := strings.TrimPrefix(child.(string), "#/components/schemas/")
+					if _, ok := schemas[name]; !ok {
+						t.Errorf("%s refers to a missing schema: %s", path, child)
+					}
+					if strings.Contains(path, v2Prefix) {
+						walk(path+" > "+name, schemas[name])
+					}
+					continue
+				}
+				if k == "_id" && strings.Contains(path, v2Prefix) {
+					t.Errorf("%s has an '_id' property", path)
+				}
+				walk(path+"."+k, child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(path, child)
+			}
+		}
+	}
+	walk("", doc)
+
+	paths := doc["paths"].(map[string]interface{})
+	for path, item := range paths {
+		for method, op := range item.(map[string]interface{}) {
+			_, deprecated := op.(map[string]interface{})["deprecated"]
+			if deprecated != strings.HasPrefix(path, v1Prefix) {
+				t.Errorf("%s %s got deprecated:%v", method, path, deprecated)
+			}
+		}
+	}
+}
+
+func TestSchema(t *testing.T) {
+	type inner struct {
+		Name string `json:"name"`
+	}
+	type Example struct {
+		inner
+		Hidden   string `json:"-"`
+		private  string
+		Count    int              `json:"count,omitempty"`
+		Tags     []string         `json:"tags"`
+		Counts   map[string]int64 `json:"counts"`
+		Next     *Example         `json:"next"`
+		Any      interface{}      `json:"any"`
+		Untagged bool
+	}
+
+	b := newSchemaBuilder()
+	got := b.schema(reflect.TypeOf([]Example{}))
+	want := map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Example"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schema got:%v want:%v", got, want)
+	}
+
+	props := b.schemas["Example"].(map[string]interface{})["properties"].(map[string]interface{})
+	var names []string
+	for name := range props {
+		names = append(names, name)
+	}
+	for _, name := range []string{"name", "count", "tags", "counts", "next", "any", "Untagged"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("schema is missing %q, got:%v", name, names)
+		}
+	}
+	if len(props) != 7 {
+		t.Errorf("schema got properties:%v want 7", names)
+	}
+}
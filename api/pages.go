@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// defaultPageLimit is the page size used when a cursor is given without a limit.
+var defaultPageLimit = 100
+
+// maxPageLimit is the largest page that can be requested from a list endpoint.
+var maxPageLimit = 500
+
+// errBadCursor is returned for cursors that were not created by a
+// previous page of the same query.
+var errBadCursor = errors.New("invalid cursor")
+
+// Page is a single page of results from a list endpoint. NextCursor will be
+// empty on the last page.
+type Page struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// cursor marks the last item of a page so the next page can pick up after it.
+// It holds the values of every field the results are sorted by.
+type cursor struct {
+	Sort       string        `bson:"s,omitempty"`
+	Importance float64       `bson:"m,omitempty"`
+	Time       time.Time     `bson:"t"`
+	ID         bson.ObjectId `bson:"i"`
+}
+
+// encode returns the cursor as an opaque, URL safe string.
+func (c cursor) encode() string {
+	b, err := bson.Marshal(c)
+	if err != nil {
+		// a struct of plain values always marshals
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errBadCursor
+	}
+	var c cursor
+	if err = bson.Unmarshal(b, &c); err != nil || !c.ID.Valid() {
+		return nil, errBadCursor
+	}
+	return &c, nil
+}
+
+// PageQuery holds the paging options for a list endpoint. A zero Limit
+// returns every result.
+type PageQuery struct {
+	Limit int
+	// After is the end of the previous page.
+	After *cursor
+}
+
+// Paged reports whether results should be returned a page at a time.
+func (p PageQuery) Paged() bool {
+	return p.Limit > 0
+}
+
+// sortKey is a field results are sorted by along with the cursor's value for it.
+type sortKey struct {
+	field string
+	desc  bool
+	value interface{}
+}
+
+// afterKeys returns the $or clauses matching everything sorted after the given key values.
+func afterKeys(keys []sortKey) []bson.M {
+	or := make([]bson.M, len(keys))
+	for i, key := range keys {
+		cond := bson.M{}
+		for _, prev := range keys[:i] {
+			cond[prev.field] = prev.value
+		}
+		op := "$gt"
+		if key.desc {
+			op = "$lt"
+		}
+		cond[key.field] = bson.M{op: key.value}
+		or[i] = cond
+	}
+	return or
+}
+
+// sortFields returns the mgo sort fields for the keys.
+func sortFields(keys []sortKey) []string {
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key.field
+		if key.desc {
+			fields[i] = "-" + key.field
+		}
+	}
+	return fields
+}
+
+// parsePageQuery pulls the optional 'limit' and 'cursor' parameters from the
+// query string. Results are only paged if one of them is given.
+func parsePageQuery(r *http.Request) (PageQuery, error) {
+	var (
+		p   PageQuery
+		err error
+	)
+	qs := r.URL.Query()
+	if c := qs.Get("cursor"); c != "" {
+		if p.After, err = decodeCursor(c); err != nil {
+			return p, err
+		}
+	}
+	if qs.Get("limit") == "" && p.After == nil {
+		return p, nil
+	}
+	p.Limit, err = parseLimit(r, defaultPageLimit, maxPageLimit)
+	return p, err
+}
+
+// pageQuery builds the paging options from an already parsed limit and cursor,
+// like parsePageQuery does for the query string.
+func pageQuery(limit int, c string) (PageQuery, error) {
+	var (
+		p   PageQuery
+		err error
+	)
+	if c != "" {
+		if p.After, err = decodeCursor(c); err != nil {
+			return p, err
+		}
+	}
+	if limit < 0 || limit > maxPageLimit {
+		return p, fmt.Errorf("invalid limit: %d", limit)
+	}
+	if limit == 0 && p.After != nil {
+		limit = defaultPageLimit
+	}
+	p.Limit = limit
+	return p, nil
+}
+
+// listParam returns the values of a query string parameter that may be
+// repeated or comma separated.
+func listParam(r *http.Request, name string) []string {
+	var values []string
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// boolParam parses an optional boolean query string parameter. A nil
+// result means it was not given.
+func boolParam(r *http.Request, name string) (*bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// hasValue returns the query for a string field that is or is not set. On
+// array fields, has matches if any element is set and !has if none are.
+func hasValue(has bool) bson.M {
+	if has {
+		return bson.M{"$gt": ""}
+	}
+	return bson.M{"$not": bson.M{"$gt": ""}}
+}
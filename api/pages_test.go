@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCursor(t *testing.T) {
+	want := cursor{
+		Sort:       importanceSort,
+		Importance: 42.5,
+		Time:       time.Date(2019, 3, 4, 5, 6, 7, 8000000, time.UTC),
+		ID:         bson.NewObjectId(),
+	}
+	got, err := decodeCursor(want.encode())
+	if err != nil {
+		t.Fatalf("decodeCursor returned an error: %s", err)
+	}
+	got.Time = got.Time.UTC()
+	if *got != want {
+		t.Errorf("decodeCursor got:%#v want:%#v", *got, want)
+	}
+
+	noID, _ := bson.Marshal(cursor{Time: want.Time})
+	for _, bad := range []string{"not a cursor!", "abcd", base64.RawURLEncoding.EncodeToString(noID)} {
+		if _, err := decodeCursor(bad); err != errBadCursor {
+			t.Errorf("decodeCursor(%q) got:%v want:%v", bad, err, errBadCursor)
+		}
+	}
+}
+
+func TestAfterKeys(t *testing.T) {
+	now := time.Now()
+	id := bson.NewObjectId()
+	got := afterKeys([]sortKey{{"importance", true, 10.0}, {"event_start", true, now}, {"_id", true, id}})
+	want := []bson.M{
+		{"importance": bson.M{"$lt": 10.0}},
+		{"importance": 10.0, "event_start": bson.M{"$lt": now}},
+		{"importance": 10.0, "event_start": now, "_id": bson.M{"$lt": id}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("afterKeys got:%#v want:%#v", got, want)
+	}
+}
+
+func TestParseEventQuery(t *testing.T) {
+	yes := true
+	timeCursor := cursor{Time: time.Date(2019, 1, 15, 12, 30, 0, 0, time.UTC), ID: bson.NewObjectId()}
+	importanceCursor := timeCursor
+	importanceCursor.Sort = importanceSort
+
+	tests := []struct {
+		query   string
+		want    EventQuery
+		wantErr bool
+	}{
+		{"", EventQuery{}, false},
+		{"?sender=cnn,fox&sender=nyt&tag=obama&min_alerts=3&has_article_url=true",
+			EventQuery{Senders: []string{"cnn", "fox", "nyt"}, Tags: []string{"obama"}, MinAlerts: 3, HasArticleURL: &yes}, false},
+		{"?limit=50", EventQuery{PageQuery: PageQuery{Limit: 50}}, false},
+		{"?cursor=" + timeCursor.encode(), EventQuery{PageQuery: PageQuery{Limit: defaultPageLimit, After: &timeCursor}}, false},
+		{"?sort=importance&cursor=" + importanceCursor.encode(),
+			EventQuery{ByImportance: true, PageQuery: PageQuery{Limit: defaultPageLimit, After: &importanceCursor}}, false},
+		{"?sort=importance&cursor=" + timeCursor.encode(), EventQuery{}, true},
+		{"?cursor=" + importanceCursor.encode(), EventQuery{}, true},
+		{"?cursor=garbage", EventQuery{}, true},
+		{"?limit=0", EventQuery{}, true},
+		{"?limit=1000", EventQuery{}, true},
+		{"?min_alerts=-1", EventQuery{}, true},
+		{"?has_article_url=maybe", EventQuery{}, true},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/find_events/2019-01-01/2019-01-31"+test.query, nil)
+		got, err := parseEventQuery(r)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseEventQuery(%q) expected an error", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseEventQuery(%q) returned an error: %s", test.query, err)
+			continue
+		}
+		if got.After != nil {
+			// cursor times are decoded in the local time zone
+			if !got.After.Time.Equal(test.want.After.Time) {
+				t.Errorf("parseEventQuery(%q) got cursor time:%s want:%s", test.query, got.After.Time, test.want.After.Time)
+			}
+			got.After.Time = test.want.After.Time
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseEventQuery(%q) got:%#v want:%#v", test.query, got, test.want)
+		}
+	}
+}
+
+func TestAlertQueryFilter(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	no := false
+	after := cursor{Time: start.Add(time.Hour), ID: bson.NewObjectId()}
+
+	q := AlertQuery{
+		Senders:       []string{"cnn"},
+		Tags:          []string{"obama", "congress"},
+		HasArticleURL: &no,
+		PageQuery:     PageQuery{Limit: 10, After: &after},
+	}
+	want := bson.M{
+		"timestamp":   bson.M{"$gte": start, "$lte": end},
+		"sender":      bson.M{"$in": []string{"cnn"}},
+		"tags":        bson.M{"$all": []string{"obama", "congress"}},
+		"article_url": bson.M{"$not": bson.M{"$gt": ""}},
+		"$or": []bson.M{
+			{"timestamp": bson.M{"$gt": after.Time}},
+			{"timestamp": after.Time, "_id": bson.M{"$gt": after.ID}},
+		},
+	}
+	if got := q.filter(start, end); !reflect.DeepEqual(got, want) {
+		t.Errorf("AlertQuery.filter got:%#v want:%#v", got, want)
+	}
+}
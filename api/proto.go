@@ -0,0 +1,204 @@
+package api
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jprobinson/newshound"
+	pb "github.com/jprobinson/newshound/api/newshoundpb"
+)
+
+// pbTime converts a time to a protobuf Timestamp. Zero times are left unset.
+func pbTime(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+func pbEntities(entities []newshound.Entity) []*pb.Entity {
+	var out []*pb.Entity
+	for _, e := range entities {
+		out = append(out, &pb.Entity{Name: e.Name, Type: string(e.Type)})
+	}
+	return out
+}
+
+func pbAlertLite(a newshound.NewsAlertLite) *pb.Alert {
+	alert := &pb.Alert{
+		Id:          a.ID.Hex(),
+		InstanceId:  a.InstanceID,
+		ArticleUrl:  a.ArticleUrl,
+		Sender:      a.Sender,
+		Timestamp:   pbTime(a.Timestamp),
+		Tags:        a.Tags,
+		Entities:    pbEntities(a.Entities),
+		Subject:     a.Subject,
+		TopSentence: a.TopSentence,
+	}
+	if a.RevisionOf != "" {
+		alert.RevisionOf = a.RevisionOf.Hex()
+	}
+	return alert
+}
+
+func pbAlerts(alerts []newshound.NewsAlertLite) []*pb.Alert {
+	var out []*pb.Alert
+	for _, a := range alerts {
+		out = append(out, pbAlertLite(a))
+	}
+	return out
+}
+
+func pbAlert(a newshound.NewsAlert) *pb.Alert {
+	alert := pbAlertLite(a.NewsAlertLite)
+	alert.Body = a.Body
+	for _, s := range a.Sentences {
+		alert.Sentences = append(alert.Sentences, &pb.Sentence{Sentence: s.Value, NounPhrases: s.Phrases})
+	}
+	return alert
+}
+
+func pbEvent(e newshound.NewsEvent) *pb.Event {
+	event := &pb.Event{
+		Id:          e.ID.Hex(),
+		Tags:        e.Tags,
+		Entities:    pbEntities(e.Entities),
+		EventStart:  pbTime(e.EventStart),
+		EventEnd:    pbTime(e.EventEnd),
+		TopSentence: e.TopSentence,
+		TopSender:   e.TopSender,
+		Headline:    e.Headline,
+		Summary:     e.Summary,
+		Importance:  e.Importance,
+		Major:       e.Major,
+	}
+	for _, a := range e.NewsAlerts {
+		event.NewsAlerts = append(event.NewsAlerts, &pb.EventAlert{
+			AlertId:     a.AlertID.Hex(),
+			InstanceId:  a.InstanceID,
+			ArticleUrl:  a.ArticleUrl,
+			Sender:      a.Sender,
+			Tags:        a.Tags,
+			Subject:     a.Subject,
+			TopSentence: a.TopSentence,
+			Order:       a.Order,
+			TimeLapsed:  a.TimeLapsed,
+		})
+	}
+	return event
+}
+
+func pbEvents(events []newshound.NewsEvent) []*pb.Event {
+	var out []*pb.Event
+	for _, e := range events {
+		out = append(out, pbEvent(e))
+	}
+	return out
+}
+
+func pbStoryline(s newshound.Storyline) *pb.Storyline {
+	storyline := &pb.Storyline{
+		Id:         s.ID.Hex(),
+		Tags:       s.Tags,
+		Entities:   pbEntities(s.Entities),
+		Start:      pbTime(s.Start),
+		End:        pbTime(s.End),
+		Headline:   s.Headline,
+		Importance: s.Importance,
+	}
+	for _, id := range s.EventIDs {
+		storyline.EventIds = append(storyline.EventIds, id.Hex())
+	}
+	return storyline
+}
+
+func pbTrending(tags []newshound.TrendingTag) *pb.TrendingList {
+	list := &pb.TrendingList{}
+	for _, t := range tags {
+		list.Tags = append(list.Tags, &pb.TrendingTag{
+			Tag:      t.Tag,
+			Type:     string(t.Type),
+			Alerts:   int64(t.Alerts),
+			Senders:  t.Senders,
+			Expected: t.Expected,
+			Score:    t.Score,
+			Since:    pbTime(t.Since),
+			Updated:  pbTime(t.Updated),
+		})
+	}
+	return list
+}
+
+func pbAlertsPerWeek(reports []AvgAlertsReport) *pb.AlertsPerWeekReport {
+	out := &pb.AlertsPerWeekReport{}
+	for _, r := range reports {
+		sender := &pb.AlertsPerWeekReport_Sender{Sender: r.Sender, Values: map[string]*pb.AvgAlerts{}}
+		for timeframe, v := range r.Values {
+			sender.Values[timeframe] = &pb.AvgAlerts{AvgAlerts: v.AvgAlerts, TotalAlerts: int64(v.TotalAlerts)}
+		}
+		out.Senders = append(out.Senders, sender)
+	}
+	return out
+}
+
+func pbEventsPerWeek(reports []AvgEventsReport) *pb.EventsPerWeekReport {
+	out := &pb.EventsPerWeekReport{}
+	for _, r := range reports {
+		sender := &pb.EventsPerWeekReport_Sender{Sender: r.Sender, Values: map[string]*pb.AvgEvents{}}
+		for timeframe, v := range r.Values {
+			sender.Values[timeframe] = &pb.AvgEvents{
+				AvgEvents:       v.AvgEvents,
+				TotalEvents:     v.TotalEvents,
+				TotalRank:       v.TotalRank,
+				AvgRank:         v.AvgRank,
+				TotalTimeLapsed: v.TotalTimeLapsed,
+				AvgTimeLapsed:   v.AvgTimeLapsed,
+			}
+		}
+		out.Senders = append(out.Senders, sender)
+	}
+	return out
+}
+
+func pbEventAttendance(reports []EventAttendReport) *pb.EventAttendanceReport {
+	out := &pb.EventAttendanceReport{}
+	for _, r := range reports {
+		sender := &pb.EventAttendanceReport_Sender{Sender: r.Sender, Values: map[string]*pb.EventAttendance{}}
+		for timeframe, v := range r.Values {
+			sender.Values[timeframe] = &pb.EventAttendance{Attendance: v.Attendance, TotalEvents: int64(v.Events)}
+		}
+		out.Senders = append(out.Senders, sender)
+	}
+	return out
+}
+
+func pbSenderInfo(info SenderInfo) *pb.SenderInfo {
+	out := &pb.SenderInfo{AlertsPerHour: info.AlertsPerHour}
+	for _, w := range info.AlertsPerWeek {
+		out.AlertsPerWeek = append(out.AlertsPerWeek, &pb.SenderInfo_AlertWeek{
+			WeekStart: pbTime(w.Id.WeekStart),
+			Alerts:    int64(w.Value.Alerts),
+			TagMap:    w.Value.TagMap,
+		})
+	}
+	for _, w := range info.EventsPerWeek {
+		out.EventsPerWeek = append(out.EventsPerWeek, &pb.SenderInfo_EventWeek{
+			WeekStart:       pbTime(w.Id.WeekStart),
+			TotalEvents:     w.Value.TotalEvents,
+			TotalRank:       w.Value.TotalRank,
+			AvgRank:         w.Value.AvgRank,
+			TotalTimeLapsed: w.Value.TotalTimeLapsed,
+			AvgTimeLapsed:   w.Value.AvgTimeLapsed,
+		})
+	}
+	for _, t := range info.TagArray {
+		out.Tags = append(out.Tags, &pb.SenderInfo_Tag{Tag: t.Tag, Frequency: t.Frequency})
+	}
+	return out
+}
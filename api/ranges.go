@@ -0,0 +1,407 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// CustomTimeframe is the timeframe key used for reports over a requested date range.
+const CustomTimeframe = "custom"
+
+// maxReportYears is the longest custom date range, in years, reports are
+// computed for on demand.
+const maxReportYears = 1
+
+// checkReportRange rejects custom date ranges longer than maxReportYears.
+// The end is exclusive.
+func checkReportRange(start, end time.Time) error {
+	if end.After(start.AddDate(maxReportYears, 0, 0)) {
+		return &InvalidDateError{fmt.Sprintf("date ranges can be at most %d year long", maxReportYears)}
+	}
+	return nil
+}
+
+// ReportQuery limits a report to a date range and/or a set of senders.
+type ReportQuery struct {
+	// Start and End are only set for custom date ranges. End is exclusive.
+	Start time.Time
+	End   time.Time
+
+	Senders []string
+}
+
+// Custom reports whether the report needs to be computed for a date range
+// rather than read from the stored reports.
+func (q ReportQuery) Custom() bool {
+	return !q.Start.IsZero()
+}
+
+func (q ReportQuery) includes(sender string) bool {
+	if len(q.Senders) == 0 {
+		return true
+	}
+	for _, s := range q.Senders {
+		if strings.EqualFold(s, sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies the query's results for the given report in the cache.
+func (q ReportQuery) key(report string) string {
+	senders := append([]string(nil), q.Senders...)
+	sort.Strings(senders)
+	return fmt.Sprintf("%s|%d|%d|%s|%s", report, q.Start.Unix(), q.End.Unix(),
+		q.Start.Location(), strings.Join(senders, ","))
+}
+
+// FindAlertsPerWeek returns the average alerts per week report. For custom date ranges
+// the report is computed on demand with a single CustomTimeframe value per sender.
+func FindAlertsPerWeek(ctx context.Context, db *mgo.Database, q ReportQuery) ([]AvgAlertsReport, error) {
+	if !q.Custom() {
+		all, err := GetAlertsPerWeek(db)
+		var results []AvgAlertsReport
+		for _, r := range all {
+			if q.includes(r.Sender) {
+				results = append(results, r)
+			}
+		}
+		return results, err
+	}
+
+	ctx, span := trace.StartSpan(ctx, "newshound/report/alerts-per-week-range")
+	defer span.End()
+
+	q, err := resolveSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := findRangeAlerts(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	senders, err := reportSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AvgAlertsReport
+	for _, avg := range report.RangeAvgAlerts(alerts, senders, CustomTimeframe, q.Start, q.End) {
+		results = append(results, AvgAlertsReport{
+			Sender: avg.ID.Sender,
+			Values: map[string]AvgAlertsValue{CustomTimeframe: {
+				AvgAlerts:   avg.Value.AvgAlerts,
+				TotalAlerts: avg.Value.TotalAlerts,
+			}},
+		})
+	}
+	return results, nil
+}
+
+// FindEventsPerWeek returns the average events per week report. For custom date ranges
+// the report is computed on demand with a single CustomTimeframe value per sender.
+func FindEventsPerWeek(ctx context.Context, db *mgo.Database, q ReportQuery) ([]AvgEventsReport, error) {
+	if !q.Custom() {
+		all, err := GetEventsPerWeek(db)
+		var results []AvgEventsReport
+		for _, r := range all {
+			if q.includes(r.Sender) {
+				results = append(results, r)
+			}
+		}
+		return results, err
+	}
+
+	ctx, span := trace.StartSpan(ctx, "newshound/report/events-per-week-range")
+	defer span.End()
+
+	q, err := resolveSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := findRangeEvents(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	senders, err := reportSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AvgEventsReport
+	for _, avg := range report.RangeAvgEvents(events, senders, CustomTimeframe, q.Start, q.End) {
+		if !q.includes(avg.ID.Sender) {
+			continue
+		}
+		results = append(results, AvgEventsReport{
+			Sender: avg.ID.Sender,
+			Values: map[string]AvgEventsValue{CustomTimeframe: {
+				AvgEvents:       avg.Value.AvgEvents,
+				TotalEvents:     avg.Value.TotalEvents,
+				TotalRank:       avg.Value.TotalRank,
+				AvgRank:         avg.Value.AvgRank,
+				TotalTimeLapsed: avg.Value.TotalTimeLapsed,
+				AvgTimeLapsed:   avg.Value.AvgTimeLapsed,
+			}},
+		})
+	}
+	return results, nil
+}
+
+// FindEventAttendance returns the event attendance report. For custom date ranges
+// the report is computed on demand with a single CustomTimeframe value per sender.
+func FindEventAttendance(ctx context.Context, db *mgo.Database, q ReportQuery) ([]EventAttendReport, error) {
+	if !q.Custom() {
+		all, err := GetEventAttendance(db)
+		var results []EventAttendReport
+		for _, r := range all {
+			if q.includes(r.Sender) {
+				results = append(results, r)
+			}
+		}
+		return results, err
+	}
+
+	ctx, span := trace.StartSpan(ctx, "newshound/report/event-attendance-range")
+	defer span.End()
+
+	q, err := resolveSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := findRangeEvents(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+	senders, err := reportSenders(ctx, db, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []EventAttendReport
+	for _, attend := range report.RangeAttendance(events, senders, CustomTimeframe, q.Start, q.End) {
+		if !q.includes(attend.ID.Sender) {
+			continue
+		}
+		results = append(results, EventAttendReport{
+			Sender: attend.ID.Sender,
+			Values: map[string]EventAttendValue{CustomTimeframe: {
+				Attendance: attend.Value.Attendance,
+				Events:     int(attend.Value.TotalEvents),
+			}},
+		})
+	}
+	return results, nil
+}
+
+// FindSenderInfoRange computes the Sender Info report for the given sender over a custom date range.
+// A NotFoundError is returned if the sender has never sent an alert.
+func FindSenderInfoRange(ctx context.Context, db *mgo.Database, sender string, q ReportQuery) (SenderInfo, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/report/sender-info-range")
+	defer span.End()
+
+	var info SenderInfo
+	sender, err := findSender(ctx, db, sender)
+	if err != nil {
+		return info, err
+	}
+	q.Senders = []string{sender}
+	alerts, err := findRangeAlerts(ctx, db, q)
+	if err != nil {
+		return info, err
+	}
+	events, err := findRangeEvents(ctx, db, q)
+	if err != nil {
+		return info, err
+	}
+
+	for _, week := range report.AlertsPerWeekBySender(alerts, q.Start.Location()) {
+		var wi AlertWeekInfo
+		wi.Id = WeekInfoID{Sender: week.ID.Sender, WeekStart: week.ID.WeekStart}
+		wi.Value.Alerts = week.Value.Alerts
+		wi.Value.TagMap = make(map[string]int64, len(week.Value.TagMap))
+		for tag, count := range week.Value.TagMap {
+			wi.Value.TagMap[tag] = int64(count)
+		}
+		info.AlertsPerWeek = append(info.AlertsPerWeek, wi)
+	}
+
+	for _, week := range report.EventsPerWeekBySender(events, q.Start.Location()) {
+		if week.ID.Sender != sender {
+			continue
+		}
+		var wi EventWeekInfo
+		wi.Id = WeekInfoID{Sender: week.ID.Sender, WeekStart: week.ID.WeekStart}
+		wi.Value.TotalEvents = week.Value.TotalEvents
+		wi.Value.TotalRank = week.Value.TotalRank
+		wi.Value.AvgRank = week.Value.AvgRank
+		wi.Value.TotalTimeLapsed = week.Value.TotalTimeLapsed
+		wi.Value.AvgTimeLapsed = week.Value.AvgTimeLapsed
+		info.EventsPerWeek = append(info.EventsPerWeek, wi)
+	}
+
+	info.TagArray = []TagInfo{}
+	for _, avg := range report.RangeAvgAlerts(alerts, q.Senders, CustomTimeframe, q.Start, q.End) {
+		for _, tag := range avg.Value.TagArray {
+			info.TagArray = append(info.TagArray, TagInfo{Tag: tag.Tag, Frequency: int64(tag.Frequency)})
+		}
+	}
+
+	var hours map[string]int64
+	for _, perHour := range report.SenderAlertsPerHour(alerts, q.Start.Location()) {
+		hours = perHour.Value.Hours
+	}
+	for hour := 0; hour < 24; hour++ {
+		info.AlertsPerHour = append(info.AlertsPerHour, hours[strconv.Itoa(hour)])
+	}
+
+	return info, nil
+}
+
+// FindComparison builds a head-to-head report for the query's senders over its date range.
+func FindComparison(ctx context.Context, db *mgo.Database, q ReportQuery, tieThreshold time.Duration) (report.Comparison, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/report/compare")
+	defer span.End()
+
+	q, err := resolveSenders(ctx, db, q)
+	if err != nil {
+		return report.Comparison{}, err
+	}
+	alerts, err := findRangeAlerts(ctx, db, q)
+	if err != nil {
+		return report.Comparison{}, err
+	}
+	events, err := findRangeEvents(ctx, db, q)
+	if err != nil {
+		return report.Comparison{}, err
+	}
+	return report.Compare(q.Senders, alerts, events, q.Start, q.End, tieThreshold), nil
+}
+
+// FindSenderHeatmap computes the day of week by hour heatmap of the sender's alerts
+// within the query's date range, bucketed in the time zone of the query's start. A NotFoundError
+// is returned if the sender has never sent an alert.
+func FindSenderHeatmap(ctx context.Context, db *mgo.Database, sender string, q ReportQuery) (report.Heatmap, error) {
+	sender, err := findSender(ctx, db, sender)
+	if err != nil {
+		return report.Heatmap{}, err
+	}
+	q.Senders = []string{sender}
+	alerts, err := findRangeAlerts(ctx, db, q)
+	if err != nil {
+		return report.Heatmap{}, err
+	}
+	return report.SenderHeatmap(sender, alerts, q.Start.Location()), nil
+}
+
+// findSender returns the sender's name as it's stored, ignoring case, or a NotFoundError
+// if the sender has never sent an alert.
+func findSender(ctx context.Context, db *mgo.Database, sender string) (string, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-sender")
+	defer span.End()
+
+	var alert newshound.NewsAlertLite
+	err := getNA(db).Find(bson.M{"sender": senderPattern(sender)}).Select(bson.M{"sender": 1}).One(&alert)
+	if err != nil {
+		return sender, notFound(err, "sender", sender)
+	}
+	return alert.Sender, nil
+}
+
+// resolveSenders swaps the query's senders for their names as they're stored so
+// they match regardless of case. Senders that have never sent an alert are kept
+// as they were given.
+func resolveSenders(ctx context.Context, db *mgo.Database, q ReportQuery) (ReportQuery, error) {
+	if len(q.Senders) == 0 {
+		return q, nil
+	}
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/resolve-senders")
+	defer span.End()
+
+	patterns := make([]bson.RegEx, len(q.Senders))
+	for i, sender := range q.Senders {
+		patterns[i] = senderPattern(sender)
+	}
+	var stored []string
+	if err := getNA(db).Find(bson.M{"sender": bson.M{"$in": patterns}}).Distinct("sender", &stored); err != nil {
+		return q, err
+	}
+	q.Senders = matchSenders(q.Senders, stored)
+	return q, nil
+}
+
+// matchSenders replaces each sender with the stored name it matches ignoring case.
+func matchSenders(senders, stored []string) []string {
+	matched := make([]string, len(senders))
+	for i, sender := range senders {
+		matched[i] = sender
+		for _, name := range stored {
+			if strings.EqualFold(sender, name) {
+				matched[i] = name
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// senderPattern matches the whole sender name ignoring case.
+func senderPattern(sender string) bson.RegEx {
+	return bson.RegEx{Pattern: "^" + regexp.QuoteMeta(sender) + "$", Options: "i"}
+}
+
+// findRangeAlerts returns the original alerts sent within the query's date range by its senders.
+func findRangeAlerts(ctx context.Context, db *mgo.Database, q ReportQuery) ([]newshound.NewsAlertLite, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-range-alerts")
+	defer span.End()
+
+	query := bson.M{
+		"timestamp":   bson.M{"$gte": q.Start, "$lt": q.End},
+		"revision_of": bson.M{"$exists": false},
+	}
+	if len(q.Senders) > 0 {
+		query["sender"] = bson.M{"$in": q.Senders}
+	}
+	var alerts []newshound.NewsAlertLite
+	err := getNA(db).Find(query).Select(bson.M{"sender": 1, "timestamp": 1, "tags": 1}).All(&alerts)
+	return alerts, err
+}
+
+// findRangeEvents returns every event that started within the query's date range.
+// Events are not filtered by sender so attendance can be calculated.
+func findRangeEvents(ctx context.Context, db *mgo.Database, q ReportQuery) ([]newshound.NewsEvent, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-range-events")
+	defer span.End()
+
+	var events []newshound.NewsEvent
+	err := getNE(db).Find(bson.M{"event_start": bson.M{"$gte": q.Start, "$lt": q.End}}).
+		Select(bson.M{"event_start": 1, "headline": 1, "news_alerts.sender": 1, "news_alerts.order": 1,
+			"news_alerts.time_lapsed": 1, "news_alerts.tags": 1}).All(&events)
+	return events, err
+}
+
+// reportSenders returns the senders that should appear in a report, even if they have no activity.
+func reportSenders(ctx context.Context, db *mgo.Database, q ReportQuery) ([]string, error) {
+	if len(q.Senders) > 0 {
+		return q.Senders, nil
+	}
+	var senders []string
+	err := getNA(db).Find(nil).Distinct("sender", &senders)
+	return senders, err
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseReportQuery(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		query   string
+		want    ReportQuery
+		wantErr bool
+	}{
+		{"", ReportQuery{}, false},
+		{"?sender=cnn&sender=nbc,%20ft", ReportQuery{Senders: []string{"cnn", "nbc", "ft"}}, false},
+		{
+			"?start=2018-01-01&end=2018-03-31",
+			ReportQuery{
+				Start: time.Date(2018, 1, 1, 0, 0, 0, 0, time.Local),
+				End:   time.Date(2018, 4, 1, 0, 0, 0, 0, time.Local),
+			},
+			false,
+		},
+		{"?start=2018-01-01", ReportQuery{}, true},
+		{"?start=2018-03-31&end=2018-01-01", ReportQuery{}, true},
+		{"?start=yesterday&end=today", ReportQuery{}, true},
+		{
+			"?start=2018-03-10&end=2018-03-11&tz=America/New_York",
+			ReportQuery{
+				Start: time.Date(2018, 3, 10, 0, 0, 0, 0, newYork),
+				End:   time.Date(2018, 3, 12, 0, 0, 0, 0, newYork),
+			},
+			false,
+		},
+		{"?tz=Mars/Olympus_Mons", ReportQuery{}, true},
+	}
+
+	s := &service{loc: time.Local}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/report/alerts_per_week"+test.query, nil)
+		got, err := s.parseReportQuery(r)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseReportQuery(%q) expected an error", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseReportQuery(%q) returned an error: %s", test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseReportQuery(%q) got:%#v want:%#v", test.query, got, test.want)
+		}
+	}
+}
+
+func TestReportCache(t *testing.T) {
+	c := newLRUCache(time.Minute)
+	a := ReportQuery{Start: time.Unix(0, 0), End: time.Unix(100, 0), Senders: []string{"nbc", "cnn"}}
+	b := ReportQuery{Start: time.Unix(0, 0), End: time.Unix(100, 0), Senders: []string{"cnn", "nbc"}}
+
+	c.set(a.key("alerts_per_week"), "cached")
+	if got, ok := c.get(b.key("alerts_per_week")); !ok || got != "cached" {
+		t.Errorf("get() with reordered senders got:%v, %v want:cached, true", got, ok)
+	}
+	if _, ok := c.get(b.key("events_per_week")); ok {
+		t.Error("get() for a different report should miss")
+	}
+
+	expired := newLRUCache(-time.Minute)
+	expired.set("key", "value")
+	if _, ok := expired.get("key"); ok {
+		t.Error("get() should not return anything from a cache with no ttl")
+	}
+
+	defer func(max int) { maxCacheEntries = max }(maxCacheEntries)
+	maxCacheEntries = 2
+	lru := newLRUCache(time.Minute)
+	lru.set("a", 1)
+	lru.set("b", 2)
+	lru.get("a")
+	lru.set("c", 3)
+	if _, ok := lru.get("b"); ok {
+		t.Error("get() should miss the least recently used entry once full")
+	}
+	if _, ok := lru.get("a"); !ok {
+		t.Error("get() should hit the recently used entry")
+	}
+}
+
+func TestCheckReportRange(t *testing.T) {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		end     time.Time
+		wantErr bool
+	}{
+		{time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, test := range tests {
+		if err := checkReportRange(start, test.end); (err != nil) != test.wantErr {
+			t.Errorf("checkReportRange(%s) got error %v, want error: %t", test.end, err, test.wantErr)
+		}
+	}
+}
+
+func TestMatchSenders(t *testing.T) {
+	got := matchSenders([]string{"cnn", "FOXNEWS", "nobody"}, []string{"CNN", "FoxNews"})
+	if want := []string{"CNN", "FoxNews", "nobody"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matchSenders() got:%#v want:%#v", got, want)
+	}
+}
@@ -205,6 +205,7 @@ func GetEventAttendance(db *mgo.Database) ([]EventAttendReport, error) {
 }
 
 // FindSenderInfo returns the full Sender Info report for the given sender over the past 3 months.
+// A NotFoundError is returned if there is no report for the sender.
 func FindSenderInfo(db *mgo.Database, sender string) (senderInfo SenderInfo, err error) {
 	tfquery := bson.M{"_id.sender": sender, "_id.timeframe": "12months"}
 	query := bson.M{"_id.sender": sender}
@@ -214,7 +215,7 @@ func FindSenderInfo(db *mgo.Database, sender string) (senderInfo SenderInfo, err
 	avgAlertsPerWeek := db.C("avg_alerts_per_week_by_sender")
 	err = avgAlertsPerWeek.Find(tfquery).One(&tagResult)
 	if err != nil {
-		return
+		return senderInfo, notFound(err, "sender", sender)
 	}
 	senderInfo.TagArray = tagResult.Value.TagArray
 
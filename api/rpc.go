@@ -0,0 +1,510 @@
+package api
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. newshoundpb/newshound.proto
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/NYTimes/gizmo/pubsub/gcp"
+	"github.com/NYTimes/gizmo/server/kit"
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	pb "github.com/jprobinson/newshound/api/newshoundpb"
+	"github.com/jprobinson/newshound/stream"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rpcService serves the Newshound gRPC service with gizmo's kit server. It
+// runs the same queries as the JSON API.
+type rpcService struct {
+	*service
+
+	// events feeds StreamEvents. It is nil if no events
+	// subscription is configured.
+	events *stream.Hub
+}
+
+var (
+	_ kit.Service        = &rpcService{}
+	_ pb.NewshoundServer = &rpcService{}
+)
+
+// NewRPCService returns the gRPC service. If an EVENTS_SUBSCRIPTION is
+// configured, new and updated events are streamed from it.
+func NewRPCService() (kit.Service, error) {
+	cfg := NewConfig()
+	svc, err := newService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &rpcService{service: svc}
+	if cfg.EventsSubscription == "" {
+		return s, nil
+	}
+
+	sub, err := gcp.NewSubscriber(context.Background(), os.Getenv("GOOGLE_CLOUD_PROJECT"), cfg.EventsSubscription)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to init events subscriber")
+	}
+	s.events = stream.NewHub()
+	go func() {
+		if err := s.events.Consume(sub); err != nil {
+			log.Printf("events subscription stopped - %s", err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *rpcService) Middleware(e endpoint.Endpoint) endpoint.Endpoint {
+	return e
+}
+
+func (s *rpcService) HTTPMiddleware(h http.Handler) http.Handler {
+	return h
+}
+
+func (s *rpcService) HTTPOptions() []httptransport.ServerOption {
+	return nil
+}
+
+func (s *rpcService) HTTPRouterOptions() []kit.RouterOption {
+	return nil
+}
+
+// HTTPEndpoints is empty as the JSON API is served by the MixedService.
+func (s *rpcService) HTTPEndpoints() map[string]map[string]kit.HTTPEndpoint {
+	return nil
+}
+
+// RPCMiddleware authorizes each RPC the same way as the JSON API.
+func (s *rpcService) RPCMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := s.authorizeRPC(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (s *rpcService) RPCServiceDesc() *grpc.ServiceDesc {
+	return pb.ServiceDesc
+}
+
+// RPCOptions authorizes streams, which gizmo has no middleware for.
+func (s *rpcService) RPCOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.StreamInterceptor(
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if _, err := s.authorizeRPC(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		})}
+}
+
+// rpcRoutes are the JSON API routes each RPC mirrors, so they need the same
+// scopes.
+var rpcRoutes = map[string]string{
+	"FindAlerts":         "/find_alerts/{start}/{end}",
+	"GetAlert":           "/alert/{alert_id}",
+	"GetAlertRevisions":  "/alert/{alert_id}/revisions",
+	"FindEvents":         "/find_events/{start}/{end}",
+	"GetEvent":           "/event/{event_id}",
+	"StreamEvents":       "/stream",
+	"FindStorylines":     "/find_storylines/{start}/{end}",
+	"GetStoryline":       "/storyline/{storyline_id}",
+	"GetTrending":        "/trending",
+	"GetAlertsPerWeek":   "/report/alerts_per_week",
+	"GetEventsPerWeek":   "/report/events_per_week",
+	"GetEventAttendance": "/report/event_attendance",
+	"GetSenderInfo":      "/report/sender_info/{sender}",
+}
+
+// rpcScope returns the scope needed to make an RPC. RPCs without a route
+// need admin so nothing new is left open by accident.
+func rpcScope(fullMethod string) string {
+	route, ok := rpcRoutes[path.Base(fullMethod)]
+	if !ok {
+		return ScopeAdmin
+	}
+	return routeScope(route, nil)
+}
+
+// authorizeRPC identifies the client making an RPC by the API key in its
+// 'x-api-key' or 'x-admin-key' metadata, checks its scope and counts the call
+// against its limits like authorize does for HTTP requests.
+func (s *rpcService) authorizeRPC(ctx context.Context, fullMethod string) (context.Context, error) {
+	c, err := s.findClient(ctx, rpcCredentials(ctx))
+	if err != nil {
+		return nil, rpcStatus(failed(err, "find api key"))
+	}
+	if scope := rpcScope(fullMethod); !c.can(scope) {
+		e := scopeError(scope)
+		return nil, rpcStatus(e.Status, e, nil)
+	}
+	if d, ok := s.checkLimits(ctx, c); ok && !d.Allowed {
+		e := limitError(d)
+		return nil, rpcStatus(e.Status, e, nil)
+	}
+	return context.WithValue(ctx, clientKey{}, c), nil
+}
+
+func rpcCredentials(ctx context.Context) credentials {
+	var creds credentials
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get("x-admin-key"); len(keys) > 0 {
+		creds.adminKey = keys[0]
+	}
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		creds.apiKey = keys[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		creds.ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(creds.ip); err == nil {
+			creds.ip = host
+		}
+	}
+	return creds
+}
+
+// rpcStatus converts the JSON endpoint response for an error into a gRPC status
+// error, so failed and badRequest can be used the same way as in the handlers.
+func rpcStatus(httpStatus int, body interface{}, _ error) error {
+	e, ok := body.(*Error)
+	if !ok {
+		e = &Error{Status: httpStatus, Message: http.StatusText(httpStatus)}
+	}
+	code := codes.Internal
+	switch e.Status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusNotFound:
+		code = codes.NotFound
+	}
+	return status.Error(code, e.Message)
+}
+
+// timeRange converts the required start and end of a request.
+func timeRange(start, end *timestamp.Timestamp) (time.Time, time.Time, error) {
+	if start == nil || end == nil {
+		return time.Time{}, time.Time{}, &InvalidDateError{"a start and end time are required"}
+	}
+	return optionalTimeRange(start, end)
+}
+
+// optionalTimeRange converts the start and end of a request, which must be given
+// together if at all.
+func optionalTimeRange(start, end *timestamp.Timestamp) (s time.Time, e time.Time, err error) {
+	if start == nil && end == nil {
+		return s, e, nil
+	}
+	if start == nil || end == nil {
+		return s, e, &InvalidDateError{"the start and end times must be given together"}
+	}
+	if s, err = ptypes.Timestamp(start); err != nil {
+		return s, e, &InvalidDateError{"please use a valid start time"}
+	}
+	if e, err = ptypes.Timestamp(end); err != nil {
+		return s, e, &InvalidDateError{"please use a valid end time"}
+	}
+	if e.Before(s) {
+		return s, e, &InvalidDateError{"the end time must not be before the start time"}
+	}
+	return s, e, nil
+}
+
+func reportQuery(req *pb.ReportRequest) (ReportQuery, error) {
+	start, end, err := optionalTimeRange(req.Start, req.End)
+	return ReportQuery{Start: start, End: end, Senders: req.Senders}, err
+}
+
+func (s *rpcService) FindAlerts(ctx context.Context, req *pb.FindAlertsRequest) (*pb.AlertPage, error) {
+	start, end, err := timeRange(req.Start, req.End)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+	q := AlertQuery{Senders: req.Senders, Tags: req.Tags}
+	if req.HasArticleUrl != nil {
+		q.HasArticleURL = &req.HasArticleUrl.Value
+	}
+	if q.PageQuery, err = pageQuery(int(req.Limit), req.Cursor); err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	alerts, next, err := FindAlertsByDate(ctx, db, start, end, q)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access alerts by date"))
+	}
+	return &pb.AlertPage{Alerts: pbAlerts(alerts), NextCursor: next}, nil
+}
+
+func (s *rpcService) GetAlert(ctx context.Context, req *pb.GetAlertRequest) (*pb.Alert, error) {
+	alertID, err := parseID("alert", req.Id)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	alert, err := FindAlertByID(ctx, db, alertID)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access alert"))
+	}
+	return pbAlert(alert), nil
+}
+
+func (s *rpcService) GetAlertRevisions(ctx context.Context, req *pb.GetAlertRequest) (*pb.AlertList, error) {
+	alertID, err := parseID("alert", req.Id)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	alerts, err := FindAlertRevisions(ctx, db, alertID)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access alert revisions"))
+	}
+	return &pb.AlertList{Alerts: pbAlerts(alerts)}, nil
+}
+
+func (s *rpcService) FindEvents(ctx context.Context, req *pb.FindEventsRequest) (*pb.EventPage, error) {
+	start, end, err := timeRange(req.Start, req.End)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+	if req.MinAlerts < 0 {
+		return nil, rpcStatus(badRequest(fmt.Errorf("invalid min_alerts: %d", req.MinAlerts)))
+	}
+	q := EventQuery{
+		MinImportance: req.MinImportance,
+		ByImportance:  req.Sort == pb.FindEventsRequest_IMPORTANCE,
+		Senders:       req.Senders,
+		Tags:          req.Tags,
+		MinAlerts:     int(req.MinAlerts),
+	}
+	if req.HasArticleUrl != nil {
+		q.HasArticleURL = &req.HasArticleUrl.Value
+	}
+	if q.PageQuery, err = pageQuery(int(req.Limit), req.Cursor); err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+	if !q.validCursor() {
+		return nil, rpcStatus(badRequest(errBadCursor))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	find := FindEventsByDate
+	if req.NewestFirst {
+		find = FindEventsByDateReverse
+	}
+	events, next, err := find(ctx, db, start, end, q)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access events by date"))
+	}
+	return &pb.EventPage{Events: pbEvents(events), NextCursor: next}, nil
+}
+
+func (s *rpcService) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.Event, error) {
+	eventID, err := parseID("event", req.Id)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	event, err := FindEventByID(ctx, db, eventID)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access event by event_id"))
+	}
+	return pbEvent(event), nil
+}
+
+// StreamEvents sends new and updated events until the client goes away. Clients
+// that fall too far behind are disconnected and can resume with the last ID they
+// received.
+func (s *rpcService) StreamEvents(req *pb.StreamEventsRequest, srv pb.Newshound_StreamEventsServer) error {
+	if s.events == nil {
+		return status.Error(codes.Unavailable, "event streaming is not configured")
+	}
+
+	sub := s.events.Subscribe(stream.Filter{
+		Senders:    req.Senders,
+		Tags:       req.Tags,
+		EventsOnly: true,
+	}, req.LastId)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case m, ok := <-sub.Messages():
+			if !ok {
+				return status.Error(codes.Aborted, "the client fell too far behind the stream")
+			}
+			update := &pb.EventUpdate{Id: m.ID, Event: pbEvent(*m.Event)}
+			if m.Kind == stream.KindEventUpdate {
+				update.Kind = pb.EventUpdate_UPDATED
+			}
+			if err := srv.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *rpcService) FindStorylines(ctx context.Context, req *pb.FindStorylinesRequest) (*pb.StorylineList, error) {
+	start, end, err := timeRange(req.Start, req.End)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	storylines, err := FindStorylinesByDate(ctx, db, start, end)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access storylines by date"))
+	}
+	list := &pb.StorylineList{}
+	for _, storyline := range storylines {
+		list.Storylines = append(list.Storylines, pbStoryline(storyline))
+	}
+	return list, nil
+}
+
+func (s *rpcService) GetStoryline(ctx context.Context, req *pb.GetStorylineRequest) (*pb.StorylineDetail, error) {
+	storylineID, err := parseID("storyline", req.Id)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	detail, err := FindStorylineByID(ctx, db, storylineID)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access storyline"))
+	}
+	return &pb.StorylineDetail{
+		Storyline: pbStoryline(detail.Storyline),
+		Events:    pbEvents(detail.Events),
+		Alerts:    pbAlerts(detail.Alerts),
+	}, nil
+}
+
+func (s *rpcService) GetTrending(ctx context.Context, req *pb.GetTrendingRequest) (*pb.TrendingList, error) {
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	tags, err := FindTrending(ctx, db)
+	if err != nil {
+		return nil, rpcStatus(failed(err, "access trending tags"))
+	}
+	return pbTrending(tags), nil
+}
+
+func (s *rpcService) GetAlertsPerWeek(ctx context.Context, req *pb.ReportRequest) (*pb.AlertsPerWeekReport, error) {
+	q, err := reportQuery(req)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	report, err := s.cachedReport(q, "alerts_per_week", func() (interface{}, error) {
+		return FindAlertsPerWeek(ctx, db, q)
+	})
+	if err != nil {
+		return nil, rpcStatus(failed(err, "retrieve sender alerts per week"))
+	}
+	return pbAlertsPerWeek(report.([]AvgAlertsReport)), nil
+}
+
+func (s *rpcService) GetEventsPerWeek(ctx context.Context, req *pb.ReportRequest) (*pb.EventsPerWeekReport, error) {
+	q, err := reportQuery(req)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	report, err := s.cachedReport(q, "events_per_week", func() (interface{}, error) {
+		return FindEventsPerWeek(ctx, db, q)
+	})
+	if err != nil {
+		return nil, rpcStatus(failed(err, "retrieve sender events per week"))
+	}
+	return pbEventsPerWeek(report.([]AvgEventsReport)), nil
+}
+
+func (s *rpcService) GetEventAttendance(ctx context.Context, req *pb.ReportRequest) (*pb.EventAttendanceReport, error) {
+	q, err := reportQuery(req)
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	report, err := s.cachedReport(q, "event_attendance", func() (interface{}, error) {
+		return FindEventAttendance(ctx, db, q)
+	})
+	if err != nil {
+		return nil, rpcStatus(failed(err, "retrieve sender event attendance"))
+	}
+	return pbEventAttendance(report.([]EventAttendReport)), nil
+}
+
+func (s *rpcService) GetSenderInfo(ctx context.Context, req *pb.SenderInfoRequest) (*pb.SenderInfo, error) {
+	q, err := reportQuery(&pb.ReportRequest{Start: req.Start, End: req.End})
+	if err != nil {
+		return nil, rpcStatus(badRequest(err))
+	}
+	q.Senders = []string{req.Sender}
+
+	sess, db := s.getDB()
+	defer sess.Close()
+
+	info, err := s.cachedReport(q, "sender_info", func() (interface{}, error) {
+		if q.Custom() {
+			return FindSenderInfoRange(ctx, db, req.Sender, q)
+		}
+		return FindSenderInfo(db, req.Sender)
+	})
+	if err != nil {
+		return nil, rpcStatus(failed(err, "retrieve sender info report"))
+	}
+	return pbSenderInfo(info.(SenderInfo)), nil
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jprobinson/newshound"
+	pb "github.com/jprobinson/newshound/api/newshoundpb"
+	"github.com/jprobinson/newshound/stream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// testRPCClient serves the service over an in-memory connection the same
+// way gizmo's kit server registers it.
+func testRPCClient(t *testing.T, s *rpcService) (pb.NewshoundClient, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(append(s.RPCOptions(), grpc.UnaryInterceptor(s.RPCMiddleware()))...)
+	srv.RegisterService(s.RPCServiceDesc(), s)
+	go srv.Serve(lis)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pb.NewNewshoundClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+// readAll is an anonymous client that can read everything.
+var readAll = client{scopes: []string{ScopeReadPublic, ScopeReadBodies}}
+
+// TestRPCErrors sends bad requests that are all rejected before touching the
+// database, which the test service doesn't have.
+func TestRPCErrors(t *testing.T) {
+	client, done := testRPCClient(t, &rpcService{service: &service{loc: time.Local, anonymous: readAll}})
+	defer done()
+
+	start, _ := ptypes.TimestampProto(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC))
+	end, _ := ptypes.TimestampProto(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"alerts without dates", func() error {
+			_, err := client.FindAlerts(ctx, &pb.FindAlertsRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"alerts bad cursor", func() error {
+			_, err := client.FindAlerts(ctx, &pb.FindAlertsRequest{Start: end, End: start, Cursor: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"alert bad id", func() error {
+			_, err := client.GetAlert(ctx, &pb.GetAlertRequest{Id: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"revisions bad id", func() error {
+			_, err := client.GetAlertRevisions(ctx, &pb.GetAlertRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"events backwards", func() error {
+			_, err := client.FindEvents(ctx, &pb.FindEventsRequest{Start: start, End: end})
+			return err
+		}, codes.InvalidArgument},
+		{"events too many", func() error {
+			_, err := client.FindEvents(ctx, &pb.FindEventsRequest{Start: end, End: start, Limit: 1000})
+			return err
+		}, codes.InvalidArgument},
+		{"event bad id", func() error {
+			_, err := client.GetEvent(ctx, &pb.GetEventRequest{Id: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"storylines without end", func() error {
+			_, err := client.FindStorylines(ctx, &pb.FindStorylinesRequest{Start: start})
+			return err
+		}, codes.InvalidArgument},
+		{"storyline bad id", func() error {
+			_, err := client.GetStoryline(ctx, &pb.GetStorylineRequest{Id: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"report without start", func() error {
+			_, err := client.GetAlertsPerWeek(ctx, &pb.ReportRequest{End: end})
+			return err
+		}, codes.InvalidArgument},
+		{"report backwards", func() error {
+			_, err := client.GetEventAttendance(ctx, &pb.ReportRequest{Start: start, End: end})
+			return err
+		}, codes.InvalidArgument},
+		{"sender info backwards", func() error {
+			_, err := client.GetSenderInfo(ctx, &pb.SenderInfoRequest{Sender: "cnn", Start: start, End: end})
+			return err
+		}, codes.InvalidArgument},
+		{"stream unavailable", func() error {
+			s, err := client.StreamEvents(ctx, &pb.StreamEventsRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = s.Recv()
+			return err
+		}, codes.Unavailable},
+	}
+
+	for _, test := range tests {
+		if got := status.Code(test.call()); got != test.code {
+			t.Errorf("%s: got code:%s want:%s", test.name, got, test.code)
+		}
+	}
+}
+
+func TestRPCStreamEvents(t *testing.T) {
+	hub := stream.NewHub()
+	client, done := testRPCClient(t, &rpcService{service: &service{anonymous: readAll}, events: hub})
+	defer done()
+
+	event := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"senate"},
+		EventStart: time.Date(2019, 6, 3, 14, 5, 0, 0, time.UTC),
+		NewsAlerts: []newshound.NewsEventAlert{{AlertID: bson.NewObjectId(), Sender: "cnn.com"}},
+	}
+	hub.PublishAlert(newshound.NewsAlertLite{Sender: "cnn.com", Tags: []string{"senate"}})
+	hub.PublishEvent(event, false)
+	hub.PublishEvent(newshound.NewsEvent{Tags: []string{"obama"}}, false)
+	hub.PublishEvent(event, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := client.StreamEvents(ctx, &pb.StreamEventsRequest{Tags: []string{"senate"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var first uint64
+	for _, want := range []pb.EventUpdate_Kind{pb.EventUpdate_NEW, pb.EventUpdate_UPDATED} {
+		update, err := s.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first == 0 {
+			first = update.Id
+		}
+		if update.Kind != want || update.Event.GetId() != event.ID.Hex() ||
+			update.Event.NewsAlerts[0].Sender != "cnn.com" || update.Event.EventStart.GetSeconds() != event.EventStart.Unix() {
+			t.Errorf("got update:%v want kind:%s", update, want)
+		}
+	}
+
+	// resuming skips what was already received
+	resumed, err := client.StreamEvents(ctx, &pb.StreamEventsRequest{Tags: []string{"senate"}, LastId: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	update, err := resumed.Recv()
+	if err != nil || update.Kind != pb.EventUpdate_UPDATED {
+		t.Errorf("resumed stream got:%v err:%v want the update", update, err)
+	}
+}
+
+func TestRPCAuthorize(t *testing.T) {
+	now := time.Date(2019, 6, 3, 14, 5, 0, 0, time.UTC)
+	svc := &service{
+		anonymous: client{scopes: []string{ScopeReadPublic}, limits: Limits{PerMinute: 1}},
+		keys:      newLRUCache(time.Minute),
+		limiter:   &memoryLimiter{now: func() time.Time { return now }, usage: map[string]*usage{}},
+	}
+	svc.keys.set(HashKey("reader"), &newshound.APIKey{ID: bson.NewObjectId(), Scopes: []string{ScopeReadPublic, ScopeReadBodies}})
+	svc.keys.set(HashKey("revoked"), (*newshound.APIKey)(nil))
+	client, done := testRPCClient(t, &rpcService{service: svc, events: stream.NewHub()})
+	defer done()
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+	getAlert := func(ctx context.Context) error {
+		_, err := client.GetAlert(ctx, &pb.GetAlertRequest{Id: "nope"})
+		return err
+	}
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"anonymous bodies", func() error { return getAlert(context.Background()) }, codes.PermissionDenied},
+		{"anonymous", func() error {
+			_, err := client.GetEvent(context.Background(), &pb.GetEventRequest{Id: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"anonymous rate limited stream", func() error {
+			s, err := client.StreamEvents(context.Background(), &pb.StreamEventsRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = s.Recv()
+			return err
+		}, codes.ResourceExhausted},
+		{"unknown key", func() error { return getAlert(withKey("revoked")) }, codes.Unauthenticated},
+		// the bad ID means the call got past authorization
+		{"key bodies", func() error { return getAlert(withKey("reader")) }, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		if got := status.Code(test.call()); got != test.code {
+			t.Errorf("%s: got code:%s want:%s", test.name, got, test.code)
+		}
+	}
+}
+
+func TestRPCScope(t *testing.T) {
+	for method := range rpcRoutes {
+		want := ScopeReadPublic
+		if method == "GetAlert" {
+			want = ScopeReadBodies
+		}
+		if got := rpcScope("/newshound.Newshound/" + method); got != want {
+			t.Errorf("rpcScope(%s) got:%s want:%s", method, got, want)
+		}
+	}
+	for _, m := range pb.ServiceDesc.Methods {
+		if _, ok := rpcRoutes[m.MethodName]; !ok {
+			t.Errorf("%s has no route", m.MethodName)
+		}
+	}
+	for _, m := range pb.ServiceDesc.Streams {
+		if _, ok := rpcRoutes[m.StreamName]; !ok {
+			t.Errorf("%s has no route", m.StreamName)
+		}
+	}
+	if got := rpcScope("/newshound.Newshound/DeleteEverything"); got != ScopeAdmin {
+		t.Errorf("rpcScope of an unknown method got:%s want:%s", got, ScopeAdmin)
+	}
+}
+
+func TestPageQuery(t *testing.T) {
+	c := cursor{Time: time.Now(), ID: bson.NewObjectId()}.encode()
+	tests := []struct {
+		limit   int
+		cursor  string
+		want    int
+		wantErr bool
+	}{
+		{0, "", 0, false},
+		{10, "", 10, false},
+		{0, c, defaultPageLimit, false},
+		{maxPageLimit + 1, "", 0, true},
+		{-1, "", 0, true},
+		{10, "nope", 0, true},
+	}
+	for _, test := range tests {
+		got, err := pageQuery(test.limit, test.cursor)
+		if (err != nil) != test.wantErr || (err == nil && got.Limit != test.want) {
+			t.Errorf("pageQuery(%d, %q) got:%d err:%v want:%d", test.limit, test.cursor, got.Limit, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"log"
+
+	"github.com/NYTimes/gizmo/server/kit"
+	"github.com/jprobinson/newshound/api"
+)
+
+func main() {
+	svc, err := api.NewRPCService()
+	if err != nil {
+		log.Fatalf("unable to init service: %s", err)
+	}
+
+	err = kit.Run(svc)
+	if err != nil {
+		log.Fatalf("server encountered a fatal error: %s", err)
+	}
+}
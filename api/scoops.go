@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/jprobinson/newshound/report"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SenderScoopsResult is used as a helper to pull the 'sender scoops'
+// leaderboard from the database.
+type SenderScoopsResult struct {
+	ID    TimeframeID       `json:"id" bson:"_id"`
+	Value report.ScoopValue `json:"value" bson:"value"`
+}
+
+// GetScoopLeaderboard returns the first-to-report leaderboard for the given timeframe
+// ordered by the number of events each sender broke.
+func GetScoopLeaderboard(ctx context.Context, db *mgo.Database, timeframe string) ([]report.SenderScoops, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/get-scoop-leaderboard")
+	defer span.End()
+
+	var results []SenderScoopsResult
+	err := db.C("sender_scoops").Find(bson.M{"_id.timeframe": timeframe}).
+		Sort("-value.wins", "-value.win_rate").All(&results)
+	if err != nil {
+		return nil, err
+	}
+
+	board := make([]report.SenderScoops, 0, len(results))
+	for _, result := range results {
+		board = append(board, report.SenderScoops{Sender: result.ID.Sender, ScoopValue: result.Value})
+	}
+	return board, nil
+}
+
+// FindTieThreshold returns the tie threshold fetchd saved with the scoop
+// leaderboards so scoops computed on demand agree with them. It is the
+// report.DefaultTieThreshold until the leaderboards are first saved.
+func FindTieThreshold(ctx context.Context, db *mgo.Database) (time.Duration, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-tie-threshold")
+	defer span.End()
+
+	var result struct {
+		TieThreshold int64 `bson:"tie_threshold"`
+	}
+	err := db.C("sender_scoops").Find(bson.M{"tie_threshold": bson.M{"$exists": true}}).
+		Select(bson.M{"tie_threshold": 1}).One(&result)
+	if err == mgo.ErrNotFound {
+		return report.DefaultTieThreshold, nil
+	}
+	return time.Duration(result.TieThreshold) * time.Second, err
+}
+
+// FindEventScoop determines which sender broke the given event. The boolean
+// result will be false if the event only has a single sender.
+func FindEventScoop(ctx context.Context, db *mgo.Database, eventID bson.ObjectId, tieThreshold time.Duration) (report.EventScoop, bool, error) {
+	event, err := FindEventByID(ctx, db, eventID)
+	if err != nil {
+		return report.EventScoop{}, false, err
+	}
+	scoop, ok := report.ScoopEvent(event, tieThreshold)
+	return scoop, ok, nil
+}
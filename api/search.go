@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound/search"
+)
+
+// searchText is an http.Handler that expects the kind of document to search ('alerts'
+// or 'events') in the URL and the search text in the 'q' query parameter. Optional
+// 'sender', 'start' and 'end' parameters filter the results, 'event' limits alerts to
+// a single event's, 'sort' can be 'relevance' (the default) or 'date' and 'offset'
+// and 'limit' page through the results.
+func (s *service) searchText(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseSearchQuery(r, server.Vars(r)["kind"])
+	if err != nil {
+		return badRequest(err)
+	}
+
+	results, err := s.searcher.Search(r.Context(), q)
+	if err != nil {
+		// the only record a search looks up is the event it's limited to
+		return failed(notFound(err, "event", q.EventID.Hex()), "search "+q.Kind)
+	}
+	if !canReadBodies(r) {
+		results = withoutBodies(results)
+	}
+
+	return http.StatusOK, results, nil
+}
+
+// canReadBodies reports whether the request's client can read alert bodies.
+func canReadBodies(r *http.Request) bool {
+	c := requestClient(r)
+	return c != nil && c.can(ScopeReadBodies)
+}
+
+// withoutBodies drops the alert body highlights from the results for clients
+// that can't read the bodies.
+func withoutBodies(results search.Results) search.Results {
+	hits := make([]search.Hit, len(results.Hits))
+	for i, hit := range results.Hits {
+		if _, ok := hit.Highlights["body"]; ok {
+			hl := map[string][]string{}
+			for field, fragments := range hit.Highlights {
+				if field != "body" {
+					hl[field] = fragments
+				}
+			}
+			if len(hl) == 0 {
+				hl = nil
+			}
+			hit.Highlights = hl
+		}
+		hits[i] = hit
+	}
+	results.Hits = hits
+	return results
+}
+
+// parseSearchQuery pulls a search.Query from the request's query string.
+func (s *service) parseSearchQuery(r *http.Request, kind string) (search.Query, error) {
+	rq, err := s.parseReportQuery(r)
+	if err != nil {
+		return search.Query{}, err
+	}
+	qs := r.URL.Query()
+	q := search.Query{
+		Kind:    kind,
+		Text:    qs.Get("q"),
+		Senders: rq.Senders,
+		Start:   rq.Start,
+		End:     rq.End,
+		Sort:    qs.Get("sort"),
+	}
+	if q.Sort == "" {
+		q.Sort = search.ByRelevance
+	}
+	if event := qs.Get("event"); event != "" {
+		if q.EventID, err = parseID("event", event); err != nil {
+			return q, err
+		}
+	}
+	if offset := qs.Get("offset"); offset != "" {
+		if q.Offset, err = strconv.Atoi(offset); err != nil {
+			return q, err
+		}
+	}
+	if q.Limit, err = parseLimit(r, 20, search.MaxLimit); err != nil {
+		return q, err
+	}
+	return q, q.Validate()
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound/search"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		kind    string
+		query   string
+		want    search.Query
+		wantErr bool
+	}{
+		{
+			"alerts", "?q=fed+rate+hike",
+			search.Query{Kind: search.Alerts, Text: "fed rate hike", Sort: search.ByRelevance, Limit: 20},
+			false,
+		},
+		{
+			"events", "?q=fed&sender=cnn,nbc&start=2018-06-01&end=2018-06-30&sort=date&offset=40&limit=10",
+			search.Query{
+				Kind:    search.Events,
+				Text:    "fed",
+				Senders: []string{"cnn", "nbc"},
+				Start:   time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+				Sort:    search.ByDate,
+				Offset:  40,
+				Limit:   10,
+			},
+			false,
+		},
+		{
+			"alerts", "?q=fed&event=5b1d2d8e1c9d440000a1b2c3",
+			search.Query{Kind: search.Alerts, Text: "fed", Sort: search.ByRelevance, Limit: 20,
+				EventID: bson.ObjectIdHex("5b1d2d8e1c9d440000a1b2c3")},
+			false,
+		},
+		{"alerts", "", search.Query{}, true},
+		{"storylines", "?q=fed", search.Query{}, true},
+		{"alerts", "?q=fed&event=nope", search.Query{}, true},
+		{"alerts", "?q=fed&offset=first", search.Query{}, true},
+		{"alerts", "?q=fed&limit=1000", search.Query{}, true},
+		{"alerts", "?q=fed&sort=popular", search.Query{}, true},
+	}
+
+	s := &service{loc: time.UTC}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/search/"+test.kind+test.query, nil)
+		got, err := s.parseSearchQuery(r, test.kind)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseSearchQuery(%s%s) expected an error", test.kind, test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSearchQuery(%s%s) returned an error: %s", test.kind, test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseSearchQuery(%s%s) got:%#v want:%#v", test.kind, test.query, got, test.want)
+		}
+	}
+}
+
+func TestWithoutBodies(t *testing.T) {
+	results := search.Results{Total: 2, Hits: []search.Hit{
+		{Title: "a", Highlights: map[string][]string{"subject": {"<em>fed</em>"}, "body": {"the <em>fed</em> said"}}},
+		{Title: "b", Highlights: map[string][]string{"body": {"<em>fed</em>"}}},
+		{Title: "c"},
+	}}
+	want := search.Results{Total: 2, Hits: []search.Hit{
+		{Title: "a", Highlights: map[string][]string{"subject": {"<em>fed</em>"}}},
+		{Title: "b"},
+		{Title: "c"},
+	}}
+	got := withoutBodies(results)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withoutBodies got:%#v want:%#v", got, want)
+	}
+	if _, ok := results.Hits[0].Highlights["body"]; !ok {
+		t.Error("withoutBodies modified the original results")
+	}
+}
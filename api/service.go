@@ -3,10 +3,13 @@ package api
 import (
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	sdpropagation "contrib.go.opencensus.io/exporter/stackdriver/propagation"
 	"github.com/NYTimes/gizmo/observe"
 	"github.com/NYTimes/gizmo/server"
+	"github.com/jprobinson/newshound/search"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
 	"go.opencensus.io/plugin/ochttp"
@@ -16,19 +19,66 @@ import (
 var _ server.MixedService = &service{}
 
 func NewService() (server.MixedService, error) {
+	return newService(NewConfig())
+}
+
+func newService(cfg *Config) (*service, error) {
 	observe.RegisterAndObserveGCP(func(err error) {
 		log.Printf("exporter client encountered an error: %s", err)
 	})
-	cfg := NewConfig()
 	sess, err := cfg.MgoSession()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init mgo")
 	}
-	return &service{sess: sess}, nil
+	if err := search.EnsureIndices(sess); err != nil {
+		return nil, errors.Wrap(err, "unable to ensure search indices")
+	}
+	loc, err := loadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load timezone")
+	}
+	s := &service{
+		sess:      sess,
+		reports:   newLRUCache(cfg.ReportCacheTTL),
+		versions:  newLRUCache(versionTTL),
+		responses: newLRUCache(cfg.ResponseCacheTTL),
+		loc:       loc,
+		searcher:  search.NewMongo(sess),
+	}
+	if err := s.initAuth(cfg.AuthConfig); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 type service struct {
 	sess *mgo.Session
+
+	adminKey string
+
+	// requireKey rejects anonymous requests. Otherwise they are
+	// made as the anonymous client.
+	requireKey bool
+	anonymous  client
+	// proxies is the number of trusted proxies
+	// that add to X-Forwarded-For
+	proxies int
+	// keys caches API key lookups
+	keys    *lruCache
+	limiter Limiter
+
+	// reports caches reports computed for custom date ranges
+	reports *lruCache
+	// versions caches the data versions and responses caches
+	// the responses built from them
+	versions  *lruCache
+	responses *lruCache
+
+	// loc is the default time zone for dates in requests
+	loc *time.Location
+
+	// searcher runs the full-text searches
+	searcher search.Backend
 }
 
 func (s *service) Prefix() string {
@@ -37,20 +87,72 @@ func (s *service) Prefix() string {
 
 func (s *service) Middleware(h http.Handler) http.Handler {
 	return &ochttp.Handler{
-		Handler:     cors.Default().Handler(h),
+		Handler:     apiCORS.Handler(deprecateV1(s.authorize(s.cache(h)))),
 		Propagation: &sdpropagation.HTTPFormat{},
 	}
 }
 
+// apiCORS allows browsers to send API keys and read the rate limit headers.
+var apiCORS = cors.New(cors.Options{
+	AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-API-Key"},
+	ExposedHeaders: []string{"Deprecation", "Link", "ETag", "Last-Modified", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-Quota-Limit", "X-Quota-Remaining", "Retry-After"},
+})
+
+// The prefixes of each version of the API. v1 is deprecated in favor of v2,
+// which serves the same endpoints with JSON responses wrapped in an Envelope.
+const (
+	v1Prefix = "/svc/newshound-api/v1"
+	v2Prefix = "/svc/newshound-api/v2"
+)
+
 func (s *service) Endpoints() map[string]map[string]http.HandlerFunc {
+	endpoints := s.v1Endpoints()
+	for path, methods := range s.v1Endpoints() {
+		endpoints[v2Path(path)] = methods
+	}
+	endpoints[v2Prefix+"/openapi.json"] = map[string]http.HandlerFunc{
+		"GET": s.getOpenAPI,
+	}
+	return endpoints
+}
+
+func (s *service) JSONEndpoints() map[string]map[string]server.JSONEndpoint {
+	endpoints := s.v1JSONEndpoints()
+	for path, methods := range s.v1JSONEndpoints() {
+		v2 := map[string]server.JSONEndpoint{}
+		for method, ep := range methods {
+			v2[method] = s.v2(ep)
+		}
+		endpoints[v2Path(path)] = v2
+	}
+	return endpoints
+}
+
+func v2Path(v1Path string) string {
+	return v2Prefix + strings.TrimPrefix(v1Path, v1Prefix)
+}
+
+func (s *service) v1Endpoints() map[string]map[string]http.HandlerFunc {
 	return map[string]map[string]http.HandlerFunc{
 		"/svc/newshound-api/v1/alert_html/{alert_id}": {
 			"GET": s.findAlertHTML,
 		},
+		"/svc/newshound-api/v1/export/{kind}/{start}/{end}": {
+			"GET": s.exportRecords,
+		},
+		"/svc/newshound-api/v1/feed/{format}/events": {
+			"GET": s.eventsFeed,
+		},
+		"/svc/newshound-api/v1/feed/{format}/sender/{sender}": {
+			"GET": s.senderFeed,
+		},
+		"/svc/newshound-api/v1/feed/{format}/tag/{tag}": {
+			"GET": s.tagFeed,
+		},
 	}
 }
 
-func (s *service) JSONEndpoints() map[string]map[string]server.JSONEndpoint {
+func (s *service) v1JSONEndpoints() map[string]map[string]server.JSONEndpoint {
 	return map[string]map[string]server.JSONEndpoint{
 		"/svc/newshound-api/v1/find_alerts/{start}/{end}": {
 			"GET": s.findAlertsByDate,
@@ -61,6 +163,9 @@ func (s *service) JSONEndpoints() map[string]map[string]server.JSONEndpoint {
 		"/svc/newshound-api/v1/alert/{alert_id}": {
 			"GET": s.findAlert,
 		},
+		"/svc/newshound-api/v1/alert/{alert_id}/revisions": {
+			"GET": s.findAlertRevisions,
+		},
 		"/svc/newshound-api/v1/find_events/{start}/{end}": {
 			"GET": s.findEventsByDate,
 		},
@@ -70,6 +175,24 @@ func (s *service) JSONEndpoints() map[string]map[string]server.JSONEndpoint {
 		"/svc/newshound-api/v1/event/{event_id}": {
 			"GET": s.findEvent,
 		},
+		"/svc/newshound-api/v1/event/{event_id}/storyline": {
+			"GET": s.findEventStoryline,
+		},
+		"/svc/newshound-api/v1/find_storylines/{start}/{end}": {
+			"GET": s.findStorylinesByDate,
+		},
+		"/svc/newshound-api/v1/storyline/{storyline_id}": {
+			"GET": s.findStoryline,
+		},
+		"/svc/newshound-api/v1/find_entities/{start}/{end}": {
+			"GET": s.findEntitiesByDate,
+		},
+		"/svc/newshound-api/v1/search/{kind}": {
+			"GET": s.searchText,
+		},
+		"/svc/newshound-api/v1/trending": {
+			"GET": s.findTrending,
+		},
 		"/svc/newshound-api/v1/report/alerts_per_week": {
 			"GET": s.getAlertsPerWeek,
 		},
@@ -82,6 +205,47 @@ func (s *service) JSONEndpoints() map[string]map[string]server.JSONEndpoint {
 		"/svc/newshound-api/v1/report/sender_info/{sender}": {
 			"GET": s.findSenderInfo,
 		},
+		"/svc/newshound-api/v1/report/sender_heatmap/{sender}": {
+			"GET": s.getSenderHeatmap,
+		},
+		"/svc/newshound-api/v1/report/tag_trend/{tag}": {
+			"GET": s.getTagTrend,
+		},
+		"/svc/newshound-api/v1/report/tag_movers": {
+			"GET": s.getTagMovers,
+		},
+		"/svc/newshound-api/v1/report/tag_cooccurrence/{tag}": {
+			"GET": s.getTagCoOccurrences,
+		},
+		"/svc/newshound-api/v1/report/compare/{start}/{end}": {
+			"GET": s.compareSenders,
+		},
+		"/svc/newshound-api/v1/report/scoop_leaderboard/{timeframe}": {
+			"GET": s.getScoopLeaderboard,
+		},
+		"/svc/newshound-api/v1/report/event_scoop/{event_id}": {
+			"GET": s.findEventScoop,
+		},
+		"/svc/newshound-api/v1/jobs": {
+			"GET": s.getJobStatus,
+		},
+		"/svc/newshound-api/v1/jobs/{job}/runs": {
+			"GET": s.findJobRuns,
+		},
+		"/svc/newshound-api/v1/admin/synonyms": {
+			"GET": s.admin(s.findSynonyms),
+		},
+		"/svc/newshound-api/v1/admin/synonyms/{tag}": {
+			"PUT":    s.admin(s.saveSynonym),
+			"DELETE": s.admin(s.deleteSynonym),
+		},
+		"/svc/newshound-api/v1/admin/keys": {
+			"GET":  s.admin(s.findAPIKeys),
+			"POST": s.admin(s.createAPIKey),
+		},
+		"/svc/newshound-api/v1/admin/keys/{key_id}": {
+			"DELETE": s.admin(s.deleteAPIKey),
+		},
 	}
 }
 
@@ -89,6 +253,18 @@ func (s *service) JSONMiddleware(e server.JSONEndpoint) server.JSONEndpoint {
 	return e
 }
 
+// admin will only allow requests with the configured admin key or an
+// API key with the admin scope through to the given endpoint.
+func (s *service) admin(e server.JSONEndpoint) server.JSONEndpoint {
+	return func(r *http.Request) (int, interface{}, error) {
+		c := requestClient(r)
+		if !s.isAdminKey(r.Header.Get("X-Admin-Key")) && (c == nil || !c.can(ScopeAdmin)) {
+			return errorStatus(http.StatusForbidden, CodeForbidden, "a valid X-Admin-Key is required")
+		}
+		return e(r)
+	}
+}
+
 type config struct {
 	DBURL      string `envconfig:"DB_URL"`
 	DBUser     string `envconfig:"DB_USER"`
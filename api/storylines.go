@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// StorylineDetail contains a Storyline along with all of its News Events and
+// News Alerts in chronological order.
+type StorylineDetail struct {
+	newshound.Storyline `bson:",inline"`
+	Events              []newshound.NewsEvent     `json:"events"`
+	Alerts              []newshound.NewsAlertLite `json:"alerts"`
+}
+
+// FindStorylinesByDate accepts a start and end date and returns all the Storylines that were
+// active in that timeframe ordered by their most recent event.
+func FindStorylinesByDate(ctx context.Context, db *mgo.Database, start, end time.Time) (storylines []newshound.Storyline, err error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-storylines-by-date")
+	defer span.End()
+
+	err = getSL(db).Find(bson.M{"start": bson.M{"$lte": end}, "end": bson.M{"$gte": start}}).
+		Sort("-end").All(&storylines)
+	return
+}
+
+// FindStorylineByID accepts a Storyline ID and returns the Storyline with all of its
+// News Events and News Alerts. A NotFoundError is returned if the storyline does not exist.
+func FindStorylineByID(ctx context.Context, db *mgo.Database, storylineID bson.ObjectId) (detail StorylineDetail, err error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-storyline-by-id")
+	defer span.End()
+
+	err = getSL(db).FindId(storylineID).One(&detail.Storyline)
+	if err != nil {
+		return detail, notFound(err, "storyline", storylineID.Hex())
+	}
+
+	err = getNE(db).Find(bson.M{"_id": bson.M{"$in": detail.EventIDs}}).Sort("event_start").All(&detail.Events)
+	if err != nil {
+		return
+	}
+
+	var alertIDs []bson.ObjectId
+	for _, event := range detail.Events {
+		for _, alert := range event.NewsAlerts {
+			alertIDs = append(alertIDs, alert.AlertID)
+		}
+	}
+	err = getNA(db).Find(bson.M{"_id": bson.M{"$in": alertIDs}}).Sort("timestamp").All(&detail.Alerts)
+	return
+}
+
+// FindStorylineByEvent accepts a News Event ID and returns the Storyline it belongs to.
+// A NotFoundError is returned if the event is not part of a storyline.
+func FindStorylineByEvent(ctx context.Context, db *mgo.Database, eventID bson.ObjectId) (storyline newshound.Storyline, err error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-storyline-by-event")
+	defer span.End()
+
+	err = getSL(db).Find(bson.M{"event_ids": eventID}).One(&storyline)
+	return storyline, notFound(err, "storyline for event", eventID.Hex())
+}
+
+func getSL(db *mgo.Database) *mgo.Collection {
+	return db.C("storylines")
+}
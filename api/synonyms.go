@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ErrSynonymChain is returned when saving a synonym would require more than
+// one lookup to find a tag's canonical form.
+var ErrSynonymChain = errors.New("canonical forms cannot be synonyms of other tags")
+
+// FindSynonyms returns the full tag synonym dictionary ordered by canonical form.
+func FindSynonyms(ctx context.Context, db *mgo.Database) ([]newshound.TagSynonym, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-synonyms")
+	defer span.End()
+
+	syns := []newshound.TagSynonym{}
+	err := getTS(db).Find(nil).Sort("canonical", "_id").All(&syns)
+	return syns, err
+}
+
+// SaveSynonym will add or replace the canonical form of the given tag. Tags
+// and canonical forms are stored in the same lowercase form np_extractor uses.
+func SaveSynonym(ctx context.Context, db *mgo.Database, syn newshound.TagSynonym) (newshound.TagSynonym, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/save-synonym")
+	defer span.End()
+
+	syn.Tag = cleanTag(syn.Tag)
+	syn.Canonical = cleanTag(syn.Canonical)
+
+	c := getTS(db)
+	// the canonical form cannot be a variant itself and the
+	// variant cannot already be the canonical form of others
+	n, err := c.Find(bson.M{"$or": []bson.M{
+		{"_id": syn.Canonical},
+		{"canonical": syn.Tag},
+	}}).Count()
+	if err != nil {
+		return syn, err
+	}
+	if n > 0 {
+		return syn, ErrSynonymChain
+	}
+
+	_, err = c.UpsertId(syn.Tag, syn)
+	return syn, err
+}
+
+// DeleteSynonym removes the given tag from the synonym dictionary. A NotFoundError
+// is returned if the tag is not in it.
+func DeleteSynonym(ctx context.Context, db *mgo.Database, tag string) error {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/delete-synonym")
+	defer span.End()
+
+	return notFound(getTS(db).RemoveId(cleanTag(tag)), "synonym", tag)
+}
+
+func cleanTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+func getTS(db *mgo.Database) *mgo.Collection {
+	return db.C("tag_synonyms")
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxTagResults is the most rising, falling or co-occurring
+// tags that can be requested at once.
+const maxTagResults = 100
+
+// TagCoOccurrences holds the tags used most often alongside a tag.
+type TagCoOccurrences struct {
+	Tag    string                `json:"tag"`
+	Alerts int                   `json:"alerts"`
+	Tags   []report.CoOccurrence `json:"tags"`
+}
+
+// FindTagTrend counts the alerts mentioning the tag in each day or week of the query's
+// date range. If bySender is set, the trend for each sender follows the overall trend.
+func FindTagTrend(ctx context.Context, db *mgo.Database, tag string, q ReportQuery, opts report.TermOptions, bySender bool) ([]report.TagTrend, error) {
+	alerts, err := findTermAlerts(ctx, db, tag, q.Start, q.End, q.Senders, opts)
+	if err != nil {
+		return nil, err
+	}
+	return report.TagTrends(alerts, tag, opts, q.Start, q.End, bySender), nil
+}
+
+// FindTagMovers returns the n tags that rose and fell the most in each day or week of
+// the query's date range.
+func FindTagMovers(ctx context.Context, db *mgo.Database, q ReportQuery, opts report.TermOptions, n int) ([]report.TagMovement, error) {
+	// include the period before the range so the first one can be compared
+	first := report.PeriodStart(q.Start, opts.Period, q.Start.Location())
+	prev := report.PeriodStart(first.Add(-time.Hour), opts.Period, q.Start.Location())
+	alerts, err := findTermAlerts(ctx, db, "", prev, q.End, q.Senders, opts)
+	if err != nil {
+		return nil, err
+	}
+	return report.TagMovers(alerts, opts, q.Start, q.End, n), nil
+}
+
+// FindTagCoOccurrences returns the n tags used most often on the same alerts as the
+// given tag within the query's date range.
+func FindTagCoOccurrences(ctx context.Context, db *mgo.Database, tag string, q ReportQuery, opts report.TermOptions, n int) (TagCoOccurrences, error) {
+	alerts, err := findTermAlerts(ctx, db, tag, q.Start, q.End, q.Senders, opts)
+	if err != nil {
+		return TagCoOccurrences{}, err
+	}
+	tags, total := report.TagCoOccurrences(alerts, tag, opts, n)
+	return TagCoOccurrences{Tag: tag, Alerts: total, Tags: tags}, nil
+}
+
+// findTermAlerts returns the alerts sent between start and end by the given senders.
+// If term is not empty, only alerts with the tag or entity will be returned.
+func findTermAlerts(ctx context.Context, db *mgo.Database, term string, start, end time.Time, senders []string, opts report.TermOptions) ([]newshound.NewsAlertLite, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-term-alerts")
+	defer span.End()
+
+	query := bson.M{
+		"timestamp":   bson.M{"$gte": start, "$lt": end},
+		"revision_of": bson.M{"$exists": false},
+	}
+	if len(senders) > 0 {
+		query["sender"] = bson.M{"$in": senders}
+	}
+	fields := bson.M{"sender": 1, "timestamp": 1, "tags": 1}
+	if opts.Entities {
+		fields = bson.M{"sender": 1, "timestamp": 1, "entities": 1}
+	}
+	if term != "" {
+		if opts.Entities {
+			query["entities.name"] = term
+		} else {
+			query["tags"] = term
+		}
+	}
+
+	var alerts []newshound.NewsAlertLite
+	err := getNA(db).Find(query).Select(fields).All(&alerts)
+	return alerts, err
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jprobinson/newshound/report"
+)
+
+func TestParseTermOptions(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    report.TermOptions
+		wantErr bool
+	}{
+		{"", report.TermOptions{Period: report.Weekly}, false},
+		{"?period=day&entities=true", report.TermOptions{Period: report.Daily, Entities: true}, false},
+		{"?period=month", report.TermOptions{}, true},
+		{"?entities=sure", report.TermOptions{}, true},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/svc/newshound-api/v1/report/tag_movers"+test.query, nil)
+		got, err := parseTermOptions(r)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseTermOptions(%q) expected an error", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTermOptions(%q) returned an error: %s", test.query, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseTermOptions(%q) got:%#v want:%#v", test.query, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jprobinson/newshound/report"
+)
+
+// dateLayout is the format of all dates accepted in URLs.
+const dateLayout = "2006-01-02"
+
+// loadLocation returns the named IANA time zone. An empty name means
+// report.Location, the same default fetchd stores reports in.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return report.Location, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// defaultLocation is the configured time zone, which should match the
+// one fetchd stores reports in.
+func (s *service) defaultLocation() *time.Location {
+	if s.loc == nil {
+		return report.Location
+	}
+	return s.loc
+}
+
+// location returns the time zone named by the request's 'tz' query
+// parameter or the configured default.
+func (s *service) location(r *http.Request) (*time.Location, error) {
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		return s.defaultLocation(), nil
+	}
+	return time.LoadLocation(tz)
+}
+
+// checkStoredZone rejects a 'tz' query parameter for stored reports, which
+// were bucketed into weeks and hours in the default time zone when generated.
+func (s *service) checkStoredZone(r *http.Request, q ReportQuery) error {
+	if q.Custom() || r.URL.Query().Get("tz") == "" {
+		return nil
+	}
+	return &InvalidDateError{fmt.Sprintf(
+		"tz requires start and end dates, stored reports are in %s", s.defaultLocation())}
+}
+
+// parseDateRange will parse the 'start' and 'end' dates from vars within the
+// request's time zone.
+func (s *service) parseDateRange(r *http.Request, vars map[string]string) (time.Time, time.Time, error) {
+	loc, err := s.location(r)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return parseDateRange(vars, loc)
+}
+
+// parseDateRange will parse the 'start' and 'end' YYYY-MM-DD dates in vars. The start
+// will be midnight of its day and the end will be the last millisecond of its day.
+func parseDateRange(vars map[string]string, loc *time.Location) (start time.Time, end time.Time, err error) {
+	if start, err = time.ParseInLocation(dateLayout, vars["start"], loc); err != nil {
+		return start, end, &InvalidDateError{"please use a valid start date with a format of YYYY-MM-DD"}
+	}
+	if end, err = time.ParseInLocation(dateLayout, vars["end"], loc); err != nil {
+		return start, end, &InvalidDateError{"please use a valid end date with a format of YYYY-MM-DD"}
+	}
+	if end.Before(start) {
+		return start, end, &InvalidDateError{"the end date must not be before the start date"}
+	}
+	end = end.AddDate(0, 0, 1).Add(-time.Millisecond)
+	return start, end, nil
+}
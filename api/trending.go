@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+)
+
+// FindTrending will return all tags and entities currently trending in News Alerts,
+// ordered by how far above their usual rate they are.
+func FindTrending(ctx context.Context, db *mgo.Database) ([]newshound.TrendingTag, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/find-trending")
+	defer span.End()
+
+	tags := []newshound.TrendingTag{}
+	err := getTT(db).Find(nil).Sort("-score", "_id").All(&tags)
+	return tags, err
+}
+
+func getTT(db *mgo.Database) *mgo.Collection {
+	return db.C("trending")
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NYTimes/gizmo/server"
+)
+
+// Envelope is the body of every v2 JSON response. Data is omitted for
+// failed requests and requests that have nothing to return.
+type Envelope struct {
+	Data  interface{} `json:"data,omitempty"`
+	Error *Error      `json:"error,omitempty"`
+	Meta  Meta        `json:"meta"`
+}
+
+// Meta describes a v2 response beyond its data. Responses may be served
+// from the cache, so nothing about how a single request was served is
+// included.
+type Meta struct {
+	// Paging is only set for a page of results.
+	Paging *Paging `json:"paging,omitempty"`
+}
+
+// Paging describes a page of results. NextCursor will be empty on the
+// last page.
+type Paging struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SenderInfoV2 is the Sender Info report without the '_id' and 'value'
+// wrappers left over from the map reduce jobs that generate it.
+type SenderInfoV2 struct {
+	AlertsPerWeek []AlertWeekV2 `json:"alerts_per_week"`
+	EventsPerWeek []EventWeekV2 `json:"events_per_week"`
+	Tags          []TagInfo     `json:"tags"`
+	AlertsPerHour []int64       `json:"alerts_per_hour"`
+}
+
+// AlertWeekV2 is a sender's alert counts for a week.
+type AlertWeekV2 struct {
+	WeekStart time.Time        `json:"week_start"`
+	Alerts    int              `json:"alerts"`
+	TagMap    map[string]int64 `json:"tag_map"`
+}
+
+// EventWeekV2 is a sender's event counts and ranks for a week.
+type EventWeekV2 struct {
+	WeekStart       time.Time `json:"week_start"`
+	TotalEvents     int64     `json:"total_events"`
+	TotalRank       int64     `json:"total_rank"`
+	AvgRank         float64   `json:"avg_rank"`
+	TotalTimeLapsed int64     `json:"total_time_lapsed"`
+	AvgTimeLapsed   float64   `json:"avg_time_lapsed"`
+}
+
+// v2 wraps a v1 JSON endpoint so its response is wrapped in an Envelope.
+func (s *service) v2(e server.JSONEndpoint) server.JSONEndpoint {
+	return func(r *http.Request) (int, interface{}, error) {
+		status, res, err := e(r)
+		if err != nil {
+			res = apiError(err)
+		}
+
+		var env Envelope
+		if e, ok := res.(*Error); ok {
+			env.Error = e
+		} else {
+			env.Data, env.Meta.Paging = v2Data(res)
+			if env.Meta.Paging != nil {
+				env.Meta.Paging.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+				if env.Meta.Paging.Limit == 0 {
+					env.Meta.Paging.Limit = defaultPageLimit
+				}
+			}
+		}
+		return status, env, nil
+	}
+}
+
+// v2Data converts the result of a v1 endpoint into its v2 form. Pages are
+// returned as their items along with the Paging for them.
+func v2Data(res interface{}) (interface{}, *Paging) {
+	switch v := res.(type) {
+	case Page:
+		return v.Items, &Paging{NextCursor: v.NextCursor}
+	case SenderInfo:
+		return senderInfoV2(v), nil
+	case string:
+		// there's nothing to return beyond the status
+		return nil, nil
+	}
+	return res, nil
+}
+
+func senderInfoV2(info SenderInfo) SenderInfoV2 {
+	out := SenderInfoV2{
+		AlertsPerWeek: []AlertWeekV2{},
+		EventsPerWeek: []EventWeekV2{},
+		Tags:          info.TagArray,
+		AlertsPerHour: info.AlertsPerHour,
+	}
+	for _, w := range info.AlertsPerWeek {
+		out.AlertsPerWeek = append(out.AlertsPerWeek, AlertWeekV2{
+			WeekStart: w.Id.WeekStart,
+			Alerts:    w.Value.Alerts,
+			TagMap:    w.Value.TagMap,
+		})
+	}
+	for _, w := range info.EventsPerWeek {
+		out.EventsPerWeek = append(out.EventsPerWeek, EventWeekV2{
+			WeekStart:       w.Id.WeekStart,
+			TotalEvents:     w.Value.TotalEvents,
+			TotalRank:       w.Value.TotalRank,
+			AvgRank:         w.Value.AvgRank,
+			TotalTimeLapsed: w.Value.TotalTimeLapsed,
+			AvgTimeLapsed:   w.Value.AvgTimeLapsed,
+		})
+	}
+	if out.Tags == nil {
+		out.Tags = []TagInfo{}
+	}
+	return out
+}
+
+// deprecateV1 marks v1 responses as deprecated and links to the same
+// endpoint in v2.
+func deprecateV1(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, v1Prefix+"/") {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "<"+v2Path(r.URL.Path)+`>; rel="successor-version"`)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// apiRoute returns a request's path relative to the prefix of its API
+// version and whether it's in the API at all.
+func apiRoute(path string) (string, bool) {
+	for _, prefix := range []string{v1Prefix, v2Prefix} {
+		if strings.HasPrefix(path, prefix+"/") {
+			return strings.TrimPrefix(path, prefix), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestV2(t *testing.T) {
+	alerts := []newshound.NewsAlertLite{{Sender: "cnn"}}
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		res    interface{}
+		err    error
+		want   Envelope
+	}{
+		{"plain", "/", http.StatusOK, alerts, nil, Envelope{Data: alerts}},
+		{"page", "/?limit=5", http.StatusOK, Page{Items: alerts, NextCursor: "next"}, nil,
+			Envelope{Data: alerts, Meta: Meta{Paging: &Paging{Limit: 5, NextCursor: "next"}}}},
+		{"last page", "/?cursor=abc", http.StatusOK, Page{Items: alerts}, nil,
+			Envelope{Data: alerts, Meta: Meta{Paging: &Paging{Limit: defaultPageLimit}}}},
+		{"ok", "/", http.StatusOK, "OK", nil, Envelope{}},
+		{"error", "/", http.StatusBadRequest, nil, &InvalidIDError{Kind: "alert", ID: "x"},
+			Envelope{Error: &Error{Status: http.StatusBadRequest, Code: CodeInvalidID, Message: `invalid alert id: "x"`}}},
+		{"server error", "/", http.StatusInternalServerError, nil, errors.New("no reachable servers"),
+			Envelope{Error: &Error{Status: http.StatusInternalServerError, Code: CodeServerError, Message: "server error"}}},
+		{"error body", "/", http.StatusForbidden, &Error{Status: http.StatusForbidden, Code: CodeForbidden}, nil,
+			Envelope{Error: &Error{Status: http.StatusForbidden, Code: CodeForbidden}}},
+	}
+
+	s := &service{}
+	for _, test := range tests {
+		ep := s.v2(func(r *http.Request) (int, interface{}, error) {
+			return test.status, test.res, test.err
+		})
+		status, res, err := ep(httptest.NewRequest("GET", test.path, nil))
+		if status != test.status || err != nil {
+			t.Errorf("%s got status:%d err:%v want status:%d", test.name, status, err, test.status)
+		}
+		if env := res.(Envelope); !reflect.DeepEqual(env, test.want) {
+			t.Errorf("%s got:%#v want:%#v", test.name, env, test.want)
+		}
+	}
+}
+
+func TestSenderInfoV2(t *testing.T) {
+	week := time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC)
+	var info SenderInfo
+	info.AlertsPerWeek = make([]AlertWeekInfo, 1)
+	info.AlertsPerWeek[0].Id.WeekStart = week
+	info.AlertsPerWeek[0].Value.Alerts = 12
+	info.EventsPerWeek = make([]EventWeekInfo, 1)
+	info.EventsPerWeek[0].Id.WeekStart = week
+	info.EventsPerWeek[0].Value.TotalEvents = 4
+	info.EventsPerWeek[0].Value.AvgRank = 1.5
+	info.AlertsPerHour = []int64{1, 2}
+
+	want := SenderInfoV2{
+		AlertsPerWeek: []AlertWeekV2{{WeekStart: week, Alerts: 12}},
+		EventsPerWeek: []EventWeekV2{{WeekStart: week, TotalEvents: 4, AvgRank: 1.5}},
+		Tags:          []TagInfo{},
+		AlertsPerHour: []int64{1, 2},
+	}
+	data, paging := v2Data(info)
+	if !reflect.DeepEqual(data, want) || paging != nil {
+		t.Errorf("v2Data got:%#v, %v want:%#v", data, paging, want)
+	}
+}
+
+func TestDeprecateV1(t *testing.T) {
+	h := deprecateV1(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	tests := []struct {
+		path string
+		link string
+	}{
+		{v1Prefix + "/event/5b1d2d8e1c9d440000a1b2c3", "<" + v2Prefix + `/event/5b1d2d8e1c9d440000a1b2c3>; rel="successor-version"`},
+		{v2Prefix + "/event/5b1d2d8e1c9d440000a1b2c3", ""},
+		{v2Prefix + "/openapi.json", ""},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		deprecated := w.Header().Get("Deprecation") == "true"
+		if deprecated != (test.link != "") || w.Header().Get("Link") != test.link {
+			t.Errorf("deprecateV1(%q) got headers:%v want link:%q", test.path, w.Header(), test.link)
+		}
+	}
+}
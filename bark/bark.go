@@ -22,6 +22,30 @@ func (e EventBarkerFunc) Bark(event newshound.NewsEvent) error {
 	return e(event)
 }
 
+type TrendBarker interface {
+	Bark(tag newshound.TrendingTag) error
+}
+
+type TrendBarkerFunc func(tag newshound.TrendingTag) error
+
+func (t TrendBarkerFunc) Bark(tag newshound.TrendingTag) error {
+	return t(tag)
+}
+
+// MinImportanceEventBarker will only pass events along to the given barker if
+// their importance score is at least 'min'.
+func MinImportanceEventBarker(min float64, barker EventBarker) EventBarker {
+	if min <= 0 {
+		return barker
+	}
+	return EventBarkerFunc(func(event newshound.NewsEvent) error {
+		if event.Importance < min {
+			return nil
+		}
+		return barker.Bark(event)
+	})
+}
+
 var SenderColors = map[string]string{
 	"cnn":                      "#B60002",
 	"foxnews.com":              "#234E6C",
@@ -23,6 +23,13 @@ type econfig struct {
 	TwitterConsumers       []string `envconfig:"TWITTER_CONSUMERS"`
 	TwitterConsumerSecrets []string `envconfig:"TWITTER_CONSUMER_SECRETS"`
 
+	// minimum importance scores for events to be sent to each type of barker
+	SlackEventMinImportance   float64 `envconfig:"SLACK_EVENT_MIN_IMPORTANCE"`
+	TwitterEventMinImportance float64 `envconfig:"TWITTER_EVENT_MIN_IMPORTANCE"`
+
+	// whether trending tags should be sent to slack
+	SlackTrends bool `envconfig:"SLACK_TRENDS"`
+
 	Auth gcp.IdentityConfig `envconfig:"AUTH"`
 }
 
@@ -31,6 +38,8 @@ type service struct {
 
 	eventsOut []EventBarker
 
+	trendsOut []TrendBarker
+
 	verifier *auth.Verifier
 }
 
@@ -48,14 +57,19 @@ func NewService() (kit.Service, error) {
 	var (
 		alerts             []AlertBarker
 		events             []EventBarker
+		trends             []TrendBarker
 		slackers, twitters int
 	)
 
 	for _, key := range cfg.SlackKeys {
 		alerts = append(alerts, NewSlackAlertBarker(
 			SlackConfig{Key: key, BotName: "Newshound Alerts"}))
-		events = append(events, NewSlackEventBarker(
-			SlackConfig{Key: key, BotName: "Newshound Alerts"}))
+		events = append(events, MinImportanceEventBarker(cfg.SlackEventMinImportance,
+			NewSlackEventBarker(SlackConfig{Key: key, BotName: "Newshound Alerts"})))
+		if cfg.SlackTrends {
+			trends = append(trends, NewSlackTrendBarker(
+				SlackConfig{Key: key, BotName: "Newshound Alerts"}))
+		}
 		slackers++
 	}
 
@@ -68,7 +82,8 @@ func NewService() (kit.Service, error) {
 		consumer := cfg.TwitterConsumers[i]
 		consumerSecret := cfg.TwitterConsumerSecrets[i]
 		alerts = append(alerts, NewTwitterAlertBarker(consumer, consumerSecret, token, secret))
-		events = append(events, NewTwitterEventBarker(token, secret))
+		events = append(events, MinImportanceEventBarker(cfg.TwitterEventMinImportance,
+			NewTwitterEventBarker(token, secret)))
 		twitters++
 	}
 
@@ -79,6 +94,7 @@ func NewService() (kit.Service, error) {
 		verifier:  v,
 		alertsOut: alerts,
 		eventsOut: events,
+		trendsOut: trends,
 	}, nil
 }
 
@@ -124,6 +140,12 @@ func (s *service) HTTPEndpoints() map[string]map[string]kit.HTTPEndpoint {
 				Endpoint: s.postEvent,
 			},
 		},
+		"/svc/newshound/v1/bark/trending": {
+			"POST": {
+				Decoder:  decodeTrend,
+				Endpoint: s.postTrend,
+			},
+		},
 	}
 }
 
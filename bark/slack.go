@@ -28,7 +28,7 @@ type SlackAlertBarker struct {
 func (s *SlackAlertBarker) Bark(alert newshound.NewsAlertLite) error {
 	title := fmt.Sprintf("%s - %s", strings.TrimSuffix(alert.Sender, ".com"), alert.Subject)
 
-	link := alertLink(alert)
+	link := newshound.AlertLink(alert)
 	message := fmt.Sprintf("\n%s\n<%s|more...>", alert.TopSentence, link)
 	color := SenderColors[strings.ToLower(alert.Sender)]
 	return sendSlack(s.cfg.BotName, s.cfg.Key, title, link, message, color)
@@ -44,14 +44,42 @@ type SlackEventBarker struct {
 
 func (s *SlackEventBarker) Bark(event newshound.NewsEvent) error {
 	title := fmt.Sprintf("New Event With %d Alerts!", len(event.NewsAlerts))
-	link := eventLink(event)
-	message := fmt.Sprintf("_key quote_\n%s\n_from_\n%s\n<%s|more info...>",
-		event.TopSentence,
-		strings.TrimSuffix(event.TopSender, ".com"),
+	link := newshound.EventLink(event)
+	// events saved before headlines existed only have a key quote
+	if event.Headline == "" {
+		message := fmt.Sprintf("_key quote_\n%s\n_from_\n%s\n<%s|more info...>",
+			event.TopSentence,
+			strings.TrimSuffix(event.TopSender, ".com"),
+			link)
+		return sendSlack(s.cfg.BotName, s.cfg.Key, title, link, message, "#439FE0")
+	}
+	message := fmt.Sprintf("*%s*\n%s\n<%s|more info...>",
+		event.Headline,
+		strings.Join(event.Summary, " "),
 		link)
 	return sendSlack(s.cfg.BotName, s.cfg.Key, title, link, message, "#439FE0")
 }
 
+func NewSlackTrendBarker(cfg SlackConfig) *SlackTrendBarker {
+	return &SlackTrendBarker{cfg: cfg}
+}
+
+type SlackTrendBarker struct {
+	cfg SlackConfig
+}
+
+func (s *SlackTrendBarker) Bark(tag newshound.TrendingTag) error {
+	title := fmt.Sprintf("Trending: %s", tag.Tag)
+	link := newshound.TrendLink(tag)
+	senders := make([]string, len(tag.Senders))
+	for i, sender := range tag.Senders {
+		senders[i] = strings.TrimSuffix(sender, ".com")
+	}
+	message := fmt.Sprintf("%d alerts in the last hour (usually %.1f)\n_from_\n%s\n<%s|more info...>",
+		tag.Alerts, tag.Expected, strings.Join(senders, ", "), link)
+	return sendSlack(s.cfg.BotName, s.cfg.Key, title, link, message, "#E0A043")
+}
+
 type slackAttachment struct {
 	Title     string   `json:"title"`
 	TitleLink string   `json:"title_link,omitempty"`
@@ -100,15 +128,3 @@ func sendSlack(bot, key, title, link, message, color string) error {
 
 	return err
 }
-
-func alertLink(alert newshound.NewsAlertLite) string {
-	return fmt.Sprintf("http://newshound.email/#/calendar?start=%s&display=alerts&alert=%s",
-		alert.Timestamp.Format("2006-01-02"),
-		alert.ID.Hex())
-}
-
-func eventLink(event newshound.NewsEvent) string {
-	return fmt.Sprintf("http://newshound.email/#/calendar?start=%s&display=events&event=%s",
-		event.EventStart.Format("2006-01-02"),
-		event.ID.Hex())
-}
@@ -0,0 +1,43 @@
+package bark
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"net/http"
+
+	"github.com/NYTimes/gizmo/server/kit"
+	"github.com/jprobinson/newshound"
+)
+
+func decodeTrend(ctx context.Context, r *http.Request) (interface{}, error) {
+	var msg psmessage
+	err := json.NewDecoder(r.Body).Decode(&msg)
+	if err != nil {
+		kit.LogErrorMsg(ctx, err, "unable to decode request. dismissing message.")
+		return nil, kit.NewJSONStatusResponse("bad request", http.StatusOK)
+	}
+
+	var tag newshound.TrendingTag
+	err = gob.NewDecoder(bytes.NewBuffer(msg.Message.Data)).Decode(&tag)
+	if err != nil {
+		kit.LogErrorMsg(ctx, err, "unable to ungob payload. dismissing message.")
+		return nil, kit.NewJSONStatusResponse("bad request", http.StatusOK)
+	}
+
+	return tag, nil
+}
+
+func (s *service) postTrend(ctx context.Context, r interface{}) (interface{}, error) {
+	tag := r.(newshound.TrendingTag)
+
+	for _, barker := range s.trendsOut {
+		err := barker.Bark(tag)
+		if err != nil {
+			kit.LogErrorMsg(ctx, err, "problems barking about trending tag")
+		}
+	}
+
+	return "OK", nil
+}
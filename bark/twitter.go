@@ -23,7 +23,7 @@ type TwitterAlertBarker struct {
 
 func (s *TwitterAlertBarker) Bark(alert newshound.NewsAlertLite) error {
 	msg := twitterize(fmt.Sprintf("%s - %s", strings.TrimSuffix(alert.Sender, ".com"), alert.TopSentence))
-	msg = msg + alertLink(alert)
+	msg = msg + newshound.AlertLink(alert)
 	_, err := s.api.PostTweet(msg, url.Values{})
 	return err
 }
@@ -39,7 +39,7 @@ type TwitterEventBarker struct {
 func (s *TwitterEventBarker) Bark(event newshound.NewsEvent) error {
 	msg := fmt.Sprintf("New News Event! %d alerts reporting on ", len(event.NewsAlerts))
 	msg = twitterize(msg + strings.TrimPrefix(fmt.Sprintf("%#v", event.Tags), "[]string"))
-	msg = msg + eventLink(event)
+	msg = msg + newshound.EventLink(event)
 	_, err := s.api.PostTweet(msg, nil)
 	return err
 }
@@ -45,7 +45,46 @@ type Config struct {
 		AccessTokenSecret string `json:"access-token-secret"`
 	} `json:"twitter"`
 
+	StreamConfig
+}
+
+// StreamConfig is where fetchd streams new alerts and events
+// and how it authorizes the clients that connect.
+type StreamConfig struct {
 	WSPort int `json:"ws-port"`
+
+	// StreamAuth works like the API's key settings. Its
+	// environment variables are prefixed with STREAM_.
+	StreamAuth AuthConfig `json:"-" envconfig:"STREAM"`
+}
+
+// AuthConfig is how requests are authorized with API keys.
+type AuthConfig struct {
+	// AdminKey must be passed in the X-Admin-Key header to use
+	// any admin endpoints. Admin endpoints are disabled without it.
+	AdminKey string `envconfig:"ADMIN_KEY"`
+
+	// RequireAPIKey rejects requests without a valid API key in the
+	// X-API-Key header or 'api_key' query parameter.
+	RequireAPIKey bool `envconfig:"REQUIRE_API_KEY"`
+
+	// AnonymousScopes and the anonymous limits apply to requests made
+	// without an API key, which are limited by IP address. Zero limits
+	// are unlimited.
+	AnonymousScopes    []string `envconfig:"ANONYMOUS_SCOPES" default:"read-public"`
+	AnonymousPerMinute int      `envconfig:"ANONYMOUS_PER_MINUTE"`
+	AnonymousPerDay    int      `envconfig:"ANONYMOUS_PER_DAY"`
+
+	// TrustedProxies is the number of proxies in front of the server that
+	// add the address they got a request from to X-Forwarded-For. Anonymous
+	// clients are identified by the address that many entries from the end
+	// of the header. Without any, the connection's address is used since
+	// clients can set the header to anything.
+	TrustedProxies int `envconfig:"TRUSTED_PROXIES"`
+
+	// RateLimitStore is where request counts are kept. 'memory' is only
+	// accurate for a single instance, 'mongo' shares them between instances.
+	RateLimitStore string `envconfig:"RATE_LIMIT_STORE" default:"memory"`
 }
 
 func (c *Config) MgoSession() (*mgo.Session, error) {
@@ -82,8 +121,12 @@ type NewsAlertLite struct {
 	Sender      string        `json:"sender"bson:"sender"`
 	Timestamp   time.Time     `json:"timestamp"bson:"timestamp"`
 	Tags        []string      `json:"tags"bson:"tags"`
+	Entities    []Entity      `json:"entities" bson:"entities"`
 	Subject     string        `json:"subject"bson:"subject"`
 	TopSentence string        `json:"top_sentence"bson:"top_sentence"`
+	// RevisionOf is set if this alert is a resend of an earlier alert from
+	// the same sender. It holds the ID of the original alert.
+	RevisionOf bson.ObjectId `json:"revision_of,omitempty" bson:"revision_of,omitempty"`
 }
 
 // NewsAlertFull is a struct that contains all News Alert
@@ -95,6 +138,32 @@ type NewsAlert struct {
 	Sentences     []Sentence `json:"sentences"bson:"sentences"`
 }
 
+// EntityType is the classification given to a proper noun phrase
+// found within a News Alert.
+type EntityType string
+
+const (
+	EntityPerson       EntityType = "person"
+	EntityOrganization EntityType = "organization"
+	EntityLocation     EntityType = "location"
+	EntityEvent        EntityType = "event"
+	EntityOther        EntityType = "other"
+)
+
+// Entity is a typed noun phrase. Name uses the same normalized
+// form as the tag it was extracted from.
+type Entity struct {
+	Name string     `json:"name" bson:"name"`
+	Type EntityType `json:"type" bson:"type"`
+}
+
+// TagSynonym maps a tag variant to the canonical form it should be
+// stored and counted as.
+type TagSynonym struct {
+	Tag       string `json:"tag" bson:"_id"`
+	Canonical string `json:"canonical" bson:"canonical"`
+}
+
 type Sentence struct {
 	Value   string   `json:"sentence"bson:"sentence"`
 	Phrases []string `json:"noun_phrases"bson:"noun_phrases"`
@@ -104,12 +173,101 @@ type Sentence struct {
 // a particular News Event.
 type NewsEvent struct {
 	ID          bson.ObjectId    `json:"id" bson:"_id"`
-	Tags        []string         `json:"tags"`
+	Tags        []string         `json:"tags" bson:"tags"`
+	Entities    []Entity         `json:"entities" bson:"entities"`
 	EventStart  time.Time        `json:"event_start"bson:"event_start"`
 	EventEnd    time.Time        `json:"event_end"bson:"event_end"`
 	NewsAlerts  []NewsEventAlert `json:"news_alerts"bson:"news_alerts"`
 	TopSentence string           `json:"top_sentence"bson:"top_sentence"`
 	TopSender   string           `json:"top_sender"bson:"top_sender"`
+	Headline    string           `json:"headline" bson:"headline"`
+	Summary     []string         `json:"summary" bson:"summary"`
+	// Importance is a 0-100 score of how big a story the event is.
+	Importance float64 `json:"importance" bson:"importance"`
+	Major      bool    `json:"major" bson:"major"`
+}
+
+// Storyline is a struct for linking related News Events that
+// span more than a single event's timeframe.
+type Storyline struct {
+	ID         bson.ObjectId   `json:"id" bson:"_id"`
+	Tags       []string        `json:"tags" bson:"tags"`
+	Entities   []Entity        `json:"entities" bson:"entities"`
+	Start      time.Time       `json:"start" bson:"start"`
+	End        time.Time       `json:"end" bson:"end"`
+	EventIDs   []bson.ObjectId `json:"event_ids" bson:"event_ids"`
+	Headline   string          `json:"headline" bson:"headline"`
+	Importance float64         `json:"importance" bson:"importance"`
+}
+
+// TrendingTag is a tag or entity that is being mentioned by News Alerts
+// much more often than usual.
+type TrendingTag struct {
+	Tag string `json:"tag" bson:"_id"`
+	// Type is only set if the tag is also an entity.
+	Type    EntityType `json:"type,omitempty" bson:"type,omitempty"`
+	Alerts  int        `json:"alerts" bson:"alerts"`
+	Senders []string   `json:"senders" bson:"senders"`
+	// Expected is the number of alerts the baseline rate predicted.
+	Expected float64   `json:"expected" bson:"expected"`
+	Score    float64   `json:"score" bson:"score"`
+	Since    time.Time `json:"since" bson:"since"`
+	Updated  time.Time `json:"updated" bson:"updated"`
+}
+
+// JobRun is the record of a single run of one of fetchd's scheduled jobs.
+type JobRun struct {
+	ID    bson.ObjectId `json:"id" bson:"_id"`
+	Job   string        `json:"job" bson:"job"`
+	Owner string        `json:"owner" bson:"owner"`
+	// Trigger is "schedule" or "manual".
+	Trigger string    `json:"trigger" bson:"trigger"`
+	Start   time.Time `json:"start" bson:"start"`
+	End     time.Time `json:"end" bson:"end"`
+	// Duration is in seconds.
+	Duration  float64 `json:"duration" bson:"duration"`
+	Succeeded bool    `json:"succeeded" bson:"succeeded"`
+	Error     string  `json:"error,omitempty" bson:"error,omitempty"`
+	// ReportErrors holds the error of each report that failed to save, keyed by name.
+	ReportErrors map[string]string `json:"report_errors,omitempty" bson:"report_errors,omitempty"`
+}
+
+// JobLock keeps more than one instance from running a scheduled job at once.
+// The lock is held until it is released or it expires.
+type JobLock struct {
+	Job      string    `json:"job" bson:"_id"`
+	Owner    string    `json:"owner" bson:"owner"`
+	Acquired time.Time `json:"acquired" bson:"acquired"`
+	Expires  time.Time `json:"expires" bson:"expires"`
+}
+
+// The names of the DataVersions the fetch pipeline bumps whenever it changes
+// the data behind them.
+const (
+	AlertsVersion  = "alerts"
+	EventsVersion  = "events"
+	ReportsVersion = "reports"
+)
+
+// DataVersion changes every time the data it is named for does, so API
+// responses built from that data can be cached until it changes again.
+type DataVersion struct {
+	Name    string        `json:"name" bson:"_id"`
+	Version bson.ObjectId `json:"version" bson:"version"`
+	Updated time.Time     `json:"updated" bson:"updated"`
+}
+
+// APIKey grants a client access to the API with the given scopes and limits.
+// Only a hash of the key itself is stored.
+type APIKey struct {
+	ID     bson.ObjectId `json:"id" bson:"_id"`
+	Hash   string        `json:"-" bson:"hash"`
+	Name   string        `json:"name" bson:"name"`
+	Scopes []string      `json:"scopes" bson:"scopes"`
+	// PerMinute and PerDay limit the requests made with the key. Zero is unlimited.
+	PerMinute int       `json:"per_minute" bson:"per_minute"`
+	PerDay    int       `json:"per_day" bson:"per_day"`
+	Created   time.Time `json:"created" bson:"created"`
 }
 
 // NewsEventAlert is a struct for holding a smaller version of
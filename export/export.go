@@ -0,0 +1,159 @@
+// Package export writes News Alerts, News Events and the alerts within each
+// event in bulk so they can be analyzed outside of Newshound. Both the export
+// command and the API's streaming endpoint are built on Export.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// The kinds of records that can be exported.
+const (
+	Alerts = "alerts"
+	Events = "events"
+	// Memberships are the alerts within each News Event, one row per alert.
+	Memberships = "memberships"
+)
+
+// The formats records can be exported in.
+const (
+	CSV     = "csv"
+	NDJSON  = "ndjson"
+	Parquet = "parquet"
+)
+
+// batchSize is the number of records pulled from the database at a time.
+var batchSize = 500
+
+// Options describe what to export.
+type Options struct {
+	Kind   string
+	Format string
+
+	// Start and End are the date range to export. End is exclusive. Alerts
+	// are selected by their timestamp and events by their start.
+	Start time.Time
+	End   time.Time
+
+	// Fields to export in order. If it is empty, all of the kind's
+	// fields are exported except for the alert bodies.
+	Fields []string
+
+	// Bodies adds the alerts' raw_body, body and sentences to the
+	// default fields.
+	Bodies bool
+}
+
+// Validate returns an error if the options cannot be exported.
+func (o Options) Validate() error {
+	if _, ok := columns[o.Kind]; !ok {
+		return fmt.Errorf("unknown export kind: %q", o.Kind)
+	}
+	if ContentType(o.Format) == "" {
+		return fmt.Errorf("unknown export format: %q", o.Format)
+	}
+	if o.Start.IsZero() || o.End.IsZero() || !o.Start.Before(o.End) {
+		return fmt.Errorf("invalid date range: %s - %s", o.Start, o.End)
+	}
+	_, err := o.columns()
+	return err
+}
+
+// ContentType returns the media type of the format or an empty
+// string if it is unknown.
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case NDJSON:
+		return "application/x-ndjson"
+	case Parquet:
+		return "application/vnd.apache.parquet"
+	}
+	return ""
+}
+
+// Export will write every record matching the options to w and return
+// the number of rows written.
+func Export(ctx context.Context, db *mgo.Database, opts Options, w io.Writer) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/export-"+opts.Kind)
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		return 0, err
+	}
+	cols, _ := opts.columns()
+	rw, err := newRowWriter(w, opts.Format, cols)
+	if err != nil {
+		return 0, err
+	}
+
+	fields := bson.M{}
+	for _, col := range cols {
+		fields[col.field] = 1
+	}
+
+	var rows int
+	write := func(rec record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row := make([]interface{}, len(cols))
+		for i, col := range cols {
+			row[i] = col.value(rec)
+		}
+		rows++
+		return rw.Write(row)
+	}
+
+	switch opts.Kind {
+	case Alerts:
+		iter := db.C("news_alerts").
+			Find(bson.M{"timestamp": bson.M{"$gte": opts.Start, "$lt": opts.End}}).
+			Select(fields).Sort("timestamp").Batch(batchSize).Iter()
+		var alert newshound.NewsAlert
+		for iter.Next(&alert) {
+			if err = write(record{alert: &alert}); err != nil {
+				iter.Close()
+				return rows, err
+			}
+			alert = newshound.NewsAlert{}
+		}
+		err = iter.Close()
+	default:
+		iter := db.C("news_events").
+			Find(bson.M{"event_start": bson.M{"$gte": opts.Start, "$lt": opts.End}}).
+			Select(fields).Sort("event_start").Batch(batchSize).Iter()
+		var event newshound.NewsEvent
+		for iter.Next(&event) {
+			if opts.Kind == Events {
+				err = write(record{event: &event})
+			} else {
+				for i := range event.NewsAlerts {
+					if err = write(record{event: &event, member: &event.NewsAlerts[i]}); err != nil {
+						break
+					}
+				}
+			}
+			if err != nil {
+				iter.Close()
+				return rows, err
+			}
+			event = newshound.NewsEvent{}
+		}
+		err = iter.Close()
+	}
+	if err != nil {
+		return rows, err
+	}
+	return rows, rw.Close()
+}
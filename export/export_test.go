@@ -0,0 +1,187 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestOptionsColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    []string
+		wantErr bool
+	}{
+		{
+			"default alerts",
+			Options{Kind: Alerts},
+			[]string{"id", "instance_id", "article_url", "sender", "timestamp", "subject",
+				"top_sentence", "tags", "entities", "revision_of"},
+			false,
+		},
+		{
+			"alerts with bodies",
+			Options{Kind: Alerts, Bodies: true},
+			[]string{"id", "instance_id", "article_url", "sender", "timestamp", "subject",
+				"top_sentence", "tags", "entities", "revision_of", "raw_body", "body", "sentences"},
+			false,
+		},
+		{
+			"selected fields",
+			Options{Kind: Memberships, Fields: []string{"sender", "event_id", "sender", "order"}},
+			[]string{"sender", "event_id", "order"},
+			false,
+		},
+		{
+			"unknown field",
+			Options{Kind: Events, Fields: []string{"id", "body"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		cols, err := test.opts.columns()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("columns(%s) expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("columns(%s) returned an error: %s", test.name, err)
+			continue
+		}
+		var got []string
+		for _, col := range cols {
+			got = append(got, col.name)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("columns(%s) got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	start := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	tests := []struct {
+		opts    Options
+		wantErr bool
+	}{
+		{Options{Kind: Alerts, Format: CSV, Start: start, End: end}, false},
+		{Options{Kind: "storylines", Format: CSV, Start: start, End: end}, true},
+		{Options{Kind: Events, Format: "xlsx", Start: start, End: end}, true},
+		{Options{Kind: Events, Format: NDJSON, Start: end, End: start}, true},
+		{Options{Kind: Events, Format: Parquet, Start: start, End: end, Fields: []string{"nope"}}, true},
+	}
+	for _, test := range tests {
+		if err := test.opts.Validate(); (err != nil) != test.wantErr {
+			t.Errorf("Validate(%#v) got error %v, wantErr %t", test.opts, err, test.wantErr)
+		}
+	}
+}
+
+var testAlert = newshound.NewsAlert{
+	NewsAlertLite: newshound.NewsAlertLite{
+		ID:        bson.ObjectIdHex("5b1d2d8e1c9d440000a1b2c3"),
+		Sender:    "cnn",
+		Timestamp: time.Date(2018, 6, 10, 12, 30, 0, 0, time.UTC),
+		Subject:   "Breaking, \"news\"",
+		Tags:      []string{"trump", "senate"},
+	},
+	Body: "the body",
+}
+
+func writeTestAlert(t *testing.T, format string, fields ...string) []byte {
+	cols, err := Options{Kind: Alerts, Fields: fields}.columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	rw, err := newRowWriter(&buf, format, cols)
+	if err != nil {
+		t.Fatalf("newRowWriter(%s) returned an error: %s", format, err)
+	}
+	row := make([]interface{}, len(cols))
+	for i, col := range cols {
+		row[i] = col.value(record{alert: &testAlert})
+	}
+	if err = rw.Write(row); err != nil {
+		t.Fatalf("Write(%s) returned an error: %s", format, err)
+	}
+	if err = rw.Close(); err != nil {
+		t.Fatalf("Close(%s) returned an error: %s", format, err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	got := string(writeTestAlert(t, CSV, "id", "timestamp", "subject", "tags", "body"))
+	want := "id,timestamp,subject,tags,body\n" +
+		"5b1d2d8e1c9d440000a1b2c3,2018-06-10T12:30:00Z,\"Breaking, \"\"news\"\"\",\"[\"\"trump\"\",\"\"senate\"\"]\",the body\n"
+	if got != want {
+		t.Errorf("CSV export got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	got := string(writeTestAlert(t, NDJSON, "sender", "timestamp", "tags"))
+	want := `{"sender":"cnn","tags":["trump","senate"],"timestamp":"2018-06-10T12:30:00Z"}` + "\n"
+	if got != want {
+		t.Errorf("NDJSON export got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	data := writeTestAlert(t, Parquet, "sender", "timestamp", "tags")
+
+	pr, err := reader.NewParquetColumnReader(newBytesFile(data), 1)
+	if err != nil {
+		t.Fatalf("unable to read parquet export: %s", err)
+	}
+	if rows := pr.GetNumRows(); rows != 1 {
+		t.Errorf("parquet export got %d rows, want 1", rows)
+	}
+
+	tests := []struct {
+		column string
+		want   interface{}
+	}{
+		{"parquet_go_root.sender", "cnn"},
+		{"parquet_go_root.timestamp", testAlert.Timestamp.UnixNano() / int64(time.Millisecond)},
+		{"parquet_go_root.tags", `["trump","senate"]`},
+	}
+	for _, test := range tests {
+		values, _, _, err := pr.ReadColumnByPath(test.column, 1)
+		if err != nil {
+			t.Errorf("unable to read %s: %s", test.column, err)
+			continue
+		}
+		if len(values) != 1 || values[0] != test.want {
+			t.Errorf("parquet %s got:%#v want:%#v", test.column, values, test.want)
+		}
+	}
+}
+
+// bytesFile lets the Parquet reader read an export from memory.
+type bytesFile struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newBytesFile(data []byte) *bytesFile {
+	return &bytesFile{Reader: bytes.NewReader(data), data: data}
+}
+
+func (b *bytesFile) Write([]byte) (int, error)                 { return 0, errStreamOnly }
+func (b *bytesFile) Close() error                              { return nil }
+func (b *bytesFile) Open(string) (source.ParquetFile, error)   { return newBytesFile(b.data), nil }
+func (b *bytesFile) Create(string) (source.ParquetFile, error) { return nil, errStreamOnly }
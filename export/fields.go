@@ -0,0 +1,152 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/jprobinson/newshound"
+)
+
+// record is a single row's worth of data. Only the values for the
+// kind being exported are set.
+type record struct {
+	alert  *newshound.NewsAlert
+	event  *newshound.NewsEvent
+	member *newshound.NewsEventAlert
+}
+
+// valueType is how a column's values are encoded.
+type valueType int
+
+const (
+	stringType valueType = iota
+	intType
+	floatType
+	boolType
+	timeType
+	// listType values are slices or structs. They are JSON
+	// encoded in CSV and Parquet.
+	listType
+)
+
+type column struct {
+	name string
+	typ  valueType
+	// field is the database field the value comes from.
+	field string
+	// body columns are only exported by default when bodies are requested.
+	body  bool
+	value func(record) interface{}
+}
+
+var columns = map[string][]column{
+	Alerts: {
+		{name: "id", field: "_id", value: func(r record) interface{} { return r.alert.ID.Hex() }},
+		{name: "instance_id", field: "instance_id", value: func(r record) interface{} { return r.alert.InstanceID }},
+		{name: "article_url", field: "article_url", value: func(r record) interface{} { return r.alert.ArticleUrl }},
+		{name: "sender", field: "sender", value: func(r record) interface{} { return r.alert.Sender }},
+		{name: "timestamp", typ: timeType, field: "timestamp", value: func(r record) interface{} { return r.alert.Timestamp }},
+		{name: "subject", field: "subject", value: func(r record) interface{} { return r.alert.Subject }},
+		{name: "top_sentence", field: "top_sentence", value: func(r record) interface{} { return r.alert.TopSentence }},
+		{name: "tags", typ: listType, field: "tags", value: func(r record) interface{} { return r.alert.Tags }},
+		{name: "entities", typ: listType, field: "entities", value: func(r record) interface{} { return r.alert.Entities }},
+		{name: "revision_of", field: "revision_of", value: func(r record) interface{} {
+			if r.alert.RevisionOf == "" {
+				return ""
+			}
+			return r.alert.RevisionOf.Hex()
+		}},
+		{name: "raw_body", field: "raw_body", body: true, value: func(r record) interface{} { return r.alert.RawBody }},
+		{name: "body", field: "body", body: true, value: func(r record) interface{} { return r.alert.Body }},
+		{name: "sentences", typ: listType, field: "sentences", body: true, value: func(r record) interface{} { return r.alert.Sentences }},
+	},
+	Events: {
+		{name: "id", field: "_id", value: func(r record) interface{} { return r.event.ID.Hex() }},
+		{name: "event_start", typ: timeType, field: "event_start", value: func(r record) interface{} { return r.event.EventStart }},
+		{name: "event_end", typ: timeType, field: "event_end", value: func(r record) interface{} { return r.event.EventEnd }},
+		{name: "headline", field: "headline", value: func(r record) interface{} { return r.event.Headline }},
+		{name: "top_sender", field: "top_sender", value: func(r record) interface{} { return r.event.TopSender }},
+		{name: "top_sentence", field: "top_sentence", value: func(r record) interface{} { return r.event.TopSentence }},
+		{name: "alerts", typ: intType, field: "news_alerts.alert_id", value: func(r record) interface{} { return int64(len(r.event.NewsAlerts)) }},
+		{name: "senders", typ: listType, field: "news_alerts.sender", value: func(r record) interface{} {
+			senders := make([]string, 0, len(r.event.NewsAlerts))
+			for _, alert := range r.event.NewsAlerts {
+				senders = append(senders, alert.Sender)
+			}
+			return senders
+		}},
+		{name: "tags", typ: listType, field: "tags", value: func(r record) interface{} { return r.event.Tags }},
+		{name: "entities", typ: listType, field: "entities", value: func(r record) interface{} { return r.event.Entities }},
+		{name: "summary", typ: listType, field: "summary", value: func(r record) interface{} { return r.event.Summary }},
+		{name: "importance", typ: floatType, field: "importance", value: func(r record) interface{} { return r.event.Importance }},
+		{name: "major", typ: boolType, field: "major", value: func(r record) interface{} { return r.event.Major }},
+	},
+	Memberships: {
+		{name: "event_id", field: "_id", value: func(r record) interface{} { return r.event.ID.Hex() }},
+		{name: "event_start", typ: timeType, field: "event_start", value: func(r record) interface{} { return r.event.EventStart }},
+		{name: "alert_id", field: "news_alerts.alert_id", value: func(r record) interface{} { return r.member.AlertID.Hex() }},
+		{name: "instance_id", field: "news_alerts.instance_id", value: func(r record) interface{} { return r.member.InstanceID }},
+		{name: "article_url", field: "news_alerts.article_url", value: func(r record) interface{} { return r.member.ArticleUrl }},
+		{name: "sender", field: "news_alerts.sender", value: func(r record) interface{} { return r.member.Sender }},
+		{name: "subject", field: "news_alerts.subject", value: func(r record) interface{} { return r.member.Subject }},
+		{name: "top_sentence", field: "news_alerts.top_sentence", value: func(r record) interface{} { return r.member.TopSentence }},
+		{name: "tags", typ: listType, field: "news_alerts.tags", value: func(r record) interface{} { return r.member.Tags }},
+		{name: "order", typ: intType, field: "news_alerts.order", value: func(r record) interface{} { return r.member.Order }},
+		{name: "time_lapsed", typ: intType, field: "news_alerts.time_lapsed", value: func(r record) interface{} { return r.member.TimeLapsed }},
+	},
+}
+
+// Fields returns the names of every field that can be exported for the kind.
+func Fields(kind string) []string {
+	var names []string
+	for _, col := range columns[kind] {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// IncludesBodies reports whether the export includes any of the alerts'
+// bodies, either by default because Bodies is set or as explicit fields.
+func (o Options) IncludesBodies() bool {
+	cols, _ := o.columns()
+	for _, col := range cols {
+		if col.body {
+			return true
+		}
+	}
+	return false
+}
+
+// columns returns the columns to export in order.
+func (o Options) columns() ([]column, error) {
+	all := columns[o.Kind]
+	if len(o.Fields) == 0 {
+		var cols []column
+		for _, col := range all {
+			if !col.body || o.Bodies {
+				cols = append(cols, col)
+			}
+		}
+		return cols, nil
+	}
+
+	var cols []column
+	seen := map[string]bool{}
+	for _, name := range o.Fields {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		found := false
+		for _, col := range all {
+			if col.name == name {
+				cols = append(cols, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown %s field: %q", o.Kind, name)
+		}
+	}
+	return cols, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound/api"
+	"github.com/jprobinson/newshound/export"
+)
+
+func main() {
+	kind := flag.String("kind", export.Alerts, "what to export: alerts, events or memberships")
+	format := flag.String("format", export.CSV, "the export format: csv, ndjson or parquet")
+	start := flag.String("start", "", "the first day to export (YYYY-MM-DD)")
+	end := flag.String("end", "", "the last day to export (YYYY-MM-DD)")
+	tz := flag.String("tz", "", "the IANA time zone of the dates (defaults to local time)")
+	fields := flag.String("fields", "", "comma separated fields to export (defaults to all)")
+	bodies := flag.Bool("bodies", false, "include the raw_body, body and sentences of alerts")
+	out := flag.String("o", "", "the file to write to (defaults to stdout)")
+	flag.Parse()
+
+	loc := time.Local
+	if *tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(*tz); err != nil {
+			log.Fatal("unable to load timezone: ", err)
+		}
+	}
+	startTime, err := time.ParseInLocation("2006-01-02", *start, loc)
+	if err != nil {
+		log.Fatal("please use a valid start date with a format of YYYY-MM-DD: ", err)
+	}
+	endTime, err := time.ParseInLocation("2006-01-02", *end, loc)
+	if err != nil {
+		log.Fatal("please use a valid end date with a format of YYYY-MM-DD: ", err)
+	}
+
+	opts := export.Options{
+		Kind:   *kind,
+		Format: *format,
+		Start:  startTime,
+		// the end date is inclusive, so run through to midnight
+		End:    endTime.AddDate(0, 0, 1),
+		Bodies: *bodies,
+	}
+	if *fields != "" {
+		for _, field := range strings.Split(*fields, ",") {
+			opts.Fields = append(opts.Fields, strings.TrimSpace(field))
+		}
+	}
+	if err = opts.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	sess, err := api.NewConfig().MgoSession()
+	if err != nil {
+		log.Fatal("unable to connect to mongo: ", err)
+	}
+	defer sess.Close()
+
+	f := os.Stdout
+	if *out != "" {
+		if f, err = os.Create(*out); err != nil {
+			log.Fatal("unable to create export file: ", err)
+		}
+	}
+	w := bufio.NewWriter(f)
+
+	began := time.Now()
+	rows, err := export.Export(context.Background(), sess.DB("newshound"), opts, w)
+	if err != nil {
+		log.Fatalf("export failed after %d rows: %s", rows, err)
+	}
+	if err = w.Flush(); err != nil {
+		log.Fatal("unable to write export: ", err)
+	}
+	if err = f.Close(); err != nil {
+		log.Fatal("unable to close export file: ", err)
+	}
+	log.Printf("exported %d %s rows in %s", rows, opts.Kind, time.Since(began))
+}
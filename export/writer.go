@@ -0,0 +1,171 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// rowWriter encodes rows of column values in an export format.
+type rowWriter interface {
+	Write(row []interface{}) error
+	// Close flushes anything buffered. It does not close the underlying writer.
+	Close() error
+}
+
+func newRowWriter(w io.Writer, format string, cols []column) (rowWriter, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w, cols)
+	case NDJSON:
+		return &ndjsonWriter{w: bufio.NewWriter(w), cols: cols}, nil
+	case Parquet:
+		return newParquetWriter(w, cols)
+	}
+	return nil, fmt.Errorf("unknown export format: %q", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, cols []column) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.name
+	}
+	return &csvWriter{w: cw}, cw.Write(header)
+}
+
+func (c *csvWriter) Write(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case string:
+			record[i] = v
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		case time.Time:
+			record[i] = v.Format(time.RFC3339)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			record[i] = string(b)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	cols []column
+}
+
+func (n *ndjsonWriter) Write(row []interface{}) error {
+	obj := make(map[string]interface{}, len(row))
+	for i, value := range row {
+		obj[n.cols[i].name] = value
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if _, err = n.w.Write(b); err != nil {
+		return err
+	}
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
+
+// parquetRowGroupSize is how much data is buffered in memory
+// before a Parquet row group is written out.
+var parquetRowGroupSize int64 = 16 * 1024 * 1024
+
+type parquetWriter struct {
+	pw *writer.CSVWriter
+}
+
+func newParquetWriter(w io.Writer, cols []column) (*parquetWriter, error) {
+	md := make([]string, len(cols))
+	for i, col := range cols {
+		typ := "UTF8"
+		switch col.typ {
+		case intType:
+			typ = "INT64"
+		case floatType:
+			typ = "DOUBLE"
+		case boolType:
+			typ = "BOOLEAN"
+		case timeType:
+			typ = "TIMESTAMP_MILLIS"
+		}
+		md[i] = fmt.Sprintf("name=%s, type=%s", col.name, typ)
+	}
+	pw, err := writer.NewCSVWriter(md, &streamFile{w: w}, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.RowGroupSize = parquetRowGroupSize
+	return &parquetWriter{pw: pw}, nil
+}
+
+func (p *parquetWriter) Write(row []interface{}) error {
+	values := make([]interface{}, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case string, int64, float64, bool:
+			values[i] = v
+		case time.Time:
+			values[i] = v.UnixNano() / int64(time.Millisecond)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			values[i] = string(b)
+		}
+	}
+	return p.pw.Write(values)
+}
+
+func (p *parquetWriter) Close() error {
+	return p.pw.WriteStop()
+}
+
+// streamFile lets the Parquet writer, which only ever appends,
+// write directly to a stream.
+type streamFile struct {
+	w io.Writer
+}
+
+var errStreamOnly = errors.New("parquet export stream is write only")
+
+func (s *streamFile) Write(p []byte) (int, error)               { return s.w.Write(p) }
+func (s *streamFile) Read([]byte) (int, error)                  { return 0, errStreamOnly }
+func (s *streamFile) Seek(int64, int) (int64, error)            { return 0, errStreamOnly }
+func (s *streamFile) Close() error                              { return nil }
+func (s *streamFile) Open(string) (source.ParquetFile, error)   { return nil, errStreamOnly }
+func (s *streamFile) Create(string) (source.ParquetFile, error) { return nil, errStreamOnly }
@@ -0,0 +1,129 @@
+// Package feed renders News Alerts and News Events as RSS 2.0, Atom and
+// JSON Feed documents for feed readers.
+package feed
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// The formats a feed can be written in.
+const (
+	RSS  = "rss"
+	Atom = "atom"
+	JSON = "json"
+)
+
+// ValidFormat reports whether format is one of the feed formats.
+func ValidFormat(format string) bool {
+	return format == RSS || format == Atom || format == JSON
+}
+
+// ContentType returns the content type of a feed format.
+func ContentType(format string) string {
+	switch format {
+	case RSS:
+		return "application/rss+xml; charset=utf-8"
+	case Atom:
+		return "application/atom+xml; charset=utf-8"
+	case JSON:
+		return "application/feed+json; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// Feed is a format independent feed.
+type Feed struct {
+	Title       string
+	Description string
+	// Link is the web page the feed is about.
+	Link string
+	// Self is the URL the feed itself was requested from.
+	Self  string
+	Items []Item
+}
+
+// Item is a single entry in a feed.
+type Item struct {
+	// ID is a permanent, unique URN for the item.
+	ID      string
+	Title   string
+	Link    string
+	Summary string
+	Author  string
+	Tags    []string
+	// Published is when the item was first sent and Updated is when it last
+	// changed. Updated is never before Published.
+	Published time.Time
+	Updated   time.Time
+}
+
+// Updated returns the newest time any of the feed's items were updated.
+// Empty feeds were last updated at the Unix epoch so they never appear
+// to change.
+func (f Feed) Updated() time.Time {
+	updated := time.Unix(0, 0).UTC()
+	for _, item := range f.Items {
+		if item.Updated.After(updated) {
+			updated = item.Updated
+		}
+	}
+	return updated
+}
+
+// AlertItem returns the feed item for a News Alert.
+func AlertItem(alert newshound.NewsAlertLite) Item {
+	return Item{
+		ID:        "urn:newshound:alert:" + alert.ID.Hex(),
+		Title:     alert.Subject,
+		Link:      newshound.AlertLink(alert),
+		Summary:   alert.TopSentence,
+		Author:    alert.Sender,
+		Tags:      alert.Tags,
+		Published: alert.Timestamp,
+		Updated:   alert.Timestamp,
+	}
+}
+
+// EventItem returns the feed item for a News Event. Events are updated as
+// alerts join them, so their Updated time is their end.
+func EventItem(event newshound.NewsEvent) Item {
+	item := Item{
+		ID:        "urn:newshound:event:" + event.ID.Hex(),
+		Title:     event.Headline,
+		Link:      newshound.EventLink(event),
+		Summary:   strings.Join(event.Summary, " "),
+		Author:    event.TopSender,
+		Tags:      event.Tags,
+		Published: event.EventStart,
+		Updated:   event.EventEnd,
+	}
+	// events saved before headlines existed only have a key quote
+	if item.Title == "" {
+		item.Title = event.TopSentence
+	}
+	if item.Summary == "" {
+		item.Summary = event.TopSentence
+	}
+	if item.Updated.Before(item.Published) {
+		item.Updated = item.Published
+	}
+	return item
+}
+
+// Write writes the feed to w in the given format.
+func Write(w io.Writer, f Feed, format string) error {
+	switch format {
+	case RSS:
+		return writeRSS(w, f)
+	case Atom:
+		return writeAtom(w, f)
+	case JSON:
+		return writeJSON(w, f)
+	}
+	return fmt.Errorf("invalid feed format: %q", format)
+}
@@ -0,0 +1,212 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	testStart = time.Date(2019, 6, 3, 14, 5, 0, 0, time.UTC)
+
+	testAlert = newshound.NewsAlertLite{
+		ID:          bson.ObjectIdHex("5cf5289c1c9d440000a1b2c3"),
+		Sender:      "nytimes.com",
+		Timestamp:   testStart,
+		Subject:     "Breaking News: Senate passes the bill",
+		TopSentence: "The Senate passed the bill <late> on Monday & sent it to the House.",
+		Tags:        []string{"senate", "congress"},
+	}
+	testEvent = newshound.NewsEvent{
+		ID:          bson.ObjectIdHex("5cf5289c1c9d440000a1b2c4"),
+		EventStart:  testStart,
+		EventEnd:    testStart.Add(20 * time.Minute),
+		TopSender:   "cnn.com",
+		TopSentence: "The Senate passed the bill.",
+		Tags:        []string{"senate"},
+	}
+)
+
+func testFeed() Feed {
+	return Feed{
+		Title:       "Newshound: News Events",
+		Description: "News Events",
+		Link:        newshound.WebURL,
+		Self:        "http://localhost/svc/newshound-api/v1/feed/rss/events",
+		Items:       []Item{AlertItem(testAlert), EventItem(testEvent)},
+	}
+}
+
+func TestItems(t *testing.T) {
+	alert := AlertItem(testAlert)
+	if alert.ID != "urn:newshound:alert:5cf5289c1c9d440000a1b2c3" || alert.Link != newshound.AlertLink(testAlert) ||
+		alert.Author != "nytimes.com" || !alert.Updated.Equal(testStart) {
+		t.Errorf("AlertItem got:%#v", alert)
+	}
+
+	event := EventItem(testEvent)
+	// events without headlines or summaries fall back to their key quote
+	if event.Title != testEvent.TopSentence || event.Summary != testEvent.TopSentence {
+		t.Errorf("EventItem got title:%q summary:%q want:%q", event.Title, event.Summary, testEvent.TopSentence)
+	}
+	if event.Link != newshound.EventLink(testEvent) || !event.Updated.Equal(testEvent.EventEnd) {
+		t.Errorf("EventItem got link:%s updated:%s", event.Link, event.Updated)
+	}
+
+	testEvent.Headline = "Senate Passes Bill"
+	testEvent.Summary = []string{"The Senate passed the bill.", "It goes to the House next."}
+	event = EventItem(testEvent)
+	testEvent.Headline, testEvent.Summary = "", nil
+	if event.Title != "Senate Passes Bill" || event.Summary != "The Senate passed the bill. It goes to the House next." {
+		t.Errorf("EventItem got title:%q summary:%q", event.Title, event.Summary)
+	}
+}
+
+func TestUpdated(t *testing.T) {
+	if got, want := testFeed().Updated(), testEvent.EventEnd; !got.Equal(want) {
+		t.Errorf("Updated got:%s want:%s", got, want)
+	}
+	if got := (Feed{}).Updated(); got.Unix() != 0 {
+		t.Errorf("Updated of an empty feed got:%s want the epoch", got)
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testFeed(), RSS); err != nil {
+		t.Fatalf("Write returned an error: %s", err)
+	}
+
+	var got struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				GUID        string   `xml:"guid"`
+				PubDate     string   `xml:"pubDate"`
+				Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				Description string   `xml:"description"`
+				Categories  []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unable to parse RSS: %s\n%s", err, buf.String())
+	}
+	if got.Version != "2.0" || got.Channel.Title != "Newshound: News Events" ||
+		got.Channel.LastBuildDate != "Mon, 03 Jun 2019 14:25:00 +0000" {
+		t.Errorf("RSS channel got:%#v", got.Channel)
+	}
+	if len(got.Channel.Items) != 2 {
+		t.Fatalf("RSS got %d items want 2", len(got.Channel.Items))
+	}
+	item := got.Channel.Items[0]
+	if item.Title != testAlert.Subject || item.Link != newshound.AlertLink(testAlert) ||
+		item.GUID != "urn:newshound:alert:5cf5289c1c9d440000a1b2c3" || item.Creator != "nytimes.com" ||
+		item.Description != testAlert.TopSentence || item.PubDate != "Mon, 03 Jun 2019 14:05:00 +0000" ||
+		!reflect.DeepEqual(item.Categories, testAlert.Tags) {
+		t.Errorf("RSS item got:%#v", item)
+	}
+	if !strings.Contains(buf.String(), `<atom:link href="http://localhost/svc/newshound-api/v1/feed/rss/events" rel="self"`) {
+		t.Errorf("RSS is missing its self link:\n%s", buf.String())
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testFeed(), Atom); err != nil {
+		t.Fatalf("Write returned an error: %s", err)
+	}
+
+	var got struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Author  string   `xml:"author>name"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Title     string `xml:"title"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Author    string `xml:"author>name"`
+			Link      struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unable to parse Atom: %s\n%s", err, buf.String())
+	}
+	if got.ID != testFeed().Self || got.Updated != "2019-06-03T14:25:00Z" || got.Author != "newshound" {
+		t.Errorf("Atom feed got id:%q updated:%q author:%q", got.ID, got.Updated, got.Author)
+	}
+	if len(got.Entries) != 2 {
+		t.Fatalf("Atom got %d entries want 2", len(got.Entries))
+	}
+	entry := got.Entries[1]
+	if entry.ID != "urn:newshound:event:5cf5289c1c9d440000a1b2c4" || entry.Title != testEvent.TopSentence ||
+		entry.Published != "2019-06-03T14:05:00Z" || entry.Updated != "2019-06-03T14:25:00Z" ||
+		entry.Author != "cnn.com" || entry.Link.Href != newshound.EventLink(testEvent) ||
+		len(entry.Categories) != 1 || entry.Categories[0].Term != "senate" {
+		t.Errorf("Atom entry got:%#v", entry)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testFeed(), JSON); err != nil {
+		t.Fatalf("Write returned an error: %s", err)
+	}
+
+	var got struct {
+		Version string `json:"version"`
+		FeedURL string `json:"feed_url"`
+		Items   []struct {
+			ID            string    `json:"id"`
+			URL           string    `json:"url"`
+			ContentText   string    `json:"content_text"`
+			DatePublished time.Time `json:"date_published"`
+			Authors       []struct {
+				Name string `json:"name"`
+			} `json:"authors"`
+			Tags []string `json:"tags"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unable to parse JSON Feed: %s\n%s", err, buf.String())
+	}
+	if got.Version != jsonFeedVersion || got.FeedURL != testFeed().Self || len(got.Items) != 2 {
+		t.Fatalf("JSON Feed got:%#v", got)
+	}
+	item := got.Items[0]
+	if item.ID != "urn:newshound:alert:5cf5289c1c9d440000a1b2c3" || item.URL != newshound.AlertLink(testAlert) ||
+		item.ContentText != testAlert.TopSentence || !item.DatePublished.Equal(testStart) ||
+		len(item.Authors) != 1 || item.Authors[0].Name != "nytimes.com" || !reflect.DeepEqual(item.Tags, testAlert.Tags) {
+		t.Errorf("JSON Feed item got:%#v", item)
+	}
+
+	// empty feeds still have an items list
+	buf.Reset()
+	if err := Write(&buf, Feed{}, JSON); err != nil || !strings.Contains(buf.String(), `"items": []`) {
+		t.Errorf("empty JSON Feed got:%s err:%v", buf.String(), err)
+	}
+}
+
+func TestWriteInvalidFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, testFeed(), "html"); err == nil {
+		t.Error("Write expected an error for an invalid format")
+	}
+}
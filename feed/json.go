@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonFeedVersion is the version of the JSON Feed spec feeds are written in.
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Description string     `json:"description,omitempty"`
+	Authors     []jsonName `json:"authors"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonName struct {
+	Name string `json:"name"`
+}
+
+type jsonItem struct {
+	ID            string     `json:"id"`
+	URL           string     `json:"url"`
+	Title         string     `json:"title"`
+	ContentText   string     `json:"content_text"`
+	DatePublished time.Time  `json:"date_published"`
+	DateModified  time.Time  `json:"date_modified"`
+	Authors       []jsonName `json:"authors,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+}
+
+func writeJSON(w io.Writer, f Feed) error {
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.Self,
+		Description: f.Description,
+		Authors:     []jsonName{{Name: feedAuthor}},
+		Items:       []jsonItem{},
+	}
+	for _, item := range f.Items {
+		ji := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentText:   item.Summary,
+			DatePublished: item.Published,
+			DateModified:  item.Updated,
+			Tags:          item.Tags,
+		}
+		if item.Author != "" {
+			ji.Authors = []jsonName{{Name: item.Author}}
+		}
+		doc.Items = append(doc.Items, ji)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -81,7 +81,7 @@ func NewNewsAlert(msg eazye.Email, host, address string) (newshound.NewsAlert, e
 		news = append(news, blankSpace...)
 		news = append(news, []byte(na.Subject)...)
 	}
-	na.Tags, na.Sentences, na.TopSentence, err = callNP(host, news)
+	na.Tags, na.Entities, na.Sentences, na.TopSentence, err = callNP(host, news)
 	return na, err
 }
 
@@ -102,7 +102,7 @@ func ReParseNewsAlert(na newshound.NewsAlert, host, address string) (newshound.N
 		news = append(news, blankSpace...)
 		news = append(news, []byte(na.Subject)...)
 	}
-	na.Tags, na.Sentences, na.TopSentence, err = callNP(host, news)
+	na.Tags, na.Entities, na.Sentences, na.TopSentence, err = callNP(host, news)
 	return na, err
 }
 
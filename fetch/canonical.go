@@ -0,0 +1,183 @@
+package fetch
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// synonyms maps a tag variant to its canonical form. It is loaded
+// from the tag_synonyms collection before any mail is parsed.
+var (
+	synonyms   = map[string]string{}
+	synonymsMu sync.RWMutex
+)
+
+// LoadSynonyms will refresh the tag synonym dictionary from the database.
+func LoadSynonyms(db *mgo.Database) error {
+	var syns []newshound.TagSynonym
+	if err := tagSynonyms(db).Find(nil).All(&syns); err != nil {
+		return err
+	}
+	dict := make(map[string]string, len(syns))
+	for _, syn := range syns {
+		dict[syn.Tag] = syn.Canonical
+	}
+
+	synonymsMu.Lock()
+	synonyms = dict
+	synonymsMu.Unlock()
+	return nil
+}
+
+// canonicalTag will clean up the spacing and possessives of the given tag
+// and swap it for its canonical form if it has one.
+func canonicalTag(tag string) string {
+	tag = strings.Join(strings.Fields(tag), " ")
+	for _, suffix := range []string{"'s", "’s"} {
+		if trimmed := strings.TrimSuffix(tag, suffix); len(trimmed) > 0 {
+			tag = trimmed
+		}
+	}
+	// 'the democrats'' => 'the democrats'
+	if strings.HasSuffix(tag, "s'") || strings.HasSuffix(tag, "s’") {
+		tag = strings.TrimRight(tag, "'’")
+	}
+
+	synonymsMu.RLock()
+	defer synonymsMu.RUnlock()
+	if canonical, ok := synonyms[strings.ToLower(tag)]; ok {
+		return canonical
+	}
+	return tag
+}
+
+// canonicalTags will canonicalize all the given tags and remove any
+// duplicates that result.
+func canonicalTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	var canon []string
+	for _, tag := range tags {
+		tag = canonicalTag(tag)
+		if _, dupe := seen[tag]; dupe {
+			continue
+		}
+		seen[tag] = struct{}{}
+		canon = append(canon, tag)
+	}
+	return canon
+}
+
+// ReCanonicalize will apply the current synonym dictionary to the tags of all
+// existing News Alerts and News Events. The data versions are bumped for
+// anything that changed so API caches drop the old tags.
+func ReCanonicalize(sess *mgo.Session) error {
+	log.Print("re-canonicalizing tags")
+	s := sess.Copy()
+	defer s.Close()
+	db := newshoundDB(s)
+
+	if err := LoadSynonyms(db); err != nil {
+		return err
+	}
+
+	na := newsAlerts(db)
+	var (
+		alert   newshound.NewsAlert
+		updated int
+	)
+	iter := na.Find(nil).Select(bson.M{"tags": 1, "entities": 1, "sentences": 1}).Batch(1000).Iter()
+	for iter.Next(&alert) {
+		tags := canonicalTags(alert.Tags)
+		changed := !equalTags(tags, alert.Tags)
+		for i, e := range alert.Entities {
+			if name := canonicalTag(e.Name); name != e.Name {
+				alert.Entities[i].Name = name
+				changed = true
+			}
+		}
+		for _, sent := range alert.Sentences {
+			for i, p := range sent.Phrases {
+				if phrase := canonicalTag(p); phrase != p {
+					sent.Phrases[i] = phrase
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		err := na.UpdateId(alert.ID, bson.M{"$set": bson.M{
+			"tags":      tags,
+			"entities":  alert.Entities,
+			"sentences": alert.Sentences,
+		}})
+		if err != nil {
+			return err
+		}
+		updated++
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	log.Printf("re-canonicalized %d alerts", updated)
+	if updated > 0 {
+		bumpVersions(db, newshound.AlertsVersion)
+	}
+
+	ne := newsEvents(db)
+	var event newshound.NewsEvent
+	updated = 0
+	iter = ne.Find(nil).Batch(1000).Iter()
+	for iter.Next(&event) {
+		tags := canonicalTags(event.Tags)
+		changed := !equalTags(tags, event.Tags)
+		for i, ea := range event.NewsAlerts {
+			if eaTags := canonicalTags(ea.Tags); !equalTags(eaTags, ea.Tags) {
+				event.NewsAlerts[i].Tags = eaTags
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		err := ne.UpdateId(event.ID, bson.M{"$set": bson.M{
+			"tags":        tags,
+			"news_alerts": event.NewsAlerts,
+		}})
+		if err != nil {
+			return err
+		}
+		updated++
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	log.Printf("re-canonicalized %d events", updated)
+	if updated > 0 {
+		bumpVersions(db, newshound.EventsVersion)
+	}
+	return nil
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func tagSynonyms(db *mgo.Database) *mgo.Collection {
+	return db.C("tag_synonyms")
+}
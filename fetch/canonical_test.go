@@ -0,0 +1,39 @@
+package fetch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalTags(t *testing.T) {
+	synonyms = map[string]string{
+		"president trump":      "donald trump",
+		"donald j. trump":      "donald trump",
+		"trump administration": "donald trump",
+	}
+	defer func() { synonyms = map[string]string{} }()
+
+	tests := []struct {
+		given []string
+		want  []string
+	}{
+		{
+			[]string{"president trump", "donald j. trump", "trump administration"},
+			[]string{"donald trump"},
+		},
+		{
+			[]string{"president  trump's", "congress"},
+			[]string{"donald trump", "congress"},
+		},
+		{
+			[]string{"the dude’s", "the democrats'", "'s"},
+			[]string{"the dude", "the democrats", "'s"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := canonicalTags(test.given); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("canonicalTags(%q) got:%q want:%q", test.given, got, test.want)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package fetch
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/jprobinson/eazye"
+	"github.com/jprobinson/newshound"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/mgo.v2"
 )
@@ -19,6 +21,31 @@ type Config struct {
 	Mailbox eazye.MailboxInfo `envconfig:"MAILBOX"`
 
 	NPHost string `envconfig:"NP_HOST"`
+
+	// ScoopTieThreshold is the lead time under which the first sender
+	// on an event is not given credit for the scoop. It's saved with the
+	// scoop leaderboards for the API. Defaults to report.DefaultTieThreshold.
+	ScoopTieThreshold time.Duration `envconfig:"SCOOP_TIE_THRESHOLD"`
+
+	RevisionWindow     time.Duration `envconfig:"REVISION_WINDOW"`
+	RevisionSimilarity float64       `envconfig:"REVISION_SIMILARITY"`
+
+	// Timezone is the IANA time zone the stored reports bucket
+	// weeks and hours in. Defaults to UTC. The reports are rebuilt
+	// on startup when it changes.
+	Timezone string `envconfig:"TIMEZONE"`
+
+	// ReportSchedule is a cron spec for when the reports are rebuilt.
+	ReportSchedule string `envconfig:"REPORT_SCHEDULE"`
+
+	// TrendingTopic is the GCP Pub/Sub topic newly trending tags are
+	// published to. They are not published if it is not set.
+	TrendingTopic string `envconfig:"TRENDING_TOPIC"`
+
+	// StreamConfig is the port new alerts and events are streamed on
+	// and how streaming clients are authorized. They are not streamed
+	// if WSPort is not set.
+	newshound.StreamConfig
 }
 
 func NewConfig() *Config {
@@ -0,0 +1,69 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a Friday
+	now := time.Date(2018, 6, 1, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		loc  *time.Location
+		want time.Time
+	}{
+		{"* * * * *", time.UTC, time.Date(2018, 6, 1, 12, 35, 0, 0, time.UTC)},
+		{"30 4 * * *", time.UTC, time.Date(2018, 6, 2, 4, 30, 0, 0, time.UTC)},
+		{"30 4 * * *", newYork, time.Date(2018, 6, 2, 8, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.UTC, time.Date(2018, 6, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.UTC, time.Date(2018, 6, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1,3", time.UTC, time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.UTC, time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * *", time.UTC, time.Date(2018, 6, 15, 0, 0, 0, 0, time.UTC)},
+		// either the day of the month or week matches when both are set
+		{"0 0 15 * 6", time.UTC, time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.UTC, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.UTC, time.Date(2018, 6, 1, 13, 0, 0, 0, time.UTC)},
+		{"@daily", time.UTC, time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.UTC, time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"@every 6h", time.UTC, now.Add(6 * time.Hour)},
+		{"0 0 31 2 *", time.UTC, time.Time{}},
+	}
+
+	for _, test := range tests {
+		sched, err := ParseSchedule(test.spec, test.loc)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) returned an error: %s", test.spec, err)
+			continue
+		}
+		if got := sched.Next(now); !got.Equal(test.want) {
+			t.Errorf("ParseSchedule(%q, %s).Next() got:%s want:%s", test.spec, test.loc, got, test.want)
+		}
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 10s",
+		"@every soon",
+		"@yearly",
+	} {
+		if _, err := ParseSchedule(spec, time.UTC); err == nil {
+			t.Errorf("ParseSchedule(%q) expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package fetch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jprobinson/newshound"
+)
+
+// properNounPOS holds the part of speech tags np_extractor uses for
+// proper nouns. Only these phrases are considered for entity extraction.
+var properNounPOS = map[string]bool{
+	"NNP":   true,
+	"NP-HL": true,
+}
+
+var (
+	// phrases we know the type of without any guessing
+	knownEntities = map[string]newshound.EntityType{
+		"white house":        newshound.EntityOrganization,
+		"pentagon":           newshound.EntityOrganization,
+		"kremlin":            newshound.EntityOrganization,
+		"fbi":                newshound.EntityOrganization,
+		"cia":                newshound.EntityOrganization,
+		"nsa":                newshound.EntityOrganization,
+		"nasa":               newshound.EntityOrganization,
+		"fed":                newshound.EntityOrganization,
+		"federal reserve":    newshound.EntityOrganization,
+		"sec":                newshound.EntityOrganization,
+		"fda":                newshound.EntityOrganization,
+		"cdc":                newshound.EntityOrganization,
+		"epa":                newshound.EntityOrganization,
+		"irs":                newshound.EntityOrganization,
+		"doj":                newshound.EntityOrganization,
+		"gop":                newshound.EntityOrganization,
+		"nato":               newshound.EntityOrganization,
+		"opec":               newshound.EntityOrganization,
+		"un":                 newshound.EntityOrganization,
+		"united nations":     newshound.EntityOrganization,
+		"eu":                 newshound.EntityOrganization,
+		"european union":     newshound.EntityOrganization,
+		"supreme court":      newshound.EntityOrganization,
+		"senate":             newshound.EntityOrganization,
+		"house":              newshound.EntityOrganization,
+		"nfl":                newshound.EntityOrganization,
+		"nba":                newshound.EntityOrganization,
+		"mlb":                newshound.EntityOrganization,
+		"nhl":                newshound.EntityOrganization,
+		"fifa":               newshound.EntityOrganization,
+		"isis":               newshound.EntityOrganization,
+		"taliban":            newshound.EntityOrganization,
+		"hamas":              newshound.EntityOrganization,
+		"hezbollah":          newshound.EntityOrganization,
+		"al qaeda":           newshound.EntityOrganization,
+		"apple":              newshound.EntityOrganization,
+		"google":             newshound.EntityOrganization,
+		"facebook":           newshound.EntityOrganization,
+		"amazon":             newshound.EntityOrganization,
+		"microsoft":          newshound.EntityOrganization,
+		"twitter":            newshound.EntityOrganization,
+		"tesla":              newshound.EntityOrganization,
+		"boeing":             newshound.EntityOrganization,
+		"super bowl":         newshound.EntityEvent,
+		"world cup":          newshound.EntityEvent,
+		"world series":       newshound.EntityEvent,
+		"stanley cup final":  newshound.EntityEvent,
+		"olympics":           newshound.EntityEvent,
+		"brexit":             newshound.EntityEvent,
+		"election day":       newshound.EntityEvent,
+		"state of the union": newshound.EntityEvent,
+	}
+
+	locations = map[string]struct{}{
+		// countries
+		"afghanistan": {}, "argentina": {}, "australia": {}, "austria": {}, "belgium": {},
+		"brazil": {}, "canada": {}, "chile": {}, "china": {}, "colombia": {}, "cuba": {},
+		"egypt": {}, "england": {}, "france": {}, "germany": {}, "greece": {}, "haiti": {},
+		"india": {}, "indonesia": {}, "iran": {}, "iraq": {}, "ireland": {}, "israel": {},
+		"italy": {}, "japan": {}, "jordan": {}, "kenya": {}, "lebanon": {}, "libya": {},
+		"mexico": {}, "nigeria": {}, "north korea": {}, "pakistan": {}, "philippines": {},
+		"poland": {}, "russia": {}, "saudi arabia": {}, "scotland": {}, "somalia": {},
+		"south africa": {}, "south korea": {}, "spain": {}, "sweden": {}, "syria": {},
+		"taiwan": {}, "turkey": {}, "ukraine": {}, "united kingdom": {}, "britain": {},
+		"united states": {}, "us": {}, "u.s": {}, "uk": {}, "venezuela": {}, "vietnam": {},
+		"yemen": {}, "gaza": {}, "west bank": {}, "crimea": {}, "puerto rico": {},
+		// us states
+		"alabama": {}, "alaska": {}, "arizona": {}, "arkansas": {}, "california": {},
+		"colorado": {}, "connecticut": {}, "delaware": {}, "florida": {}, "georgia": {},
+		"hawaii": {}, "idaho": {}, "illinois": {}, "indiana": {}, "iowa": {}, "kansas": {},
+		"kentucky": {}, "louisiana": {}, "maine": {}, "maryland": {}, "massachusetts": {},
+		"michigan": {}, "minnesota": {}, "mississippi": {}, "missouri": {}, "montana": {},
+		"nebraska": {}, "nevada": {}, "new hampshire": {}, "new jersey": {}, "new mexico": {},
+		"new york": {}, "north carolina": {}, "north dakota": {}, "ohio": {}, "oklahoma": {},
+		"oregon": {}, "pennsylvania": {}, "rhode island": {}, "south carolina": {},
+		"south dakota": {}, "tennessee": {}, "texas": {}, "utah": {}, "vermont": {},
+		"virginia": {}, "west virginia": {}, "wisconsin": {}, "wyoming": {},
+		// cities
+		"atlanta": {}, "baghdad": {}, "baltimore": {}, "beijing": {}, "berlin": {},
+		"boston": {}, "brussels": {}, "cairo": {}, "chicago": {}, "dallas": {}, "denver": {},
+		"detroit": {}, "hong kong": {}, "houston": {}, "istanbul": {}, "jerusalem": {},
+		"kabul": {}, "kiev": {}, "las vegas": {}, "london": {}, "los angeles": {},
+		"madrid": {}, "manhattan": {}, "miami": {}, "moscow": {}, "mumbai": {},
+		"new orleans": {}, "new york city": {}, "orlando": {}, "paris": {}, "philadelphia": {},
+		"phoenix": {}, "pyongyang": {}, "rome": {}, "san francisco": {}, "seattle": {},
+		"seoul": {}, "tehran": {}, "tokyo": {}, "toronto": {}, "washington dc": {},
+		"d.c": {},
+	}
+
+	personTitles = map[string]struct{}{
+		"president": {}, "vice president": {}, "sen": {}, "senator": {}, "rep": {},
+		"representative": {}, "gov": {}, "governor": {}, "mayor": {}, "mr": {}, "mrs": {},
+		"ms": {}, "dr": {}, "judge": {}, "justice": {}, "secretary": {}, "minister": {},
+		"prime minister": {}, "king": {}, "queen": {}, "prince": {}, "princess": {},
+		"pope": {}, "chancellor": {}, "gen": {}, "general": {}, "speaker": {}, "coach": {},
+		"ceo": {}, "chairman": {}, "chairwoman": {}, "attorney general": {}, "sheriff": {},
+		"leader": {}, "actor": {}, "actress": {}, "singer": {}, "rapper": {},
+	}
+
+	eventWords = map[string]struct{}{
+		"election": {}, "elections": {}, "primary": {}, "caucus": {}, "caucuses": {},
+		"debate": {}, "hurricane": {}, "storm": {}, "earthquake": {}, "tsunami": {},
+		"wildfire": {}, "summit": {}, "trial": {}, "war": {}, "attack": {}, "shooting": {},
+		"bombing": {}, "olympics": {}, "games": {}, "championship": {}, "cup": {},
+		"bowl": {}, "series": {}, "open": {}, "marathon": {}, "inauguration": {},
+		"impeachment": {}, "festival": {}, "awards": {}, "oscars": {}, "grammys": {},
+		"derby": {}, "conference": {}, "convention": {}, "shutdown": {}, "referendum": {},
+	}
+
+	orgWords = map[string]struct{}{
+		"inc": {}, "corp": {}, "corporation": {}, "co": {}, "company": {}, "llc": {},
+		"university": {}, "college": {}, "school": {}, "department": {}, "ministry": {},
+		"agency": {}, "party": {}, "committee": {}, "council": {}, "court": {}, "bank": {},
+		"fund": {}, "group": {}, "association": {}, "league": {}, "institute": {},
+		"foundation": {}, "board": {}, "commission": {}, "administration": {}, "airlines": {},
+		"airways": {}, "motors": {}, "technologies": {}, "holdings": {}, "partners": {},
+		"capital": {}, "securities": {}, "exchange": {}, "network": {}, "times": {},
+		"post": {}, "journal": {}, "news": {}, "army": {}, "navy": {}, "police": {},
+		"church": {}, "union": {}, "team": {}, "club": {}, "fc": {},
+	}
+
+	locationWords = map[string]struct{}{
+		"city": {}, "county": {}, "river": {}, "island": {}, "islands": {}, "mountain": {},
+		"mountains": {}, "valley": {}, "bay": {}, "beach": {}, "province": {}, "region": {},
+		"street": {}, "avenue": {}, "airport": {}, "square": {}, "park": {}, "lake": {},
+		"ocean": {}, "sea": {}, "coast": {}, "peninsula": {}, "strait": {}, "border": {},
+	}
+
+	locationPrepositions = map[string]struct{}{
+		"in": {}, "near": {}, "outside": {}, "across": {}, "to": {}, "from": {},
+	}
+)
+
+// extractEntities will classify any proper noun phrases found by np_extractor.
+// Phrases are expected to already be normalized and canonicalized.
+func extractEntities(phrases map[string]string, sentences []newshound.Sentence) []newshound.Entity {
+	var entities []newshound.Entity
+	for phrase, pos := range phrases {
+		if !properNounPOS[pos] {
+			continue
+		}
+		entities = append(entities, newshound.Entity{
+			Name: phrase,
+			Type: classifyEntity(phrase, precedingWords(phrase, sentences)),
+		})
+	}
+	sort.Sort(entitiesByName(entities))
+	return entities
+}
+
+// classifyEntity takes a guess at what type of thing the given phrase
+// is. The 'preceding' words are any words found just before the phrase
+// in the alert's sentences and are used for a little extra context.
+func classifyEntity(phrase string, preceding []string) newshound.EntityType {
+	phrase = strings.TrimSuffix(strings.TrimSuffix(phrase, "'s"), "’s")
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return newshound.EntityOther
+	}
+
+	if typ, ok := knownEntities[phrase]; ok {
+		return typ
+	}
+	if _, ok := locations[phrase]; ok {
+		return newshound.EntityLocation
+	}
+
+	for _, word := range words {
+		if _, ok := eventWords[word]; ok {
+			return newshound.EntityEvent
+		}
+	}
+	for _, word := range words {
+		if _, ok := orgWords[strings.TrimSuffix(word, ".")]; ok {
+			return newshound.EntityOrganization
+		}
+	}
+	if _, ok := locationWords[words[len(words)-1]]; ok {
+		return newshound.EntityLocation
+	}
+
+	// 'president obama', 'prime minister theresa may'
+	for i := len(words) - 1; i > 0; i-- {
+		if _, ok := personTitles[strings.Join(words[:i], " ")]; ok {
+			return newshound.EntityPerson
+		}
+	}
+	for _, word := range preceding {
+		if _, ok := personTitles[word]; ok {
+			return newshound.EntityPerson
+		}
+	}
+	for _, word := range preceding {
+		if _, ok := locationPrepositions[word]; ok && len(words) == 1 {
+			return newshound.EntityLocation
+		}
+	}
+
+	// most unclassified 2-3 word proper nouns are a first and last name
+	if (len(words) == 2 || len(words) == 3) && isName(words) {
+		return newshound.EntityPerson
+	}
+
+	return newshound.EntityOther
+}
+
+// isName checks that every word looks like it could be part of a
+// person's name (letters, periods, hyphens and apostrophes only).
+func isName(words []string) bool {
+	for _, word := range words {
+		for _, r := range word {
+			if !unicode.IsLetter(r) && r != '.' && r != '-' && r != '\'' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// precedingWords returns the word directly before each occurrence of
+// the phrase within the given sentences.
+func precedingWords(phrase string, sentences []newshound.Sentence) []string {
+	var preceding []string
+	for _, s := range sentences {
+		words := strings.Fields(strings.ToLower(s.Value))
+		plen := len(strings.Fields(phrase))
+		for i := 1; i+plen <= len(words); i++ {
+			candidate := strings.Trim(strings.Join(words[i:i+plen], " "), ",.;:\"“”'")
+			if candidate != phrase && strings.TrimSuffix(candidate, "'s") != phrase {
+				continue
+			}
+			preceding = append(preceding, strings.Trim(words[i-1], ",.;:\"“”"))
+		}
+	}
+	return preceding
+}
+
+// keyEntities returns the set of people and places mentioned in an alert.
+func keyEntities(entities []newshound.Entity) map[string]struct{} {
+	keys := map[string]struct{}{}
+	for _, e := range entities {
+		if e.Type == newshound.EntityPerson || e.Type == newshound.EntityLocation {
+			keys[e.Name] = struct{}{}
+		}
+	}
+	return keys
+}
+
+// sharesKeyEntity reports whether the given alert mentions any of the people or
+// places in keys. If either side has no people or places (like alerts parsed before
+// entity extraction existed), there is nothing to compare so we let it through.
+func sharesKeyEntity(keys map[string]struct{}, a newshound.NewsAlert) bool {
+	if len(keys) == 0 {
+		return true
+	}
+	others := keyEntities(a.Entities)
+	if len(others) == 0 {
+		return true
+	}
+	for other := range others {
+		for key := range keys {
+			if strings.EqualFold(key, other) || partialMatch(key, other) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// eventEntities returns any entities mentioned by at least 2 of the given alerts.
+func eventEntities(alerts []newshound.NewsAlert) []newshound.Entity {
+	counts := map[newshound.Entity]int{}
+	for _, a := range alerts {
+		for _, e := range a.Entities {
+			counts[e]++
+		}
+	}
+	var entities []newshound.Entity
+	for e, count := range counts {
+		if count >= 2 {
+			entities = append(entities, e)
+		}
+	}
+	sort.Sort(entitiesByName(entities))
+	return entities
+}
+
+type entitiesByName []newshound.Entity
+
+func (e entitiesByName) Len() int      { return len(e) }
+func (e entitiesByName) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e entitiesByName) Less(i, j int) bool {
+	if e[i].Name == e[j].Name {
+		return e[i].Type < e[j].Type
+	}
+	return e[i].Name < e[j].Name
+}
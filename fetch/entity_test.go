@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestClassifyEntity(t *testing.T) {
+	tests := []struct {
+		given     string
+		preceding []string
+		want      newshound.EntityType
+	}{
+		{"paris", nil, newshound.EntityLocation},
+		{"paris hilton", nil, newshound.EntityPerson},
+		{"president trump", nil, newshound.EntityPerson},
+		{"mattis", []string{"secretary"}, newshound.EntityPerson},
+		{"white house", nil, newshound.EntityOrganization},
+		{"justice department", nil, newshound.EntityOrganization},
+		{"goldman sachs group", nil, newshound.EntityOrganization},
+		{"hurricane harvey", nil, newshound.EntityEvent},
+		{"super bowl", nil, newshound.EntityEvent},
+		{"orange county", nil, newshound.EntityLocation},
+		{"aleppo", []string{"in"}, newshound.EntityLocation},
+		{"dow", nil, newshound.EntityOther},
+		{"boeing 737", nil, newshound.EntityOther},
+	}
+
+	for _, test := range tests {
+		if got := classifyEntity(test.given, test.preceding); got != test.want {
+			t.Errorf("classifyEntity(%q, %q) got:%s want:%s", test.given, test.preceding, got, test.want)
+		}
+	}
+}
+
+func TestPrecedingWords(t *testing.T) {
+	sentences := []newshound.Sentence{
+		{Value: "A gunman opened fire in Aleppo, officials said."},
+		{Value: "Secretary Mattis's trip to Aleppo was canceled."},
+	}
+
+	got := precedingWords("aleppo", sentences)
+	if len(got) != 2 || got[0] != "in" || got[1] != "to" {
+		t.Errorf("precedingWords(aleppo) got:%q want:[in to]", got)
+	}
+
+	got = precedingWords("mattis", sentences)
+	if len(got) != 1 || got[0] != "secretary" {
+		t.Errorf("precedingWords(mattis) got:%q want:[secretary]", got)
+	}
+}
+
+func TestSharesKeyEntity(t *testing.T) {
+	keys := keyEntities([]newshound.Entity{
+		{Name: "donald trump", Type: newshound.EntityPerson},
+		{Name: "white house", Type: newshound.EntityOrganization},
+	})
+
+	tests := []struct {
+		given []newshound.Entity
+		want  bool
+	}{
+		{nil, true},
+		{[]newshound.Entity{{Name: "trump", Type: newshound.EntityPerson}}, true},
+		{[]newshound.Entity{{Name: "paris", Type: newshound.EntityLocation}}, false},
+		{[]newshound.Entity{{Name: "fbi", Type: newshound.EntityOrganization}}, true},
+	}
+
+	for _, test := range tests {
+		a := newshound.NewsAlert{NewsAlertLite: newshound.NewsAlertLite{Entities: test.given}}
+		if got := sharesKeyEntity(keys, a); got != test.want {
+			t.Errorf("sharesKeyEntity(%v) got:%v want:%v", test.given, got, test.want)
+		}
+	}
+}
@@ -34,11 +34,11 @@ func minOccurances(alertCount int) int {
 	return int(math.Max(math.Ceil(float64(alertCount)*minOccurPerc), 2.0))
 }
 
-func EventRefresh(na *mgo.Collection, ne *mgo.Collection, eventTime time.Time, pub pubsub.Publisher) error {
+func EventRefresh(na, ne, sl *mgo.Collection, eventTime time.Time, pub pubsub.Publisher) error {
 	// find all alerts within a event timeframe of the given time and refresh the events
 	start := eventTime.Add(-eventTimeframe)
 	end := eventTime.Add(eventTimeframe)
-	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}}
+	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}, "revision_of": bson.M{"$exists": false}}
 	var eligible []newshound.NewsAlert
 	err := na.Find(query).All(&eligible)
 	if err != nil {
@@ -46,7 +46,7 @@ func EventRefresh(na *mgo.Collection, ne *mgo.Collection, eventTime time.Time, p
 	}
 
 	for _, alert := range eligible {
-		if err = UpdateEvents(na, ne, alert, pub); err != nil {
+		if err = UpdateEvents(na, ne, sl, alert, pub); err != nil {
 			return err
 		}
 	}
@@ -54,7 +54,7 @@ func EventRefresh(na *mgo.Collection, ne *mgo.Collection, eventTime time.Time, p
 	return nil
 }
 
-func UpdateEvents(na *mgo.Collection, ne *mgo.Collection, a newshound.NewsAlert, pub pubsub.Publisher) error {
+func UpdateEvents(na, ne, sl *mgo.Collection, a newshound.NewsAlert, pub pubsub.Publisher) error {
 
 	cluster, tags, err := findLikeAlertCluster(na, a)
 	if err != nil {
@@ -99,9 +99,13 @@ func UpdateEvents(na *mgo.Collection, ne *mgo.Collection, a newshound.NewsAlert,
 	if err != nil {
 		return err
 	}
+	// let the API know its cached events and storylines are stale
+	// once the event and everything linked to it are saved
+	defer bumpVersions(ne.Database, newshound.EventsVersion)
 
 	ctx := context.Background()
-	// emit event notification if new event
+	// emit event notifications for new and updated events. the key
+	// tells subscribers which one it is.
 	if pub != nil {
 		if newID {
 			var buff bytes.Buffer
@@ -109,7 +113,7 @@ func UpdateEvents(na *mgo.Collection, ne *mgo.Collection, a newshound.NewsAlert,
 			if err != nil {
 				log.Print("unable to gob event: ", err)
 			} else {
-				if err = pub.PublishRaw(ctx, "", buff.Bytes()); err != nil {
+				if err = pub.PublishRaw(ctx, newshound.NewsEventTopic, buff.Bytes()); err != nil {
 					log.Print("unable to publish event: ", err)
 				}
 			}
@@ -120,7 +124,7 @@ func UpdateEvents(na *mgo.Collection, ne *mgo.Collection, a newshound.NewsAlert,
 			if err != nil {
 				log.Print("unable to gob event: ", err)
 			} else {
-				if err = pub.PublishRaw(ctx, "", buff.Bytes()); err != nil {
+				if err = pub.PublishRaw(ctx, newshound.NewsEventUpdateTopic, buff.Bytes()); err != nil {
 					log.Print("unable to publish event update: ", err)
 				}
 			}
@@ -129,9 +133,19 @@ func UpdateEvents(na *mgo.Collection, ne *mgo.Collection, a newshound.NewsAlert,
 
 	// clean up stale events
 	if len(staleEventIDs) > 0 {
-		_, err = ne.RemoveAll(bson.M{"_id": bson.M{"$in": staleEventIDs}})
+		if _, err = ne.RemoveAll(bson.M{"_id": bson.M{"$in": staleEventIDs}}); err != nil {
+			return err
+		}
+		if err = removeStorylineEvents(ne, sl, staleEventIDs); err != nil {
+			return err
+		}
 	}
-	return err
+
+	// link the event to any related events from previous days
+	if err = UpdateStoryline(ne, sl, event); err != nil {
+		log.Print("unable to update storyline: ", err)
+	}
+	return nil
 }
 
 func hasMinSenders(alerts []newshound.NewsAlert) bool {
@@ -147,6 +161,9 @@ func NewNewsEvent(id bson.ObjectId, alerts []newshound.NewsAlert, eventTags []st
 	// sort by timestamp
 	sort.Sort(naByTimestamp(alerts))
 
+	// existing events may have been tagged before a synonym was added
+	eventTags = canonicalTags(eventTags)
+
 	// grab our start n end since we're sorted
 	start := alerts[0].Timestamp
 	end := alerts[len(alerts)-1].Timestamp
@@ -161,6 +178,7 @@ func NewNewsEvent(id bson.ObjectId, alerts []newshound.NewsAlert, eventTags []st
 		for _, s := range a.Sentences {
 			alertTagCount := 0
 			for _, phrase := range s.Phrases {
+				phrase = canonicalTag(phrase)
 				for _, tag := range eventTags {
 					// increment the score for any tag/phrase intersection
 					if strings.EqualFold(tag, phrase) {
@@ -190,14 +208,21 @@ func NewNewsEvent(id bson.ObjectId, alerts []newshound.NewsAlert, eventTags []st
 		eas = append(eas, ea)
 	}
 	sort.Strings(eventTags)
+	headline, summary := summarizeEvent(alerts, eventTags)
+	importance := eventImportance(alerts)
 	return newshound.NewsEvent{
 		ID:          id,
 		Tags:        eventTags,
+		Entities:    eventEntities(alerts),
 		EventStart:  start,
 		EventEnd:    end,
 		NewsAlerts:  eas,
 		TopSentence: topSentence,
 		TopSender:   topSender,
+		Headline:    headline,
+		Summary:     summary,
+		Importance:  importance,
+		Major:       importance >= MajorEventImportance,
 	}
 }
 
@@ -277,6 +302,12 @@ func findLikeAlertCluster(na *mgo.Collection, a newshound.NewsAlert) (alerts []b
 		return alerts, tags, err
 	}
 
+	// apply any synonyms added since the alerts were parsed
+	a.Tags = canonicalTags(a.Tags)
+	for i := range possible {
+		possible[i].Tags = canonicalTags(possible[i].Tags)
+	}
+
 	// build tag map around main alert's tags
 	tagCounts := buildTagCounts(a.Tags, possible)
 
@@ -298,8 +329,15 @@ func findLikeAlertCluster(na *mgo.Collection, a newshound.NewsAlert) (alerts []b
 	// make sure main alert goes the the same filtering
 	possible = append(possible, a)
 
+	// alerts about different people or places are not the same event
+	keys := keyEntities(a.Entities)
+
 	// filter out any alerts that do not have minLikeTags
 	for _, alert := range possible {
+		if !sharesKeyEntity(keys, alert) {
+			continue
+		}
+
 		likeTags := 0
 		tagScore := 0
 		for _, tag := range alert.Tags {
@@ -338,7 +376,8 @@ func findPossibleLikeAlerts(na *mgo.Collection, a newshound.NewsAlert) (possible
 	// find any alerts within a  timeframe
 	start := a.Timestamp.Add(-eventTimeframe)
 	end := a.Timestamp.Add(eventTimeframe)
-	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}, "_id": bson.M{"$ne": a.ID}, "tags": bson.M{"$in": a.Tags}}
+	query := bson.M{"timestamp": bson.M{"$gte": start, "$lte": end}, "_id": bson.M{"$ne": a.ID}, "tags": bson.M{"$in": a.Tags},
+		"revision_of": bson.M{"$exists": false}}
 	err = na.Find(query).All(&possible)
 	if err != nil {
 		return possible, err
@@ -22,12 +22,28 @@ import (
 // https://github.com/golang/go/issues/3575 :(
 var procs = runtime.NumCPU()
 
-func FetchMail(ctx context.Context, cfg *Config, sess *mgo.Session, apub, epub pubsub.MultiPublisher) {
+func FetchMail(ctx context.Context, cfg *Config, sess *mgo.Session, apub, epub pubsub.MultiPublisher, trends *TrendTracker) {
 	log.Print("getting mail")
 	start := time.Now()
 
 	// give it 1000 buffer so we can load whatever IMAP throws at us in memory
 	alerts := make(chan newshound.NewsAlert, 100)
+
+	s := sess.Copy()
+	defer s.Close()
+	db := newshoundDB(s)
+	na := newsAlerts(db)
+	ne := newsEvents(db)
+	sl := storylines(db)
+
+	// pick up any new tag synonyms before we parse
+	if err := LoadSynonyms(db); err != nil {
+		log.Print("unable to load tag synonyms: ", err)
+	}
+	if err := LoadAttendance(db); err != nil {
+		log.Print("unable to load sender attendance: ", err)
+	}
+
 	mail, err := eazye.GenerateUnread(cfg.Mailbox, cfg.MarkRead, false)
 	if err != nil {
 		log.Fatal("unable to get mail: ", err)
@@ -40,20 +56,29 @@ func FetchMail(ctx context.Context, cfg *Config, sess *mgo.Session, apub, epub p
 		go parseMessages(cfg.Mailbox.User, cfg.NPHost, mail, alerts, &parsers)
 	}
 
-	s := sess.Copy()
-	defer s.Close()
-	db := newshoundDB(s)
-	na := newsAlerts(db)
-	ne := newsEvents(db)
-
 	completeCount := make(chan int, 1)
-	go saveAndRefresh(na, ne, alerts, completeCount, apub, epub)
+	updates := &reportUpdates{}
+	go saveAndRefresh(na, ne, sl, alerts, completeCount, apub, epub, trends, updates)
 
 	// wait for the parsers to complete and then close the alerts channel
 	parsers.Wait()
 	close(alerts)
 	count := <-completeCount
 
+	if err := updateReports(s, updates); err != nil {
+		log.Print("unable to update reports: ", err)
+	}
+
+	if trends != nil {
+		if err := trends.Save(ctx, trendingTags(db), time.Now()); err != nil {
+			log.Print("unable to save trending tags: ", err)
+		}
+	}
+
+	if count > 0 {
+		bumpVersions(db, newshound.AlertsVersion, newshound.ReportsVersion)
+	}
+
 	log.Printf("fetched %d messages in %s", count, time.Since(start))
 }
 
@@ -78,8 +103,22 @@ func ReParse(cfg *Config, sess *mgo.Session) error {
 			return err
 		}
 	}
+	sl := storylinesTemp(db)
+	if err := sl.DropCollection(); err != nil {
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	if err := LoadSynonyms(db); err != nil {
+		return err
+	}
+	if err := LoadAttendance(db); err != nil {
+		return err
+	}
 
-	alerts := make(chan newshound.NewsAlert, 1000)
+	alerts := make(chan orderedAlert, 1000)
+	parsed := make(chan orderedAlert, 1000)
 	reAlerts := make(chan newshound.NewsAlert, 1000)
 	// grab all existing alerts from the main collection
 	go getAllAlerts(newsAlerts(db), alerts)
@@ -88,15 +127,17 @@ func ReParse(cfg *Config, sess *mgo.Session) error {
 	for i := 0; i < procs; i++ {
 		parsers.Add(1)
 		// multi goroutines so we can utilize the CPU while waiting for URLs
-		go reParseMessages(cfg.Mailbox.User, cfg.NPHost, alerts, reAlerts, &parsers)
+		go reParseMessages(cfg.Mailbox.User, cfg.NPHost, alerts, parsed, &parsers)
 	}
+	// the parsers finish out of order, so put the alerts back in order
+	go inOrder(parsed, reAlerts)
 
 	completeCount := make(chan int, 1)
-	go saveAndRefresh(na, ne, reAlerts, completeCount, nil, nil)
+	go saveAndRefresh(na, ne, sl, reAlerts, completeCount, nil, nil, nil, nil)
 
-	// wait for the parsers to complete and then close the alerts channel
+	// wait for the parsers to complete and then close the parsed channel
 	parsers.Wait()
-	close(reAlerts)
+	close(parsed)
 	count := <-completeCount
 
 	// replace the na/ne main colls with the new temps
@@ -106,6 +147,11 @@ func ReParse(cfg *Config, sess *mgo.Session) error {
 	if err := replaceColl(s, "news_events_temp", "news_events"); err != nil {
 		return err
 	}
+	if err := replaceColl(s, "storylines_temp", "storylines"); err != nil {
+		return err
+	}
+
+	bumpVersions(db, newshound.AlertsVersion, newshound.EventsVersion, newshound.ReportsVersion)
 
 	log.Printf("reparsed %d messages in %s", count, time.Since(start))
 	return nil
@@ -116,18 +162,28 @@ func isNotFound(err error) bool {
 }
 
 // saveAndRefresh will insert all alerts passed through the channel and kick off all event refreshes
-func saveAndRefresh(na *mgo.Collection, ne *mgo.Collection, alerts <-chan newshound.NewsAlert, completeCount chan<- int, apub, epub pubsub.Publisher) {
+func saveAndRefresh(na, ne, sl *mgo.Collection, alerts <-chan newshound.NewsAlert, completeCount chan<- int, apub, epub pubsub.Publisher, trends *TrendTracker, updates *reportUpdates) {
 	var count int
 	timeframes := map[int64]struct{}{}
 
 	var err error
 	for alert := range alerts {
 		count++
+		// link any resends to their original alert
+		if alert.RevisionOf, err = findRevisionOf(na, alert); err != nil {
+			log.Print("unable to check for alert revisions: ", err)
+		}
 		if err = na.Insert(alert); err != nil {
 			log.Print("unable to save alert to db: ", err)
 			continue
 		}
 
+		updates.add(alert)
+
+		if trends != nil && alert.RevisionOf == "" {
+			trends.Add(alert)
+		}
+
 		// emit alert notification
 		if apub != nil {
 			var buff bytes.Buffer
@@ -136,7 +192,7 @@ func saveAndRefresh(na *mgo.Collection, ne *mgo.Collection, alerts <-chan newsho
 				log.Print("unable to gob alert: ", err)
 			} else {
 				ctx := context.Background()
-				if err = apub.PublishRaw(ctx, "", buff.Bytes()); err != nil {
+				if err = apub.PublishRaw(ctx, newshound.NewsAlertTopic, buff.Bytes()); err != nil {
 					log.Print("unable to publish alert: ", err)
 				}
 			}
@@ -151,7 +207,7 @@ func saveAndRefresh(na *mgo.Collection, ne *mgo.Collection, alerts <-chan newsho
 		timeframes[aTime.Unix()] = struct{}{}
 		if len(timeframes) > 5 {
 			for tf, _ := range timeframes {
-				if err = EventRefresh(na, ne, time.Unix(tf, 0), epub); err != nil {
+				if err = EventRefresh(na, ne, sl, time.Unix(tf, 0), epub); err != nil {
 					log.Print("problems refreshing event: ", err)
 				}
 			}
@@ -160,31 +216,55 @@ func saveAndRefresh(na *mgo.Collection, ne *mgo.Collection, alerts <-chan newsho
 	}
 	// flush the timeframe buffer at the end
 	for tf, _ := range timeframes {
-		if err = EventRefresh(na, ne, time.Unix(tf, 0), epub); err != nil {
+		if err = EventRefresh(na, ne, sl, time.Unix(tf, 0), epub); err != nil {
 			log.Print("problems refreshing event: ", err)
 		}
 	}
 	completeCount <- count
 }
 
-func reParseMessages(user, host string, alerts <-chan newshound.NewsAlert, reAlerts chan<- newshound.NewsAlert, wg *sync.WaitGroup) {
+// orderedAlert is an alert along with its position in the order
+// it was read from the database.
+type orderedAlert struct {
+	order int
+	alert newshound.NewsAlert
+}
+
+func reParseMessages(user, host string, alerts <-chan orderedAlert, reAlerts chan<- orderedAlert, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	var (
-		na  newshound.NewsAlert
-		err error
-	)
-	for alert := range alerts {
-		if na, err = ReParseNewsAlert(alert, host, user); err != nil {
+	var err error
+	for oa := range alerts {
+		if oa.alert, err = ReParseNewsAlert(oa.alert, host, user); err != nil {
 			// panic so that we stop the reparse and dont lose any data.
 			// we're good to die at this point bc temp collections ftw!
 			log.Fatal("unable to reparse email: ", err)
 		}
 
-		reAlerts <- na
+		reAlerts <- oa
 	}
 }
 
+// inOrder passes the alerts along in the order they were read, holding any that
+// arrive early until the ones before them show up. out is closed once in is.
+func inOrder(in <-chan orderedAlert, out chan<- newshound.NewsAlert) {
+	early := map[int]newshound.NewsAlert{}
+	next := 0
+	for oa := range in {
+		early[oa.order] = oa.alert
+		for {
+			alert, ok := early[next]
+			if !ok {
+				break
+			}
+			delete(early, next)
+			out <- alert
+			next++
+		}
+	}
+	close(out)
+}
+
 func parseMessages(user, host string, mail chan eazye.Response, alerts chan<- newshound.NewsAlert, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -212,11 +292,13 @@ func parseMessages(user, host string, mail chan eazye.Response, alerts chan<- ne
 	}
 }
 
-func getAllAlerts(na *mgo.Collection, alerts chan<- newshound.NewsAlert) {
-	i := na.Find(nil).Batch(1000).Iter()
+func getAllAlerts(na *mgo.Collection, alerts chan<- orderedAlert) {
+	// in order so resends are saved after their originals. inOrder
+	// restores the order after the alerts are reparsed.
+	i := na.Find(nil).Sort("timestamp").Batch(1000).Iter()
 	var alert newshound.NewsAlert
-	for i.Next(&alert) {
-		alerts <- alert
+	for order := 0; i.Next(&alert); order++ {
+		alerts <- orderedAlert{order: order, alert: alert}
 	}
 
 	if err := i.Close(); err != nil {
@@ -255,3 +337,23 @@ func newsAlertsTemp(db *mgo.Database) *mgo.Collection {
 func newsEventsTemp(db *mgo.Database) *mgo.Collection {
 	return db.C("news_events_temp")
 }
+
+func storylines(db *mgo.Database) *mgo.Collection {
+	return db.C("storylines")
+}
+
+func storylinesTemp(db *mgo.Database) *mgo.Collection {
+	return db.C("storylines_temp")
+}
+
+func trendingTags(db *mgo.Database) *mgo.Collection {
+	return db.C("trending")
+}
+
+func jobLocks(db *mgo.Database) *mgo.Collection {
+	return db.C("job_locks")
+}
+
+func jobRuns(db *mgo.Database) *mgo.Collection {
+	return db.C("job_runs")
+}
@@ -4,6 +4,8 @@ import (
 	"net/mail"
 	"reflect"
 	"testing"
+
+	"github.com/jprobinson/newshound"
 )
 
 func TestReplaceHREFs(t *testing.T) {
@@ -173,3 +175,23 @@ func TestFindSender(t *testing.T) {
 		}
 	}
 }
+
+func TestInOrder(t *testing.T) {
+	in := make(chan orderedAlert, 5)
+	out := make(chan newshound.NewsAlert, 5)
+	for _, order := range []int{2, 0, 4, 1, 3} {
+		var alert newshound.NewsAlert
+		alert.Subject = string(rune('a' + order))
+		in <- orderedAlert{order: order, alert: alert}
+	}
+	close(in)
+	inOrder(in, out)
+
+	var got []string
+	for alert := range out {
+		got = append(got, alert.Subject)
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inOrder() got:%v want:%v", got, want)
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -14,15 +16,41 @@ import (
 	"github.com/NYTimes/gizmo/pubsub"
 	"github.com/NYTimes/gizmo/pubsub/gcp"
 	"github.com/gorilla/mux"
+	"github.com/jprobinson/newshound/api"
 	"github.com/jprobinson/newshound/fetch"
+	"github.com/jprobinson/newshound/report"
+	"github.com/jprobinson/newshound/search"
+	"github.com/jprobinson/newshound/stream"
 )
 
 func main() {
 	reparse := flag.Bool("r", false, "reparse all alerts and events")
+	recanon := flag.Bool("c", false, "re-canonicalize the tags of all alerts and events")
+	rebuild := flag.Bool("m", false, "rebuild all reports from scratch")
 	flag.Parse()
 
 	ctx := context.Background()
 	config := fetch.NewConfig()
+	tieThreshold := report.DefaultTieThreshold
+	if config.ScoopTieThreshold > 0 {
+		tieThreshold = config.ScoopTieThreshold
+	}
+	if config.RevisionWindow > 0 {
+		fetch.RevisionWindow = config.RevisionWindow
+	}
+	if config.RevisionSimilarity > 0 {
+		fetch.RevisionSimilarity = config.RevisionSimilarity
+	}
+	if config.Timezone != "" {
+		loc, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			log.Fatal("unable to load timezone: ", err)
+		}
+		report.Location = loc
+	}
+	if config.ReportSchedule != "" {
+		fetch.ReportSchedule = config.ReportSchedule
+	}
 
 	observe.RegisterAndObserveGCP(func(err error) {
 		log.Printf("observe error: %s", err)
@@ -39,12 +67,29 @@ func main() {
 		log.Fatal("unable to init events publisher: ", err)
 	}
 
+	var tpub pubsub.Publisher
+	if config.TrendingTopic != "" {
+		tpub, err = gcp.NewPublisher(ctx, gcp.Config{Topic: config.TrendingTopic, ProjectID: proj})
+		if err != nil {
+			log.Fatal("unable to init trending publisher: ", err)
+		}
+	}
+
+	// tee new alerts and events to any streaming clients
+	hub := stream.NewHub()
+	apub, epub = hub.Publisher(apub), hub.Publisher(epub)
+
 	sess, err := config.MgoSession()
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer sess.Close()
 
+	// searches in the API rely on the text indices
+	if err := search.EnsureIndices(sess); err != nil {
+		log.Fatal("unable to ensure search indices: ", err)
+	}
+
 	if *reparse {
 		if err := fetch.ReParse(config, sess); err != nil {
 			log.Fatal(err)
@@ -52,14 +97,51 @@ func main() {
 		return
 	}
 
+	sched, err := fetch.NewReportScheduler(sess, tieThreshold)
+	if err != nil {
+		log.Fatal("unable to init report scheduler: ", err)
+	}
+
+	if *rebuild {
+		if _, err := sched.Run(ctx, fetch.RebuildReportsJob); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *recanon {
+		if err := fetch.ReCanonicalize(sess); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// the weekly and hourly reports are bucketed in the TIMEZONE
+	if changed, err := fetch.ReportsLocationChanged(sess); err != nil {
+		log.Print("unable to check the reports' time zone: ", err)
+	} else if changed {
+		log.Printf("rebuilding reports in %s", report.Location)
+		if _, err := sched.Run(ctx, fetch.RebuildReportsJob); err != nil {
+			log.Print("unable to rebuild reports: ", err)
+		}
+	}
+
+	sched.Start(ctx)
+
 	go func() {
 		mv := mux.NewRouter()
 		mv.HandleFunc("/mapreduce", func(w http.ResponseWriter, r *http.Request) {
-			err := fetch.MapReduce(sess)
-			if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			run, err := sched.Run(r.Context(), fetch.RebuildReportsJob)
+			switch {
+			case err == fetch.ErrJobRunning:
+				http.Error(w, "reports are already being rebuilt", http.StatusConflict)
+				return
+			case err != nil:
 				log.Print("problems performing mapreduce: ", err)
+				w.WriteHeader(http.StatusInternalServerError)
 			}
-			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(run)
 		})
 		ok := func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -75,12 +157,31 @@ func main() {
 		http.ListenAndServe(":"+port, mv)
 	}()
 
-	fetchMail(ctx, config, sess, apub, epub)
+	if wsPort := config.WSPort; wsPort > 0 {
+		// streaming clients need API keys from the same collection as the API's
+		authorize, err := api.Authorizer(config.StreamAuth, sess)
+		if err != nil {
+			log.Fatal("unable to init stream authorizer: ", err)
+		}
+		go func() {
+			sv := mux.NewRouter()
+			sv.Handle("/svc/newshound-api/v1/stream", authorize(stream.Handler(hub)))
+			log.Printf("streaming on %d", wsPort)
+			log.Print(http.ListenAndServe(fmt.Sprintf(":%d", wsPort), sv))
+		}()
+	}
+
+	trends := fetch.NewTrendTracker(tpub)
+	if err := trends.Seed(sess, time.Now()); err != nil {
+		log.Print("unable to seed trending tags: ", err)
+	}
+
+	fetchMail(ctx, config, sess, apub, epub, trends)
 }
 
-func fetchMail(ctx context.Context, config *fetch.Config, sess *mgo.Session, apub, epub pubsub.MultiPublisher) {
+func fetchMail(ctx context.Context, config *fetch.Config, sess *mgo.Session, apub, epub pubsub.MultiPublisher, trends *fetch.TrendTracker) {
 	for {
-		fetch.FetchMail(ctx, config, sess, apub, epub)
+		fetch.FetchMail(ctx, config, sess, apub, epub, trends)
 		time.Sleep(120 * time.Second)
 	}
 }
@@ -0,0 +1,104 @@
+package fetch
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// MajorEventImportance is the importance score at which
+	// an event is flagged as a major story.
+	MajorEventImportance = 60.0
+
+	// the number of senders, alerts in the velocityWindow and event
+	// length at which each part of the importance score maxes out.
+	fullSenders       = 8.0
+	fullVelocity      = 6.0
+	fullDuration      = 1 * time.Hour
+	velocityWindow    = 30 * time.Minute
+	unknownAttendance = 50.0
+
+	sendersWeight    = 0.4
+	velocityWeight   = 0.25
+	attendanceWeight = 0.2
+	durationWeight   = 0.15
+)
+
+// attendance holds each sender's percentage of events attended
+// over the past 12 months. It is loaded before any mail is parsed.
+var (
+	attendance   = map[string]float64{}
+	attendanceMu sync.RWMutex
+)
+
+// LoadAttendance will refresh the senders' historical event attendance from the
+// latest 'sender_event_attendance' report.
+func LoadAttendance(db *mgo.Database) error {
+	var results []struct {
+		ID struct {
+			Sender string `bson:"sender"`
+		} `bson:"_id"`
+		Value struct {
+			Attendance float64 `bson:"attendance"`
+		} `bson:"value"`
+	}
+	err := db.C("sender_event_attendance").Find(bson.M{"_id.timeframe": TwelveMonths}).All(&results)
+	if err != nil {
+		return err
+	}
+	att := make(map[string]float64, len(results))
+	for _, result := range results {
+		att[result.ID.Sender] = result.Value.Attendance
+	}
+
+	attendanceMu.Lock()
+	attendance = att
+	attendanceMu.Unlock()
+	return nil
+}
+
+// eventImportance scores an event from 0 to 100 based on how many senders reported
+// on it, how quickly the alerts came in, how unusual it is for those senders to
+// report on events and how long the event lasted. Alerts are expected to be sorted by time.
+func eventImportance(alerts []newshound.NewsAlert) float64 {
+	if len(alerts) == 0 {
+		return 0
+	}
+	start := alerts[0].Timestamp
+	end := alerts[len(alerts)-1].Timestamp
+
+	senders := map[string]struct{}{}
+	fast := 0
+	for _, a := range alerts {
+		senders[a.Sender] = struct{}{}
+		if a.Timestamp.Sub(start) <= velocityWindow {
+			fast++
+		}
+	}
+
+	// senders that rarely report on events joining in
+	// is a good sign something big is happening.
+	attendanceMu.RLock()
+	var rarity float64
+	for sender := range senders {
+		att, ok := attendance[sender]
+		if !ok {
+			att = unknownAttendance
+		}
+		rarity += 1 - math.Min(att, 100)/100
+	}
+	attendanceMu.RUnlock()
+	rarity /= float64(len(senders))
+
+	score := sendersWeight*math.Min(float64(len(senders))/fullSenders, 1) +
+		velocityWeight*math.Min(float64(fast)/fullVelocity, 1) +
+		attendanceWeight*rarity +
+		durationWeight*math.Min(float64(end.Sub(start))/float64(fullDuration), 1)
+
+	return math.Round(score*1000) / 10
+}
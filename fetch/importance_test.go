@@ -0,0 +1,43 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestEventImportance(t *testing.T) {
+	attendance = map[string]float64{"CNN": 90, "NBC": 80, "FT": 10}
+	defer func() { attendance = map[string]float64{} }()
+
+	start := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	alert := func(sender string, minutes int) newshound.NewsAlert {
+		return newshound.NewsAlert{NewsAlertLite: newshound.NewsAlertLite{
+			Sender:    sender,
+			Timestamp: start.Add(time.Duration(minutes) * time.Minute),
+		}}
+	}
+
+	small := eventImportance([]newshound.NewsAlert{
+		alert("CNN", 0), alert("NBC", 20), alert("CNN", 40),
+	})
+	big := eventImportance([]newshound.NewsAlert{
+		alert("CNN", 0), alert("NBC", 1), alert("FT", 2), alert("ABC", 3),
+		alert("CBS", 4), alert("NPR", 5), alert("BBC", 6), alert("Yahoo", 50),
+		alert("Politico", 70),
+	})
+
+	if small >= big {
+		t.Errorf("eventImportance() small event scored %v, big event scored %v", small, big)
+	}
+	if big < MajorEventImportance {
+		t.Errorf("eventImportance() big event scored %v, want >= %v", big, MajorEventImportance)
+	}
+	if small >= MajorEventImportance {
+		t.Errorf("eventImportance() small event scored %v, want < %v", small, MajorEventImportance)
+	}
+	if got := eventImportance(nil); got != 0 {
+		t.Errorf("eventImportance(nil) got:%v want:0", got)
+	}
+}
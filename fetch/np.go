@@ -20,7 +20,7 @@ type npResp struct {
 	TopSentence string               `json:"top_sentence"`
 }
 
-func callNP(host string, body []byte) (tags []string, sentences []newshound.Sentence, topSentence string, err error) {
+func callNP(host string, body []byte) (tags []string, entities []newshound.Entity, sentences []newshound.Sentence, topSentence string, err error) {
 
 	var resp *http.Response
 	resp, err = http.Post(host, "application/json", bytes.NewReader(body))
@@ -38,16 +38,27 @@ func callNP(host string, body []byte) (tags []string, sentences []newshound.Sent
 
 	// normalize the results
 	nrmlzr := normalizer()
-	for tag, _ := range npR.NounPhrases {
-		tags = append(tags, normalize(nrmlzr, tag))
+	phrases := map[string]string{}
+	for tag, pos := range npR.NounPhrases {
+		tag = canonicalTag(normalize(nrmlzr, tag))
+		// multiple variants may share a canonical form
+		if existing, dupe := phrases[tag]; dupe {
+			if !properNounPOS[existing] {
+				phrases[tag] = pos
+			}
+			continue
+		}
+		tags = append(tags, tag)
+		phrases[tag] = pos
 	}
 	for _, s := range npR.Sentences {
 		for i, p := range s.Phrases {
-			s.Phrases[i] = normalize(nrmlzr, p)
+			s.Phrases[i] = canonicalTag(normalize(nrmlzr, p))
 		}
 		sentences = append(sentences, s)
 	}
 	topSentence = npR.TopSentence
+	entities = extractEntities(phrases, sentences)
 	return
 }
 
@@ -0,0 +1,100 @@
+package fetch
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// reportUpdates collects what was saved during a fetch so the reports
+// can be brought up to date once it's done.
+type reportUpdates struct {
+	weeks  []time.Time
+	alerts []newshound.NewsAlertLite
+}
+
+// add will record the weeks the alert and any events refreshed
+// because of it may fall in.
+func (r *reportUpdates) add(alert newshound.NewsAlert) {
+	if r == nil {
+		return
+	}
+	// events can reach back a couple timeframes from any alert in them
+	r.weeks = append(r.weeks, alert.Timestamp, alert.Timestamp.Add(-2*eventTimeframe))
+	if alert.RevisionOf == "" {
+		r.alerts = append(r.alerts, alert.NewsAlertLite)
+	}
+}
+
+// updateReports will incrementally update the reports for the weeks touched by the
+// given updates along with the per sender averages of the timeframes those weeks
+// fall in. Use MapReduce to rebuild all reports from scratch.
+func updateReports(sess *mgo.Session, updates *reportUpdates) error {
+	if updates == nil {
+		return nil
+	}
+	store := &mongoStore{sess: sess}
+	err := withJobLock(store, RebuildReportsJob, func() error {
+		return report.Update(store, updates.weeks, updates.alerts, report.Timeframes(time.Now()))
+	})
+	if err == ErrJobRunning {
+		// the rebuild will pick up whatever was fetched
+		log.Print("skipping report update, the reports are being rebuilt")
+		return nil
+	}
+	return err
+}
+
+// withJobLock runs fn while holding the job's lock so it can't write the same
+// collections as the job. ErrJobRunning is returned without calling fn if the
+// job is running.
+func withJobLock(store JobStore, job string, fn func() error) error {
+	owner := lockOwner()
+	locked, err := store.Lock(job, owner, jobLockTTL)
+	if err != nil {
+		return fmt.Errorf("unable to lock job: %s", err)
+	}
+	if !locked {
+		return ErrJobRunning
+	}
+	defer func() {
+		if err := store.Unlock(job, owner); err != nil {
+			log.Printf("unable to unlock job %s: %s", job, err)
+		}
+	}()
+	return fn()
+}
+
+// ReportsLocationChanged reports whether the stored reports were last rebuilt
+// in a time zone other than report.Location. Their weeks and hours need to be
+// rebuilt with MapReduce if so.
+func ReportsLocationChanged(sess *mgo.Session) (bool, error) {
+	var setting struct {
+		Value string `bson:"value"`
+	}
+	err := reportSettings(newshoundDB(sess)).FindId("location").One(&setting)
+	if err == mgo.ErrNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return setting.Value != report.Location.String(), nil
+}
+
+// saveReportsLocation records report.Location as the time zone the stored
+// reports were built in.
+func saveReportsLocation(sess *mgo.Session) error {
+	_, err := reportSettings(newshoundDB(sess)).UpsertId("location",
+		bson.M{"$set": bson.M{"value": report.Location.String()}})
+	return err
+}
+
+func reportSettings(db *mgo.Database) *mgo.Collection {
+	return db.C("report_settings")
+}
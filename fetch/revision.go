@@ -0,0 +1,85 @@
+package fetch
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// RevisionWindow is how far back we look for an alert from the same sender
+	// that a new alert may be a resend of.
+	RevisionWindow = 3 * time.Hour
+
+	// RevisionSimilarity is the minimum text similarity (0-1) for an alert to
+	// be considered a resend of an earlier one.
+	RevisionSimilarity = 0.8
+)
+
+// senders prefix resent alerts with things like "UPDATE:" or "CORRECTION - "
+var revisionPrefix = regexp.MustCompile(`(?i)^\s*((breaking|updated?|correct(ed|ion)|clarification|developing|new|corrects?)(\s+news)?\s*[:\-–—]+\s*)+`)
+
+// findRevisionOf will look for an earlier alert from the same sender within the
+// RevisionWindow that is nearly identical to the given alert. If one is found,
+// the ID of the original alert in the revision chain is returned.
+func findRevisionOf(na *mgo.Collection, a newshound.NewsAlert) (bson.ObjectId, error) {
+	query := bson.M{
+		"sender":    a.Sender,
+		"timestamp": bson.M{"$gte": a.Timestamp.Add(-RevisionWindow), "$lte": a.Timestamp},
+		"_id":       bson.M{"$ne": a.ID},
+	}
+	var possible []newshound.NewsAlert
+	err := na.Find(query).
+		Select(bson.M{"subject": 1, "top_sentence": 1, "sentences": 1, "revision_of": 1, "timestamp": 1}).
+		All(&possible)
+	if err != nil {
+		return "", err
+	}
+
+	orig, ok := mostSimilarAlert(a, possible)
+	if !ok {
+		return "", nil
+	}
+	if orig.RevisionOf != "" {
+		return orig.RevisionOf, nil
+	}
+	return orig.ID, nil
+}
+
+// mostSimilarAlert returns the alert that is most similar to 'a', if any pass the
+// RevisionSimilarity threshold.
+func mostSimilarAlert(a newshound.NewsAlert, possible []newshound.NewsAlert) (newshound.NewsAlert, bool) {
+	words := wordSet(revisionText(a))
+	var (
+		best      newshound.NewsAlert
+		bestScore float64
+	)
+	for _, p := range possible {
+		// only alerts sent before this one can be the original
+		if p.Timestamp.After(a.Timestamp) {
+			continue
+		}
+		score := jaccard(words, wordSet(revisionText(p)))
+		if score >= RevisionSimilarity && score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// revisionText is the alert's subject and news content without any
+// resend prefixes.
+func revisionText(a newshound.NewsAlert) string {
+	parts := []string{revisionPrefix.ReplaceAllString(a.Subject, "")}
+	if len(a.Sentences) == 0 {
+		parts = append(parts, a.TopSentence)
+	}
+	for _, s := range a.Sentences {
+		parts = append(parts, revisionPrefix.ReplaceAllString(s.Value, ""))
+	}
+	return strings.Join(parts, " ")
+}
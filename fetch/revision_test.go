@@ -0,0 +1,61 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestRevisionText(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{"UPDATE: Senate passes bill", "Senate passes bill "},
+		{"Correction - Senate passes bill", "Senate passes bill "},
+		{"BREAKING NEWS: UPDATED: Senate passes bill", "Senate passes bill "},
+		{"Senate passes bill", "Senate passes bill "},
+		{"New York mayor resigns", "New York mayor resigns "},
+	}
+
+	for _, test := range tests {
+		a := newshound.NewsAlert{NewsAlertLite: newshound.NewsAlertLite{Subject: test.subject}}
+		if got := revisionText(a); got != test.want {
+			t.Errorf("revisionText(%q) got:%q want:%q", test.subject, got, test.want)
+		}
+	}
+}
+
+func TestMostSimilarAlert(t *testing.T) {
+	start := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	alert := func(minutes int, subject, body string) newshound.NewsAlert {
+		return newshound.NewsAlert{
+			NewsAlertLite: newshound.NewsAlertLite{
+				ID:        bson.NewObjectId(),
+				Subject:   subject,
+				Timestamp: start.Add(time.Duration(minutes) * time.Minute),
+			},
+			Sentences: []newshound.Sentence{{Value: body}},
+		}
+	}
+
+	orig := alert(0, "Breaking News", "The Senate passed the tax bill on Tuesday by a vote of 51 to 49.")
+	other := alert(5, "Breaking News", "A powerful earthquake struck off the coast of Japan on Tuesday.")
+	later := alert(30, "Breaking News", "The Senate passed the tax bill on Tuesday by a vote of 51 to 49.")
+
+	resend := alert(10, "UPDATE: Breaking News", "The Senate passsed the tax bill on Tuesday by a vote of 51 to 49.")
+	got, ok := mostSimilarAlert(resend, []newshound.NewsAlert{orig, other, later})
+	if !ok {
+		t.Fatal("mostSimilarAlert() found no original for a resend")
+	}
+	if got.ID != orig.ID {
+		t.Errorf("mostSimilarAlert() got alert %s, want %s", got.ID.Hex(), orig.ID.Hex())
+	}
+
+	fresh := alert(10, "Breaking News", "The House will vote on the tax bill next week, leaders said.")
+	if got, ok := mostSimilarAlert(fresh, []newshound.NewsAlert{orig, other}); ok {
+		t.Errorf("mostSimilarAlert() matched a new alert to %#v", got)
+	}
+}
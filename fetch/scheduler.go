@@ -0,0 +1,187 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ReportSchedule is when the reports are rebuilt from scratch.
+var ReportSchedule = "30 4 * * *"
+
+// jobLockTTL is how long a job's lock lasts without being renewed. Running
+// jobs renew their lock well before it expires.
+var jobLockTTL = 10 * time.Minute
+
+// ErrJobRunning is returned when a job is already running somewhere else.
+var ErrJobRunning = errors.New("job is already running")
+
+// JobStore holds the locks that keep jobs from overlapping and the
+// history of every run.
+type JobStore interface {
+	// Lock will take the job's lock for owner if it is free or expired and
+	// return true if it was acquired.
+	Lock(job, owner string, ttl time.Duration) (bool, error)
+	// RenewLock extends the owner's lock on the job.
+	RenewLock(job, owner string, ttl time.Duration) error
+	// Unlock releases the owner's lock on the job.
+	Unlock(job, owner string) error
+	// SaveRun records a finished run.
+	SaveRun(run newshound.JobRun) error
+}
+
+// Job is a task that runs on a schedule.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs on their schedules, making sure only one instance
+// runs a job at a time.
+type Scheduler struct {
+	store JobStore
+	owner string
+	jobs  map[string]Job
+}
+
+// NewScheduler creates a Scheduler for the jobs. Nothing runs until Start.
+func NewScheduler(store JobStore, jobs ...Job) *Scheduler {
+	s := &Scheduler{
+		store: store,
+		owner: lockOwner(),
+		jobs:  make(map[string]Job, len(jobs)),
+	}
+	for _, job := range jobs {
+		s.jobs[job.Name] = job
+	}
+	return s
+}
+
+// lockOwner returns a unique owner for job locks taken by this process.
+func lockOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), bson.NewObjectId().Hex())
+}
+
+// Start runs each job on its schedule until the context is canceled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		next := job.Schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("job %s will never run again", job.Name)
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		run, err := s.run(ctx, job, "schedule")
+		switch {
+		case err == ErrJobRunning:
+			log.Printf("skipping job %s, it is already running", job.Name)
+		case err != nil:
+			log.Printf("job %s failed: %s", job.Name, err)
+		default:
+			log.Printf("job %s complete in %.1fs", job.Name, run.Duration)
+		}
+	}
+}
+
+// Run will run the named job immediately. ErrJobRunning is returned if it's
+// already running. The run is returned along with its error.
+func (s *Scheduler) Run(ctx context.Context, name string) (newshound.JobRun, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return newshound.JobRun{}, fmt.Errorf("unknown job: %q", name)
+	}
+	return s.run(ctx, job, "manual")
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job, trigger string) (newshound.JobRun, error) {
+	run := newshound.JobRun{
+		ID:      bson.NewObjectId(),
+		Job:     job.Name,
+		Owner:   s.owner,
+		Trigger: trigger,
+	}
+	locked, err := s.store.Lock(job.Name, s.owner, jobLockTTL)
+	if err != nil {
+		return run, fmt.Errorf("unable to lock job: %s", err)
+	}
+	if !locked {
+		return run, ErrJobRunning
+	}
+	defer func() {
+		if err := s.store.Unlock(job.Name, s.owner); err != nil {
+			log.Printf("unable to unlock job %s: %s", job.Name, err)
+		}
+	}()
+
+	// keep the lock for as long as the job runs and stop the job if it's lost
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(jobLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.store.RenewLock(job.Name, s.owner, jobLockTTL); err != nil {
+					log.Printf("unable to renew lock on job %s, canceling it: %s", job.Name, err)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	run.Start = time.Now()
+	err = job.Run(ctx)
+	run.End = time.Now()
+	close(done)
+	wg.Wait()
+	if err == nil && ctx.Err() != nil {
+		err = fmt.Errorf("job canceled: %s", ctx.Err())
+	}
+
+	run.Duration = run.End.Sub(run.Start).Seconds()
+	run.Succeeded = err == nil
+	if err != nil {
+		run.Error = err.Error()
+		if errs, ok := err.(report.Errors); ok {
+			run.ReportErrors = make(map[string]string, len(errs))
+			for name, rerr := range errs {
+				run.ReportErrors[name] = rerr.Error()
+			}
+		}
+	}
+	if serr := s.store.SaveRun(run); serr != nil {
+		log.Printf("unable to save run of job %s: %s", job.Name, serr)
+	}
+	return run, err
+}
@@ -0,0 +1,166 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+)
+
+// testJobStore is an in-memory JobStore.
+type testJobStore struct {
+	locks    map[string]newshound.JobLock
+	runs     []newshound.JobRun
+	renewErr error
+}
+
+func (s *testJobStore) Lock(job, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	if lock, ok := s.locks[job]; ok && lock.Expires.After(now) {
+		return false, nil
+	}
+	s.locks[job] = newshound.JobLock{Job: job, Owner: owner, Acquired: now, Expires: now.Add(ttl)}
+	return true, nil
+}
+
+func (s *testJobStore) RenewLock(job, owner string, ttl time.Duration) error {
+	return s.renewErr
+}
+
+func (s *testJobStore) Unlock(job, owner string) error {
+	if s.locks[job].Owner == owner {
+		delete(s.locks, job)
+	}
+	return nil
+}
+
+func (s *testJobStore) SaveRun(run newshound.JobRun) error {
+	s.runs = append(s.runs, run)
+	return nil
+}
+
+func TestSchedulerRun(t *testing.T) {
+	store := &testJobStore{locks: map[string]newshound.JobLock{}}
+	failure := errors.New("disk full")
+
+	var (
+		jobErr error
+		held   bool
+	)
+	sched := NewScheduler(store, Job{
+		Name:     "test",
+		Schedule: every(time.Hour),
+		Run: func(ctx context.Context) error {
+			_, held = store.locks["test"]
+			return jobErr
+		},
+	})
+
+	run, err := sched.Run(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Run() returned an error: %s", err)
+	}
+	if !held {
+		t.Error("Run() did not hold the lock while the job ran")
+	}
+	if _, ok := store.locks["test"]; ok {
+		t.Error("Run() did not release the lock")
+	}
+	if !run.Succeeded || run.Trigger != "manual" || run.End.Before(run.Start) {
+		t.Errorf("Run() got run %#v", run)
+	}
+
+	jobErr = report.Errors{report.EventsPerWeekReport: failure}
+	run, err = sched.Run(context.Background(), "test")
+	if _, ok := err.(report.Errors); !ok {
+		t.Errorf("Run() got error %#v, want report.Errors", err)
+	}
+	if run.Succeeded || run.ReportErrors[report.EventsPerWeekReport] != failure.Error() {
+		t.Errorf("Run() got run %#v, want a failure with report errors", run)
+	}
+	if len(store.runs) != 2 {
+		t.Errorf("Run() saved %d runs, want 2", len(store.runs))
+	}
+
+	jobErr = nil
+	// someone else is running it
+	store.locks["test"] = newshound.JobLock{Job: "test", Owner: "other", Expires: time.Now().Add(time.Minute)}
+	if _, err = sched.Run(context.Background(), "test"); err != ErrJobRunning {
+		t.Errorf("Run() with the lock held got error %v, want ErrJobRunning", err)
+	}
+	if store.locks["test"].Owner != "other" {
+		t.Error("Run() released a lock it did not own")
+	}
+
+	// expired locks can be taken over
+	store.locks["test"] = newshound.JobLock{Job: "test", Owner: "other", Expires: time.Now().Add(-time.Minute)}
+	if _, err = sched.Run(context.Background(), "test"); err != nil {
+		t.Errorf("Run() with an expired lock returned an error: %s", err)
+	}
+
+	if _, err = sched.Run(context.Background(), "nope"); err == nil {
+		t.Error("Run() of an unknown job expected an error")
+	}
+}
+
+func TestSchedulerRunLostLock(t *testing.T) {
+	defer func(ttl time.Duration) { jobLockTTL = ttl }(jobLockTTL)
+	jobLockTTL = 30 * time.Millisecond
+
+	store := &testJobStore{locks: map[string]newshound.JobLock{}, renewErr: errors.New("not found")}
+	sched := NewScheduler(store, Job{
+		Name:     "test",
+		Schedule: every(time.Hour),
+		Run: func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("job was not canceled")
+			}
+		},
+	})
+
+	run, err := sched.Run(context.Background(), "test")
+	if err == nil || err.Error() != "job canceled: context canceled" {
+		t.Errorf("Run() got error %v, want the job canceled", err)
+	}
+	if run.Succeeded {
+		t.Error("Run() recorded a canceled run as succeeding")
+	}
+}
+
+func TestWithJobLock(t *testing.T) {
+	store := &testJobStore{locks: map[string]newshound.JobLock{}}
+
+	var held bool
+	err := withJobLock(store, "test", func() error {
+		_, held = store.locks["test"]
+		return nil
+	})
+	if err != nil {
+		t.Errorf("withJobLock() returned an error: %s", err)
+	}
+	if !held {
+		t.Error("withJobLock() did not hold the lock")
+	}
+	if _, ok := store.locks["test"]; ok {
+		t.Error("withJobLock() did not release the lock")
+	}
+
+	store.locks["test"] = newshound.JobLock{Job: "test", Owner: "other", Expires: time.Now().Add(time.Minute)}
+	var called bool
+	err = withJobLock(store, "test", func() error {
+		called = true
+		return nil
+	})
+	if err != ErrJobRunning || called {
+		t.Errorf("withJobLock() with the job running got %v and called=%t, want ErrJobRunning", err, called)
+	}
+	if store.locks["test"].Owner != "other" {
+		t.Error("withJobLock() released a lock it did not own")
+	}
+}
@@ -0,0 +1,59 @@
+package fetch
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// senderScoopsReport is the name of the first-to-report leaderboard report.
+const senderScoopsReport = "sender_scoops"
+
+// generateSenderScoops will compute the first-to-report leaderboard for each timeframe.
+// The tie threshold is saved with each sender's scoops, in seconds, so the API can
+// compute scoops on demand that agree with the leaderboard.
+func generateSenderScoops(sess *mgo.Session, tieThreshold time.Duration) error {
+	db := sess.DB("newshound")
+	resultCollection := senderScoopsReport
+	tempResultCollection := resultCollection + "_tmp"
+	tempResult := db.C(tempResultCollection)
+	if _, err := tempResult.RemoveAll(nil); err != nil {
+		return fmt.Errorf("remove error for %s:  %s", tempResultCollection, err)
+	}
+
+	for timekey, timeframe := range Timeframes {
+		log.Printf("calculating %s over '%s' thru '%s' as %s", resultCollection, timeframe[0], timeframe[1], timekey)
+		events, err := findScoopEvents(db, timeframe[0], timeframe[1])
+		if err != nil {
+			return err
+		}
+
+		for _, scoops := range report.ScoopLeaderboard(events, tieThreshold) {
+			err = tempResult.Insert(bson.M{
+				"_id":           bson.M{"sender": scoops.Sender, "timeframe": timekey},
+				"value":         scoops.ScoopValue,
+				"tie_threshold": int64(tieThreshold / time.Second),
+			})
+			if err != nil {
+				return fmt.Errorf("unable to insert %s record for %s: %s", resultCollection, scoops.Sender, err)
+			}
+		}
+	}
+
+	return renameCollection(sess, tempResultCollection, resultCollection)
+}
+
+// findScoopEvents returns events in the given timeframe with only the
+// fields needed to calculate scoops.
+func findScoopEvents(db *mgo.Database, from, to time.Time) ([]newshound.NewsEvent, error) {
+	var events []newshound.NewsEvent
+	err := newsEvents(db).Find(bson.M{"event_start": bson.M{"$gte": from, "$lt": to}}).
+		Select(bson.M{"event_start": 1, "news_alerts.sender": 1, "news_alerts.time_lapsed": 1}).
+		All(&events)
+	return events, err
+}
@@ -0,0 +1,268 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"github.com/jprobinson/newshound/report"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// the number of report documents to insert at once
+var reportBatchSize = 1000
+
+// mongoStore is the MongoDB backed report.Store. If ctx is set, reports
+// are no longer replaced once it's done.
+type mongoStore struct {
+	sess *mgo.Session
+	ctx  context.Context
+}
+
+var (
+	_ report.Store = &mongoStore{}
+	_ JobStore     = &mongoStore{}
+)
+
+func (m *mongoStore) EachAlert(since time.Time, fn func(newshound.NewsAlertLite) error) error {
+	query := bson.M{"revision_of": bson.M{"$exists": false}}
+	if !since.IsZero() {
+		query["timestamp"] = bson.M{"$gte": since}
+	}
+	iter := newsAlerts(newshoundDB(m.sess)).Find(query).
+		Select(bson.M{"sender": 1, "timestamp": 1, "tags": 1}).Batch(1000).Iter()
+	var alert newshound.NewsAlertLite
+	for iter.Next(&alert) {
+		if err := fn(alert); err != nil {
+			iter.Close()
+			return err
+		}
+		// start fresh so nothing carries over from the last alert
+		alert = newshound.NewsAlertLite{}
+	}
+	return iter.Close()
+}
+
+func (m *mongoStore) EachEvent(since time.Time, fn func(newshound.NewsEvent) error) error {
+	query := bson.M{}
+	if !since.IsZero() {
+		query["event_start"] = bson.M{"$gte": since}
+	}
+	iter := newsEvents(newshoundDB(m.sess)).Find(query).
+		Select(bson.M{"event_start": 1, "news_alerts.sender": 1, "news_alerts.order": 1,
+			"news_alerts.time_lapsed": 1, "news_alerts.tags": 1}).Batch(1000).Iter()
+	var event newshound.NewsEvent
+	for iter.Next(&event) {
+		if err := fn(event); err != nil {
+			iter.Close()
+			return err
+		}
+		// start fresh so nothing carries over from the last event
+		event = newshound.NewsEvent{}
+	}
+	return iter.Close()
+}
+
+func (m *mongoStore) Senders() ([]string, error) {
+	var senders []string
+	err := newsAlerts(newshoundDB(m.sess)).Find(nil).Distinct("sender", &senders)
+	return senders, err
+}
+
+// Replace will write the docs to a temp collection and then swap it in for the report.
+func (m *mongoStore) Replace(name string, docs []interface{}) error {
+	if m.ctx != nil && m.ctx.Err() != nil {
+		return m.ctx.Err()
+	}
+	db := newshoundDB(m.sess)
+	tempName := name + "_tmp"
+	temp := db.C(tempName)
+	if _, err := temp.RemoveAll(nil); err != nil {
+		return fmt.Errorf("remove error for %s:  %s", tempName, err)
+	}
+
+	// nothing to swap in, so just empty out the report
+	if len(docs) == 0 {
+		_, err := db.C(name).RemoveAll(nil)
+		return err
+	}
+
+	for start := 0; start < len(docs); start += reportBatchSize {
+		end := start + reportBatchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+		if err := temp.Insert(docs[start:end]...); err != nil {
+			return err
+		}
+	}
+
+	for _, indx := range indices[name] {
+		if err := temp.EnsureIndexKey(indx...); err != nil {
+			return err
+		}
+	}
+
+	return renameCollection(m.sess, tempName, name)
+}
+
+var _ report.WeeklyStore = &mongoStore{}
+
+func (m *mongoStore) AlertsBetween(from, to time.Time) ([]newshound.NewsAlertLite, error) {
+	query := bson.M{
+		"timestamp":   bson.M{"$gte": from, "$lt": to},
+		"revision_of": bson.M{"$exists": false},
+	}
+	var alerts []newshound.NewsAlertLite
+	err := newsAlerts(newshoundDB(m.sess)).Find(query).
+		Select(bson.M{"sender": 1, "timestamp": 1, "tags": 1}).All(&alerts)
+	return alerts, err
+}
+
+func (m *mongoStore) EventsBetween(from, to time.Time) ([]newshound.NewsEvent, error) {
+	var events []newshound.NewsEvent
+	err := newsEvents(newshoundDB(m.sess)).Find(bson.M{"event_start": bson.M{"$gte": from, "$lt": to}}).
+		Select(bson.M{"event_start": 1, "news_alerts.sender": 1, "news_alerts.order": 1,
+			"news_alerts.time_lapsed": 1, "news_alerts.tags": 1}).All(&events)
+	return events, err
+}
+
+func (m *mongoStore) CountEvents(from, to time.Time) (int, error) {
+	return newsEvents(newshoundDB(m.sess)).Find(bson.M{"event_start": bson.M{"$gte": from, "$lt": to}}).Count()
+}
+
+func (m *mongoStore) AlertWeeks() ([]report.AlertWeek, error) {
+	var weeks []report.AlertWeek
+	err := newshoundDB(m.sess).C(report.AlertsPerWeekBySenderReport).Find(nil).All(&weeks)
+	return weeks, err
+}
+
+func (m *mongoStore) EventWeeks() ([]report.EventWeek, error) {
+	var weeks []report.EventWeek
+	err := newshoundDB(m.sess).C(report.EventsPerWeekBySenderReport).Find(nil).All(&weeks)
+	return weeks, err
+}
+
+func (m *mongoStore) ReplaceWeek(name string, weekStart time.Time, docs []interface{}) error {
+	err := upsertAll(newshoundDB(m.sess).C(name), bson.M{"_id.week_start": weekStart}, docs)
+	if err != nil {
+		return fmt.Errorf("unable to replace week of %s in %s: %s", weekStart, name, err)
+	}
+	return nil
+}
+
+func (m *mongoStore) RemoveWeeksBefore(name string, before time.Time) error {
+	_, err := newshoundDB(m.sess).C(name).RemoveAll(bson.M{"_id.week_start": bson.M{"$lt": before}})
+	return err
+}
+
+func (m *mongoStore) ReplaceTimeframe(name, timeframe string, docs []interface{}) error {
+	err := upsertAll(newshoundDB(m.sess).C(name), bson.M{"_id.timeframe": timeframe}, docs)
+	if err != nil {
+		return fmt.Errorf("unable to replace %s in %s: %s", timeframe, name, err)
+	}
+	return nil
+}
+
+// upsertAll saves the docs over the ones with the same _id and then removes
+// the rest of the docs matching the query. Readers see the old or new version
+// of each doc while it runs, never a report with the docs missing.
+func upsertAll(c *mgo.Collection, query bson.M, docs []interface{}) error {
+	ids := make([]interface{}, 0, len(docs))
+	for start := 0; start < len(docs); start += reportBatchSize {
+		end := start + reportBatchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+		bulk := c.Bulk()
+		for _, doc := range docs[start:end] {
+			id, err := docID(doc)
+			if err != nil {
+				return err
+			}
+			bulk.Upsert(bson.M{"_id": id}, doc)
+			ids = append(ids, id)
+		}
+		if _, err := bulk.Run(); err != nil {
+			return err
+		}
+	}
+
+	stale := bson.M{"_id": bson.M{"$nin": ids}}
+	for k, v := range query {
+		stale[k] = v
+	}
+	_, err := c.RemoveAll(stale)
+	return err
+}
+
+// docID returns the _id of a report doc. It's kept as raw BSON so compound
+// IDs keep their field order when they're matched.
+func docID(doc interface{}) (bson.Raw, error) {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return bson.Raw{}, err
+	}
+	var d struct {
+		ID bson.Raw `bson:"_id"`
+	}
+	if err := bson.Unmarshal(b, &d); err != nil {
+		return bson.Raw{}, err
+	}
+	if d.ID.Kind == 0 {
+		return bson.Raw{}, fmt.Errorf("report doc is missing an _id: %v", doc)
+	}
+	return d.ID, nil
+}
+
+func (m *mongoStore) AddHours(hours []report.AlertsPerHour) error {
+	c := newshoundDB(m.sess).C(report.AlertsPerHourReport)
+	for _, sender := range hours {
+		inc := bson.M{}
+		for hour, count := range sender.Value.Hours {
+			if count > 0 {
+				inc["value.hours."+hour] = count
+			}
+		}
+		if len(inc) == 0 {
+			continue
+		}
+		if _, err := c.Upsert(bson.M{"_id": sender.ID}, bson.M{"$inc": inc}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lock relies on the unique _id of the job's lock. If an unexpired lock exists,
+// the upsert will not match it and fail to insert a duplicate.
+func (m *mongoStore) Lock(job, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	_, err := jobLocks(newshoundDB(m.sess)).Upsert(
+		bson.M{"_id": job, "expires": bson.M{"$lt": now}},
+		newshound.JobLock{Job: job, Owner: owner, Acquired: now, Expires: now.Add(ttl)},
+	)
+	if mgo.IsDup(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (m *mongoStore) RenewLock(job, owner string, ttl time.Duration) error {
+	return jobLocks(newshoundDB(m.sess)).Update(bson.M{"_id": job, "owner": owner},
+		bson.M{"$set": bson.M{"expires": time.Now().Add(ttl)}})
+}
+
+func (m *mongoStore) Unlock(job, owner string) error {
+	err := jobLocks(newshoundDB(m.sess)).Remove(bson.M{"_id": job, "owner": owner})
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (m *mongoStore) SaveRun(run newshound.JobRun) error {
+	return jobRuns(newshoundDB(m.sess)).Insert(run)
+}
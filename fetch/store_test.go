@@ -0,0 +1,30 @@
+package fetch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound/report"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestDocID(t *testing.T) {
+	week := report.SenderWeek{Sender: "CNN", WeekStart: time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC)}
+	id, err := docID(report.AlertWeek{ID: week, Value: report.AlertWeekValue{Alerts: 3}})
+	if err != nil {
+		t.Fatalf("docID returned an error: %s", err)
+	}
+	// compound IDs must keep their field order to match the stored ones
+	want, err := bson.Marshal(bson.D{{Name: "sender", Value: "CNN"}, {Name: "week_start", Value: week.WeekStart}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Kind != 0x03 || !bytes.Equal(id.Data, want) {
+		t.Errorf("docID got:%x want:%x", id.Data, want)
+	}
+
+	if _, err := docID(bson.M{"value": 1}); err == nil {
+		t.Error("docID without an _id returned no error")
+	}
+}
@@ -0,0 +1,224 @@
+package fetch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// how far back to look for related events
+	storylineWindow = 7 * 24 * time.Hour
+
+	// minimum weighted overlap of tags and entities between related events
+	minStorylineOverlap = 0.4
+
+	// minimum number of tags and entities related events must share
+	minStorylineShared = 2
+)
+
+// UpdateStoryline will link the given event to any storyline it belongs to. If the event
+// is not part of a storyline yet, the most similar recent event is found and the event
+// either joins that event's storyline or a new storyline is started for the two.
+func UpdateStoryline(ne, sl *mgo.Collection, event newshound.NewsEvent) error {
+	var existing newshound.Storyline
+	err := sl.Find(bson.M{"event_ids": event.ID}).One(&existing)
+	switch err {
+	case nil:
+		return addToStoryline(sl, existing, event)
+	case mgo.ErrNotFound:
+	default:
+		return err
+	}
+
+	// find any recent events that share some tags
+	var candidates []newshound.NewsEvent
+	query := bson.M{
+		"_id":         bson.M{"$ne": event.ID},
+		"event_start": bson.M{"$gte": event.EventStart.Add(-storylineWindow), "$lte": event.EventEnd},
+		"tags":        bson.M{"$in": event.Tags},
+	}
+	err = ne.Find(query).Select(bson.M{"news_alerts": 0}).All(&candidates)
+	if err != nil {
+		return err
+	}
+
+	var (
+		best      newshound.NewsEvent
+		bestScore float64
+	)
+	for _, cand := range candidates {
+		shared, score := storylineSimilarity(event, cand)
+		if shared < minStorylineShared || score < minStorylineOverlap {
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = cand, score
+		}
+	}
+	if bestScore == 0 {
+		return nil
+	}
+
+	err = sl.Find(bson.M{"event_ids": best.ID}).One(&existing)
+	switch err {
+	case nil:
+		return addToStoryline(sl, existing, event)
+	case mgo.ErrNotFound:
+		story := newshound.Storyline{ID: bson.NewObjectId()}
+		story = mergeStoryline(mergeStoryline(story, best), event)
+		return sl.Insert(story)
+	default:
+		return err
+	}
+}
+
+// removeStorylineEvents drops the events from any storylines they are in and rebuilds
+// the storylines from the events they have left. Storylines left with fewer than 2
+// events are deleted. The event that replaced them will start a new storyline or join
+// another one when it is linked with UpdateStoryline.
+func removeStorylineEvents(ne, sl *mgo.Collection, eventIDs []bson.ObjectId) error {
+	var stories []newshound.Storyline
+	if err := sl.Find(bson.M{"event_ids": bson.M{"$in": eventIDs}}).All(&stories); err != nil {
+		return err
+	}
+	for _, story := range stories {
+		var events []newshound.NewsEvent
+		err := ne.Find(bson.M{"_id": bson.M{"$in": story.EventIDs, "$nin": eventIDs}}).
+			Select(bson.M{"news_alerts": 0}).All(&events)
+		if err != nil {
+			return err
+		}
+		if len(events) < 2 {
+			if err = sl.RemoveId(story.ID); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+			continue
+		}
+		if err = sl.UpdateId(story.ID, rebuildStoryline(story, events)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildStoryline recomputes the storyline's timeframe, tags, entities and headline
+// from the events it has left, merging them in the order they joined the storyline.
+func rebuildStoryline(story newshound.Storyline, events []newshound.NewsEvent) newshound.Storyline {
+	joined := make(map[bson.ObjectId]int, len(story.EventIDs))
+	for i, id := range story.EventIDs {
+		joined[id] = i
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return joined[events[i].ID] < joined[events[j].ID]
+	})
+
+	rebuilt := newshound.Storyline{ID: story.ID}
+	for _, event := range events {
+		rebuilt = mergeStoryline(rebuilt, event)
+	}
+	return rebuilt
+}
+
+func addToStoryline(sl *mgo.Collection, story newshound.Storyline, event newshound.NewsEvent) error {
+	return sl.UpdateId(story.ID, mergeStoryline(story, event))
+}
+
+// mergeStoryline adds the event's timeframe, tags and entities to the storyline.
+// The storyline's headline comes from its most important event.
+func mergeStoryline(story newshound.Storyline, event newshound.NewsEvent) newshound.Storyline {
+	if story.Start.IsZero() || event.EventStart.Before(story.Start) {
+		story.Start = event.EventStart
+	}
+	if event.EventEnd.After(story.End) {
+		story.End = event.EventEnd
+	}
+
+	found := false
+	for _, id := range story.EventIDs {
+		if id == event.ID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		story.EventIDs = append(story.EventIDs, event.ID)
+	}
+
+	tags := map[string]struct{}{}
+	for _, tag := range story.Tags {
+		tags[tag] = struct{}{}
+	}
+	for _, tag := range event.Tags {
+		if _, ok := tags[tag]; !ok {
+			tags[tag] = struct{}{}
+			story.Tags = append(story.Tags, tag)
+		}
+	}
+	sort.Strings(story.Tags)
+
+	entities := map[newshound.Entity]struct{}{}
+	for _, e := range story.Entities {
+		entities[e] = struct{}{}
+	}
+	for _, e := range event.Entities {
+		if _, ok := entities[e]; !ok {
+			entities[e] = struct{}{}
+			story.Entities = append(story.Entities, e)
+		}
+	}
+	sort.Sort(entitiesByName(story.Entities))
+
+	if story.Headline == "" || event.Importance > story.Importance {
+		story.Headline = event.Headline
+		if story.Headline == "" {
+			story.Headline = event.TopSentence
+		}
+		story.Importance = event.Importance
+	}
+	return story
+}
+
+// storylineSimilarity compares the canonical tags and entities of two events. People
+// and places count double. The score is the weighted overlap of the smaller of the two sets
+// so long running stories with many tags can still match a new, smaller event.
+func storylineSimilarity(a, b newshound.NewsEvent) (shared int, score float64) {
+	aTerms, bTerms := storylineTerms(a), storylineTerms(b)
+	var aTotal, bTotal, sharedWeight float64
+	for term, weight := range aTerms {
+		aTotal += weight
+		if _, ok := bTerms[term]; ok {
+			shared++
+			sharedWeight += weight
+		}
+	}
+	for _, weight := range bTerms {
+		bTotal += weight
+	}
+	min := aTotal
+	if bTotal < min {
+		min = bTotal
+	}
+	if min == 0 {
+		return 0, 0
+	}
+	return shared, sharedWeight / min
+}
+
+func storylineTerms(event newshound.NewsEvent) map[string]float64 {
+	terms := map[string]float64{}
+	for _, tag := range canonicalTags(event.Tags) {
+		terms[tag] = 1
+	}
+	for _, e := range event.Entities {
+		weight := 1.0
+		if e.Type == newshound.EntityPerson || e.Type == newshound.EntityLocation {
+			weight = 2
+		}
+		terms[canonicalTag(e.Name)] = weight
+	}
+	return terms
+}
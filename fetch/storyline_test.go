@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestStorylineSimilarity(t *testing.T) {
+	harvey := newshound.NewsEvent{
+		Tags: []string{"hurricane harvey", "houston", "flooding", "texas"},
+		Entities: []newshound.Entity{
+			{Name: "hurricane harvey", Type: newshound.EntityEvent},
+			{Name: "houston", Type: newshound.EntityLocation},
+		},
+	}
+
+	tests := []struct {
+		given      newshound.NewsEvent
+		wantShared int
+		wantMatch  bool
+	}{
+		{
+			newshound.NewsEvent{
+				Tags:     []string{"houston", "hurricane harvey", "death toll"},
+				Entities: []newshound.Entity{{Name: "houston", Type: newshound.EntityLocation}},
+			},
+			2,
+			true,
+		},
+		{
+			newshound.NewsEvent{
+				Tags:     []string{"houston", "rockets", "nba finals", "game 7"},
+				Entities: []newshound.Entity{{Name: "houston", Type: newshound.EntityLocation}},
+			},
+			1,
+			false,
+		},
+		{
+			newshound.NewsEvent{Tags: []string{"federal reserve", "interest rates"}},
+			0,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		shared, score := storylineSimilarity(harvey, test.given)
+		match := shared >= minStorylineShared && score >= minStorylineOverlap
+		if shared != test.wantShared || match != test.wantMatch {
+			t.Errorf("storylineSimilarity(%q) got:%d, %v (%v) want:%d, %v",
+				test.given.Tags, shared, score, match, test.wantShared, test.wantMatch)
+		}
+	}
+}
+
+func TestMergeStoryline(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2017, 8, 25, 12, 0, 0, 0, time.UTC)
+	first := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"hurricane harvey", "texas"},
+		EventStart: start,
+		EventEnd:   start.Add(time.Hour),
+		Headline:   "Harvey makes landfall in Texas.",
+		Importance: 70,
+	}
+	second := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"houston", "hurricane harvey"},
+		EventStart: start.Add(2 * day),
+		EventEnd:   start.Add(2*day + time.Hour),
+		Headline:   "Houston floods.",
+		Importance: 50,
+	}
+
+	story := mergeStoryline(mergeStoryline(newshound.Storyline{}, second), first)
+	// merging the same event again should not change anything
+	story = mergeStoryline(story, second)
+
+	if !story.Start.Equal(first.EventStart) || !story.End.Equal(second.EventEnd) {
+		t.Errorf("mergeStoryline() timeframe got:%s - %s", story.Start, story.End)
+	}
+	if want := []bson.ObjectId{second.ID, first.ID}; !reflect.DeepEqual(story.EventIDs, want) {
+		t.Errorf("mergeStoryline() event IDs got:%v want:%v", story.EventIDs, want)
+	}
+	if want := []string{"houston", "hurricane harvey", "texas"}; !reflect.DeepEqual(story.Tags, want) {
+		t.Errorf("mergeStoryline() tags got:%q want:%q", story.Tags, want)
+	}
+	if story.Headline != first.Headline {
+		t.Errorf("mergeStoryline() headline got:%q want:%q", story.Headline, first.Headline)
+	}
+}
+
+func TestRebuildStoryline(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2017, 8, 25, 12, 0, 0, 0, time.UTC)
+	landfall := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"hurricane harvey", "texas"},
+		Entities:   []newshound.Entity{{Name: "texas", Type: newshound.EntityLocation}},
+		EventStart: start,
+		EventEnd:   start.Add(time.Hour),
+		Headline:   "Harvey makes landfall in Texas.",
+		Importance: 70,
+	}
+	floods := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"houston", "hurricane harvey"},
+		Entities:   []newshound.Entity{{Name: "houston", Type: newshound.EntityLocation}},
+		EventStart: start.Add(2 * day),
+		EventEnd:   start.Add(2*day + time.Hour),
+		Headline:   "Houston floods.",
+		Importance: 50,
+	}
+	tolls := newshound.NewsEvent{
+		ID:         bson.NewObjectId(),
+		Tags:       []string{"death toll", "hurricane harvey"},
+		EventStart: start.Add(4 * day),
+		EventEnd:   start.Add(4*day + time.Hour),
+		Headline:   "Harvey's death toll rises.",
+		Importance: 40,
+	}
+	story := newshound.Storyline{ID: bson.NewObjectId()}
+	for _, event := range []newshound.NewsEvent{floods, landfall, tolls} {
+		story = mergeStoryline(story, event)
+	}
+
+	// a reparse replaced the landfall event, so only the others are left
+	got := rebuildStoryline(story, []newshound.NewsEvent{tolls, floods})
+	want := newshound.Storyline{
+		ID:         story.ID,
+		Tags:       []string{"death toll", "houston", "hurricane harvey"},
+		Entities:   []newshound.Entity{{Name: "houston", Type: newshound.EntityLocation}},
+		Start:      floods.EventStart,
+		End:        tolls.EventEnd,
+		EventIDs:   []bson.ObjectId{floods.ID, tolls.ID},
+		Headline:   floods.Headline,
+		Importance: floods.Importance,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rebuildStoryline() got:%#v want:%#v", got, want)
+	}
+}
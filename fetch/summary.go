@@ -0,0 +1,168 @@
+package fetch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jprobinson/newshound"
+)
+
+var (
+	// headlines longer than this are only used if nothing else is available
+	headlineMaxWords = 25
+
+	summarySentences = 3
+
+	// sentences more similar than this to one already in the summary are skipped
+	maxSummarySimilarity = 0.5
+
+	centralityWeight = 0.6
+	coverageWeight   = 0.4
+)
+
+type candidate struct {
+	alert   int
+	value   string
+	words   map[string]struct{}
+	phrases []string
+	score   float64
+}
+
+// summarizeEvent will score every sentence from the event's alerts by how central
+// it is to what all the other senders said and by how many of the event's tags it covers.
+// The best concise sentence becomes the headline and the next few distinct sentences
+// become the summary.
+func summarizeEvent(alerts []newshound.NewsAlert, eventTags []string) (headline string, summary []string) {
+	tagSet := make(map[string]struct{}, len(eventTags))
+	for _, tag := range eventTags {
+		tagSet[strings.ToLower(tag)] = struct{}{}
+	}
+
+	// gather all the unique sentences
+	var cands []*candidate
+	seen := map[string]struct{}{}
+	for i, a := range alerts {
+		for _, s := range a.Sentences {
+			value := strings.TrimSpace(s.Value)
+			key := strings.ToLower(value)
+			if _, dupe := seen[key]; dupe || len(value) == 0 {
+				continue
+			}
+			seen[key] = struct{}{}
+			cands = append(cands, &candidate{
+				alert:   i,
+				value:   value,
+				words:   wordSet(value),
+				phrases: s.Phrases,
+			})
+		}
+	}
+	if len(cands) == 0 {
+		return "", nil
+	}
+
+	for _, c := range cands {
+		c.score = centralityWeight*centrality(c, cands, len(alerts)) +
+			coverageWeight*coverage(c, tagSet)
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].score > cands[j].score })
+
+	// the top scoring sentence that isn't too long
+	head := cands[0]
+	for _, c := range cands {
+		if len(strings.Fields(c.value)) <= headlineMaxWords {
+			head = c
+			break
+		}
+	}
+	headline = head.value
+
+	chosen := []*candidate{head}
+	for _, c := range cands {
+		if len(summary) >= summarySentences {
+			break
+		}
+		if c == head {
+			continue
+		}
+		distinct := true
+		for _, ch := range chosen {
+			if jaccard(c.words, ch.words) > maxSummarySimilarity {
+				distinct = false
+				break
+			}
+		}
+		if !distinct {
+			continue
+		}
+		chosen = append(chosen, c)
+		summary = append(summary, c.value)
+	}
+	return headline, summary
+}
+
+// centrality is the average of the best similarity the sentence has
+// with each of the other alerts in the event.
+func centrality(c *candidate, cands []*candidate, alertCount int) float64 {
+	if alertCount < 2 {
+		return 0
+	}
+	best := make(map[int]float64, alertCount)
+	for _, other := range cands {
+		if other.alert == c.alert {
+			continue
+		}
+		if sim := jaccard(c.words, other.words); sim > best[other.alert] {
+			best[other.alert] = sim
+		}
+	}
+	var total float64
+	for _, sim := range best {
+		total += sim
+	}
+	return total / float64(alertCount-1)
+}
+
+// coverage is the fraction of the event's tags found in the sentence.
+func coverage(c *candidate, tags map[string]struct{}) float64 {
+	if len(tags) == 0 {
+		return 0
+	}
+	found := map[string]struct{}{}
+	for _, phrase := range c.phrases {
+		phrase = strings.ToLower(canonicalTag(phrase))
+		if _, ok := tags[phrase]; ok {
+			found[phrase] = struct{}{}
+		}
+	}
+	return float64(len(found)) / float64(len(tags))
+}
+
+// wordSet breaks a sentence into its lowercased words, ignoring
+// punctuation and any very short words.
+func wordSet(s string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	})
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		if len(w) > 2 {
+			set[w] = struct{}{}
+		}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if _, ok := b[w]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
@@ -0,0 +1,55 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestSummarizeEvent(t *testing.T) {
+	alert := func(sentences ...newshound.Sentence) newshound.NewsAlert {
+		return newshound.NewsAlert{Sentences: sentences}
+	}
+	alerts := []newshound.NewsAlert{
+		alert(
+			newshound.Sentence{
+				Value:   "The Federal Reserve raised interest rates by a quarter point on Wednesday, its third increase this year, and signaled more hikes were likely as the economy continued to grow at a healthy clip despite trade tensions.",
+				Phrases: []string{"federal reserve", "interest rates", "quarter point", "economy"},
+			},
+		),
+		alert(
+			newshound.Sentence{
+				Value:   "Federal Reserve raises interest rates by a quarter point.",
+				Phrases: []string{"federal reserve", "interest rates", "quarter point"},
+			},
+			newshound.Sentence{
+				Value:   "Stocks fell after the announcement.",
+				Phrases: []string{"stocks", "announcement"},
+			},
+		),
+		alert(
+			newshound.Sentence{
+				Value:   "The Federal Reserve raised interest rates a quarter point.",
+				Phrases: []string{"federal reserve", "interest rates", "quarter point"},
+			},
+		),
+	}
+
+	headline, summary := summarizeEvent(alerts, []string{"federal reserve", "interest rates", "quarter point"})
+	if want := "The Federal Reserve raised interest rates a quarter point."; headline != want {
+		t.Errorf("summarizeEvent() headline got:%q want:%q", headline, want)
+	}
+	// the near duplicate 'raises' sentence should be dropped
+	for _, s := range summary {
+		if s == "Federal Reserve raises interest rates by a quarter point." {
+			t.Errorf("summarizeEvent() summary contains a near duplicate of the headline: %q", summary)
+		}
+	}
+	if len(summary) != 2 {
+		t.Errorf("summarizeEvent() summary got:%q want 2 sentences", summary)
+	}
+
+	if headline, summary = summarizeEvent(nil, nil); headline != "" || summary != nil {
+		t.Errorf("summarizeEvent(nil) got:%q, %q want empty", headline, summary)
+	}
+}
@@ -0,0 +1,235 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	pubsub "github.com/NYTimes/gizmo/pubsub"
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// the recent window we look for spikes in
+	trendWindow = 1 * time.Hour
+
+	// the window before trendWindow used to calculate a tag's usual rate
+	trendBaseline = 24 * time.Hour
+
+	trendBucket = 10 * time.Minute
+
+	// the lowest expected count we'll use so brand new tags need more than a single alert
+	minTrendExpected = 0.5
+
+	minTrendAlerts  = 3
+	minTrendSenders = 2
+
+	// the Poisson z-score a tag's recent count must reach to be trending
+	minTrendScore = 3.0
+)
+
+// TrendTracker keeps a rolling count of the tags and entities mentioned in
+// recent News Alerts so it can detect any that are suddenly spiking.
+type TrendTracker struct {
+	mu      sync.Mutex
+	buckets map[int64]map[string]*trendCount
+	types   map[string]newshound.EntityType
+	current map[string]newshound.TrendingTag
+
+	pub pubsub.Publisher
+}
+
+type trendCount struct {
+	alerts  int
+	senders map[string]struct{}
+}
+
+// NewTrendTracker creates a TrendTracker. If pub is not nil, any newly
+// trending tags will be published to it.
+func NewTrendTracker(pub pubsub.Publisher) *TrendTracker {
+	return &TrendTracker{
+		buckets: map[int64]map[string]*trendCount{},
+		types:   map[string]newshound.EntityType{},
+		current: map[string]newshound.TrendingTag{},
+		pub:     pub,
+	}
+}
+
+// Seed will add all alerts within the trend and baseline windows
+// from the database so the tracker can start with a full baseline.
+func (t *TrendTracker) Seed(sess *mgo.Session, now time.Time) error {
+	s := sess.Copy()
+	defer s.Close()
+
+	var alert newshound.NewsAlert
+	query := bson.M{
+		"timestamp":   bson.M{"$gte": now.Add(-trendWindow - trendBaseline)},
+		"revision_of": bson.M{"$exists": false},
+	}
+	iter := newsAlerts(newshoundDB(s)).Find(query).
+		Select(bson.M{"sender": 1, "timestamp": 1, "tags": 1, "entities": 1}).Iter()
+	for iter.Next(&alert) {
+		t.Add(alert)
+	}
+	return iter.Close()
+}
+
+// Add will count the tags and entities of the given alert.
+func (t *TrendTracker) Add(alert newshound.NewsAlert) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := alert.Timestamp.Truncate(trendBucket).Unix()
+	bucket, ok := t.buckets[key]
+	if !ok {
+		bucket = map[string]*trendCount{}
+		t.buckets[key] = bucket
+	}
+
+	terms := map[string]struct{}{}
+	for _, tag := range canonicalTags(alert.Tags) {
+		terms[tag] = struct{}{}
+	}
+	for _, e := range alert.Entities {
+		name := canonicalTag(e.Name)
+		terms[name] = struct{}{}
+		t.types[name] = e.Type
+	}
+
+	for term := range terms {
+		count, ok := bucket[term]
+		if !ok {
+			count = &trendCount{senders: map[string]struct{}{}}
+			bucket[term] = count
+		}
+		count.alerts++
+		count.senders[alert.Sender] = struct{}{}
+	}
+}
+
+// Trending returns all tags that are spiking as of the given time ordered by how
+// far above their baseline they are.
+func (t *TrendTracker) Trending(now time.Time) []newshound.TrendingTag {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trending(now)
+}
+
+func (t *TrendTracker) trending(now time.Time) []newshound.TrendingTag {
+	windowStart := now.Add(-trendWindow)
+	baselineStart := windowStart.Add(-trendBaseline)
+
+	recent := map[string]*trendCount{}
+	baseline := map[string]int{}
+	for key, bucket := range t.buckets {
+		bucketTime := time.Unix(key, 0)
+		// drop anything too old to matter
+		if bucketTime.Add(trendBucket).Before(baselineStart) {
+			delete(t.buckets, key)
+			continue
+		}
+		if bucketTime.After(now) {
+			continue
+		}
+		for term, count := range bucket {
+			if bucketTime.Before(windowStart) {
+				baseline[term] += count.alerts
+				continue
+			}
+			rc, ok := recent[term]
+			if !ok {
+				rc = &trendCount{senders: map[string]struct{}{}}
+				recent[term] = rc
+			}
+			rc.alerts += count.alerts
+			for sender := range count.senders {
+				rc.senders[sender] = struct{}{}
+			}
+		}
+	}
+
+	ratio := float64(trendWindow) / float64(trendBaseline)
+	var trending []newshound.TrendingTag
+	for term, count := range recent {
+		if count.alerts < minTrendAlerts || len(count.senders) < minTrendSenders {
+			continue
+		}
+		expected := math.Max(float64(baseline[term])*ratio, minTrendExpected)
+		score := (float64(count.alerts) - expected) / math.Sqrt(expected)
+		if score < minTrendScore {
+			continue
+		}
+
+		tt := newshound.TrendingTag{
+			Tag:      term,
+			Type:     t.types[term],
+			Alerts:   count.alerts,
+			Expected: math.Round(expected*100) / 100,
+			Score:    math.Round(score*100) / 100,
+			Since:    now,
+			Updated:  now,
+		}
+		for sender := range count.senders {
+			tt.Senders = append(tt.Senders, sender)
+		}
+		sort.Strings(tt.Senders)
+		trending = append(trending, tt)
+	}
+	sort.Slice(trending, func(i, j int) bool {
+		if trending[i].Score == trending[j].Score {
+			return trending[i].Tag < trending[j].Tag
+		}
+		return trending[i].Score > trending[j].Score
+	})
+	return trending
+}
+
+// Save will replace the stored trending tags with the current ones and publish
+// any that just started trending.
+func (t *TrendTracker) Save(ctx context.Context, tr *mgo.Collection, now time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trending := t.trending(now)
+	current := make(map[string]newshound.TrendingTag, len(trending))
+	for i, tt := range trending {
+		if prev, ok := t.current[tt.Tag]; ok {
+			trending[i].Since = prev.Since
+		} else {
+			t.publish(ctx, tt)
+		}
+		current[tt.Tag] = trending[i]
+	}
+	t.current = current
+
+	if _, err := tr.RemoveAll(nil); err != nil {
+		return err
+	}
+	for _, tt := range trending {
+		if err := tr.Insert(tt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TrendTracker) publish(ctx context.Context, tt newshound.TrendingTag) {
+	if t.pub == nil {
+		return
+	}
+	var buff bytes.Buffer
+	if err := gob.NewEncoder(&buff).Encode(&tt); err != nil {
+		log.Print("unable to gob trending tag: ", err)
+		return
+	}
+	if err := t.pub.PublishRaw(ctx, "", buff.Bytes()); err != nil {
+		log.Print("unable to publish trending tag: ", err)
+	}
+}
@@ -0,0 +1,58 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestTrendTracker(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	alert := func(sender string, minutes int, tags ...string) newshound.NewsAlert {
+		return newshound.NewsAlert{NewsAlertLite: newshound.NewsAlertLite{
+			Sender:    sender,
+			Timestamp: now.Add(-time.Duration(minutes) * time.Minute),
+			Tags:      tags,
+		}}
+	}
+
+	tracker := NewTrendTracker(nil)
+	// a steady stream of 'president' alerts all day
+	for m := 70; m < 25*60; m += 30 {
+		tracker.Add(alert("CNN", m, "president"))
+	}
+	tracker.Add(alert("CNN", 5, "president"))
+	tracker.Add(alert("NBC", 15, "president"))
+	tracker.Add(alert("FT", 25, "president"))
+
+	// a sudden burst of 'hurricane' alerts
+	tracker.Add(alert("CNN", 5, "hurricane"))
+	tracker.Add(alert("NBC", 10, "hurricane"))
+	tracker.Add(alert("FT", 20, "hurricane"))
+	tracker.Add(alert("CNN", 40, "hurricane"))
+
+	// a burst from a single sender
+	tracker.Add(alert("CNN", 5, "weather"))
+	tracker.Add(alert("CNN", 10, "weather"))
+	tracker.Add(alert("CNN", 15, "weather"))
+
+	got := tracker.Trending(now)
+	if len(got) != 1 {
+		t.Fatalf("Trending() got %d tags: %#v, want 1", len(got), got)
+	}
+	if got[0].Tag != "hurricane" {
+		t.Errorf("Trending() got tag %q, want %q", got[0].Tag, "hurricane")
+	}
+	if got[0].Alerts != 4 {
+		t.Errorf("Trending() got %d alerts, want 4", got[0].Alerts)
+	}
+	if want := []string{"CNN", "FT", "NBC"}; !equalTags(got[0].Senders, want) {
+		t.Errorf("Trending() got senders %v, want %v", got[0].Senders, want)
+	}
+
+	// once the burst falls out of the window it should stop trending
+	if got := tracker.Trending(now.Add(2 * time.Hour)); len(got) != 0 {
+		t.Errorf("Trending() after window got %#v, want none", got)
+	}
+}
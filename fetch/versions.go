@@ -0,0 +1,28 @@
+package fetch
+
+import (
+	"log"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// bumpVersions gives each of the named data versions a new version so the
+// API stops serving responses cached from the old data.
+func bumpVersions(db *mgo.Database, names ...string) {
+	now := time.Now().UTC()
+	for _, name := range names {
+		_, err := dataVersions(db).UpsertId(name, bson.M{"$set": bson.M{
+			"version": bson.NewObjectId(),
+			"updated": now,
+		}})
+		if err != nil {
+			log.Printf("unable to bump %s version: %s", name, err)
+		}
+	}
+}
+
+func dataVersions(db *mgo.Database) *mgo.Collection {
+	return db.C("data_versions")
+}
@@ -14,6 +14,7 @@ require (
 	github.com/dustin/gojson v0.0.0-20160307161227-2e71ec9dd5ad // indirect
 	github.com/garyburd/go-oauth v0.0.0-20180319155456-bca2e7f09a17 // indirect
 	github.com/go-kit/kit v0.9.0
+	github.com/golang/protobuf v1.3.2
 	github.com/gorilla/mux v1.7.3
 	github.com/jasonmoo/toget v0.0.0-20141111214334-58f11c5e4ab2
 	github.com/jprobinson/eazye v0.0.0-20190817162318-4cb129ef8264
@@ -24,6 +25,7 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/rs/cors v1.7.0
 	github.com/sloonz/go-qprintable v0.0.0-20160203160305-775b3a4592d5 // indirect
+	github.com/xitongsys/parquet-go v1.5.1
 	go.opencensus.io v0.22.2
 	golang.org/x/exp v0.0.0-20191129062945-2f5052295587
 	golang.org/x/net v0.0.0-20191209160850-c0dbc17a3553
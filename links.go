@@ -0,0 +1,31 @@
+package newshound
+
+import "fmt"
+
+// WebURL is the root of the newshound web app that alerts, events and
+// trends link back to.
+const WebURL = "http://newshound.email"
+
+// AlertLink returns the link to the alert on the newshound calendar.
+func AlertLink(alert NewsAlertLite) string {
+	return fmt.Sprintf("%s/#/calendar?start=%s&display=alerts&alert=%s",
+		WebURL,
+		alert.Timestamp.Format("2006-01-02"),
+		alert.ID.Hex())
+}
+
+// TrendLink returns the link to the alerts on the newshound calendar since
+// the tag started trending.
+func TrendLink(tag TrendingTag) string {
+	return fmt.Sprintf("%s/#/calendar?start=%s&display=alerts",
+		WebURL,
+		tag.Since.Format("2006-01-02"))
+}
+
+// EventLink returns the link to the event on the newshound calendar.
+func EventLink(event NewsEvent) string {
+	return fmt.Sprintf("%s/#/calendar?start=%s&display=events&event=%s",
+		WebURL,
+		event.EventStart.Format("2006-01-02"),
+		event.ID.Hex())
+}
@@ -0,0 +1,278 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// the upper bounds (in seconds) of each lead time bucket. leads over
+	// the last bound fall into a final, open ended bucket.
+	leadBuckets = []int64{60, 5 * 60, 15 * 60, 60 * 60}
+
+	// tags must be used at least this many times by the compared senders
+	// to be considered for over or under coverage.
+	minSkewTagCount = 3
+
+	// the number of over and under covered tags listed for each sender
+	maxSkewTags = 10
+)
+
+// Comparison is a head-to-head report on how two or more senders
+// covered the same date range.
+type Comparison struct {
+	Senders []string  `json:"senders"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+
+	// SharedEvents are the events at least 2 of the senders reported on.
+	SharedEvents []SharedEvent `json:"shared_events"`
+	// Exclusive holds the events only one of the senders reported on.
+	Exclusive map[string][]EventRef `json:"exclusive"`
+
+	Results []SenderComparison `json:"results"`
+}
+
+// SharedEvent describes which of the compared senders was first on an event.
+type SharedEvent struct {
+	EventRef
+	// First is empty if the event was a tie.
+	First    string `json:"first"`
+	RunnerUp string `json:"runner_up"`
+	// Lead is the number of seconds First beat RunnerUp by.
+	Lead int64 `json:"lead"`
+	Tie  bool  `json:"tie"`
+	// Lapsed holds the seconds from the event's start until each sender's first alert.
+	Lapsed map[string]int64 `json:"lapsed"`
+}
+
+// EventRef is a lightweight reference to a News Event.
+type EventRef struct {
+	EventID    bson.ObjectId `json:"event_id"`
+	EventStart time.Time     `json:"event_start"`
+	Headline   string        `json:"headline"`
+}
+
+// SenderComparison is one sender's side of a Comparison.
+type SenderComparison struct {
+	Sender string `json:"sender"`
+	Alerts int    `json:"alerts"`
+	// Events is the number of events the sender reported on.
+	Events int `json:"events"`
+	// Shared is the number of shared events the sender reported on.
+	Shared int `json:"shared"`
+	Wins   int `json:"wins"`
+	Ties   int `json:"ties"`
+	// Exclusive is the number of events only this sender reported on.
+	Exclusive int `json:"exclusive"`
+
+	Leads LeadDistribution `json:"leads"`
+
+	// OverCovers are tags the sender uses much more than the others.
+	OverCovers []TagSkew `json:"over_covers"`
+	// UnderCovers are tags the sender uses much less than the others.
+	UnderCovers []TagSkew `json:"under_covers"`
+}
+
+// LeadDistribution describes the lead times (in seconds) of a sender's wins.
+type LeadDistribution struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Max    float64 `json:"max"`
+	// Buckets counts the leads under 1m, 5m, 15m, 1h and over 1h.
+	Buckets []LeadBucket `json:"buckets"`
+}
+
+type LeadBucket struct {
+	// Max is the upper bound of the bucket in seconds. It is 0 for the last bucket.
+	Max   int64 `json:"max"`
+	Count int   `json:"count"`
+}
+
+// TagSkew compares the share of a sender's alerts with a tag to the share
+// of all the other compared senders' alerts with it.
+type TagSkew struct {
+	Tag         string  `json:"tag"`
+	Share       float64 `json:"share"`
+	OthersShare float64 `json:"others_share"`
+	// Ratio is the smoothed Share / OthersShare.
+	Ratio float64 `json:"ratio"`
+}
+
+// Compare builds a head-to-head report for the given senders from the alerts and
+// events in [from, to). Events are filtered down to only the compared senders, so
+// 'first' means first among them.
+func Compare(senders []string, alerts []newshound.NewsAlertLite, events []newshound.NewsEvent, from, to time.Time, tieThreshold time.Duration) Comparison {
+	comp := Comparison{
+		Senders:   senders,
+		Start:     from,
+		End:       to,
+		Exclusive: map[string][]EventRef{},
+	}
+	results := map[string]*SenderComparison{}
+	included := map[string]bool{}
+	for _, sender := range senders {
+		results[sender] = &SenderComparison{Sender: sender}
+		included[sender] = true
+	}
+
+	leads := map[string][]float64{}
+	for _, event := range eventsBetween(events, from, to) {
+		filtered := event
+		filtered.NewsAlerts = nil
+		for _, alert := range event.NewsAlerts {
+			if included[alert.Sender] {
+				filtered.NewsAlerts = append(filtered.NewsAlerts, alert)
+			}
+		}
+
+		firsts := senderFirsts(filtered)
+		if len(firsts) == 0 {
+			continue
+		}
+		ref := EventRef{EventID: event.ID, EventStart: event.EventStart, Headline: event.Headline}
+		for _, first := range firsts {
+			results[first.sender].Events++
+		}
+
+		scoop, ok := ScoopEvent(filtered, tieThreshold)
+		if !ok {
+			results[firsts[0].sender].Exclusive++
+			comp.Exclusive[firsts[0].sender] = append(comp.Exclusive[firsts[0].sender], ref)
+			continue
+		}
+
+		shared := SharedEvent{
+			EventRef: ref,
+			RunnerUp: scoop.RunnerUp,
+			Lead:     scoop.Lead,
+			Tie:      scoop.Tie,
+			Lapsed:   map[string]int64{},
+		}
+		for _, first := range firsts {
+			shared.Lapsed[first.sender] = first.lapsed
+			results[first.sender].Shared++
+		}
+		if scoop.Tie {
+			results[scoop.Sender].Ties++
+		} else {
+			shared.First = scoop.Sender
+			results[scoop.Sender].Wins++
+			leads[scoop.Sender] = append(leads[scoop.Sender], float64(scoop.Lead))
+		}
+		comp.SharedEvents = append(comp.SharedEvents, shared)
+	}
+
+	tagSkews(results, alertsBetween(alerts, from, to))
+
+	for _, sender := range senders {
+		result := results[sender]
+		result.Leads = leadDistribution(leads[sender])
+		comp.Results = append(comp.Results, *result)
+	}
+	return comp
+}
+
+func leadDistribution(leads []float64) LeadDistribution {
+	dist := LeadDistribution{Buckets: make([]LeadBucket, len(leadBuckets)+1)}
+	for i, max := range leadBuckets {
+		dist.Buckets[i].Max = max
+	}
+	if len(leads) == 0 {
+		return dist
+	}
+
+	dist.Min, dist.Max = leads[0], leads[0]
+	for _, lead := range leads {
+		dist.Min = math.Min(dist.Min, lead)
+		dist.Max = math.Max(dist.Max, lead)
+		bucket := len(leadBuckets)
+		for i, max := range leadBuckets {
+			if int64(lead) < max {
+				bucket = i
+				break
+			}
+		}
+		dist.Buckets[bucket].Count++
+	}
+	dist.Median = Median(leads)
+	return dist
+}
+
+// tagSkews finds the tags each sender over and under covers compared to the others.
+func tagSkews(results map[string]*SenderComparison, alerts []newshound.NewsAlertLite) {
+	counts := map[string]map[string]int{}
+	totals := map[string]int{}
+	for sender := range results {
+		counts[sender] = map[string]int{}
+	}
+	for _, alert := range alerts {
+		result, ok := results[alert.Sender]
+		if !ok {
+			continue
+		}
+		result.Alerts++
+		seen := map[string]struct{}{}
+		for _, tag := range alert.Tags {
+			tag = strings.ToLower(tag)
+			if _, dupe := seen[tag]; dupe {
+				continue
+			}
+			seen[tag] = struct{}{}
+			counts[alert.Sender][tag]++
+			totals[tag]++
+		}
+	}
+
+	for sender, result := range results {
+		others := 0
+		for other, r := range results {
+			if other != sender {
+				others += r.Alerts
+			}
+		}
+		if result.Alerts == 0 || others == 0 {
+			continue
+		}
+
+		var skews []TagSkew
+		for tag, total := range totals {
+			if total < minSkewTagCount {
+				continue
+			}
+			mine := counts[sender][tag]
+			theirs := total - mine
+			skew := TagSkew{
+				Tag:         tag,
+				Share:       float64(mine) / float64(result.Alerts),
+				OthersShare: float64(theirs) / float64(others),
+			}
+			// add-one smoothing so tags only one side used don't divide by zero
+			skew.Ratio = (float64(mine+1) / float64(result.Alerts+1)) /
+				(float64(theirs+1) / float64(others+1))
+			skews = append(skews, skew)
+		}
+
+		sort.Slice(skews, func(i, j int) bool {
+			if skews[i].Ratio == skews[j].Ratio {
+				return skews[i].Tag < skews[j].Tag
+			}
+			return skews[i].Ratio > skews[j].Ratio
+		})
+		for _, skew := range skews {
+			if skew.Ratio > 1 && len(result.OverCovers) < maxSkewTags {
+				result.OverCovers = append(result.OverCovers, skew)
+			}
+		}
+		for i := len(skews) - 1; i >= 0; i-- {
+			if skews[i].Ratio < 1 && len(result.UnderCovers) < maxSkewTags {
+				result.UnderCovers = append(result.UnderCovers, skews[i])
+			}
+		}
+	}
+}
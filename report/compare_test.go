@@ -0,0 +1,77 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestCompare(t *testing.T) {
+	s := newTestStore(t)
+	from := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	comp := Compare([]string{"cnn", "nbc"}, s.alerts, s.events, from, to, time.Minute)
+
+	if len(comp.SharedEvents) != 2 {
+		t.Fatalf("Compare() got %d shared events, want 2", len(comp.SharedEvents))
+	}
+	for _, shared := range comp.SharedEvents {
+		if shared.First != "cnn" || shared.RunnerUp != "nbc" {
+			t.Errorf("Compare() shared event first:%q runner up:%q, want cnn and nbc", shared.First, shared.RunnerUp)
+		}
+	}
+	if got := comp.SharedEvents[1].Lead; got != 1200 {
+		t.Errorf("Compare() second shared event lead got:%d want:1200", got)
+	}
+	// the 06-13 event only had nbc out of the two
+	if got := len(comp.Exclusive["nbc"]); got != 1 {
+		t.Errorf("Compare() nbc exclusive events got:%d want:1", got)
+	}
+
+	cnn, nbc := comp.Results[0], comp.Results[1]
+	if cnn.Wins != 2 || nbc.Wins != 0 {
+		t.Errorf("Compare() wins got cnn:%d nbc:%d want cnn:2 nbc:0", cnn.Wins, nbc.Wins)
+	}
+	if cnn.Events != 2 || nbc.Events != 3 || nbc.Exclusive != 1 {
+		t.Errorf("Compare() got cnn:%#v nbc:%#v", cnn, nbc)
+	}
+	if cnn.Leads.Min != 600 || cnn.Leads.Max != 1200 || cnn.Leads.Median != 900 {
+		t.Errorf("Compare() cnn leads got:%#v", cnn.Leads)
+	}
+	// 10m and 20m leads
+	if cnn.Leads.Buckets[2].Count != 1 || cnn.Leads.Buckets[3].Count != 1 {
+		t.Errorf("Compare() cnn lead buckets got:%#v", cnn.Leads.Buckets)
+	}
+}
+
+func TestTagSkews(t *testing.T) {
+	alert := func(sender string, tags ...string) newshound.NewsAlertLite {
+		return newshound.NewsAlertLite{Sender: sender, Tags: tags}
+	}
+	results := map[string]*SenderComparison{
+		"cnn": {Sender: "cnn"},
+		"fox": {Sender: "fox"},
+	}
+	tagSkews(results, []newshound.NewsAlertLite{
+		alert("cnn", "russia"), alert("cnn", "russia"), alert("cnn", "russia", "Senate"),
+		alert("cnn", "weather"),
+		alert("fox", "border"), alert("fox", "border"), alert("fox", "border", "senate"),
+		alert("fox", "weather"), alert("nbc", "russia"),
+	})
+
+	cnn, fox := results["cnn"], results["fox"]
+	if cnn.Alerts != 4 || fox.Alerts != 4 {
+		t.Errorf("tagSkews() alerts got cnn:%d fox:%d, want 4 and 4", cnn.Alerts, fox.Alerts)
+	}
+	if len(cnn.OverCovers) != 1 || cnn.OverCovers[0].Tag != "russia" {
+		t.Errorf("tagSkews() cnn over covers got:%#v want russia", cnn.OverCovers)
+	}
+	if len(cnn.UnderCovers) != 1 || cnn.UnderCovers[0].Tag != "border" {
+		t.Errorf("tagSkews() cnn under covers got:%#v want border", cnn.UnderCovers)
+	}
+	if len(fox.OverCovers) != 1 || fox.OverCovers[0].Tag != "border" {
+		t.Errorf("tagSkews() fox over covers got:%#v want border", fox.OverCovers)
+	}
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// Store is where the reports get their alerts and events from and
+// where the finished reports are saved.
+type Store interface {
+	// EachAlert calls fn with each original (non-resent) alert sent at or after
+	// 'since', stopping at the first error. Only the sender, timestamp and tags
+	// are required.
+	EachAlert(since time.Time, fn func(newshound.NewsAlertLite) error) error
+	// EachEvent calls fn with each event that started at or after 'since',
+	// stopping at the first error. Only the start and each alert's sender,
+	// order, time lapsed and tags are required.
+	EachEvent(since time.Time, fn func(newshound.NewsEvent) error) error
+	// Senders returns every sender that has ever sent an alert.
+	Senders() ([]string, error)
+	// Replace swaps the entire contents of the named report for docs.
+	Replace(report string, docs []interface{}) error
+}
+
+// The names of each stored report.
+const (
+	AlertsPerWeekBySenderReport = "alerts_per_week_by_sender"
+	AlertsPerWeekReport         = "alerts_per_week"
+	AvgAlertsPerWeekReport      = "avg_alerts_per_week_by_sender"
+	EventsPerWeekBySenderReport = "events_per_week_by_sender"
+	EventsPerWeekReport         = "events_per_week"
+	AvgEventsPerWeekReport      = "avg_events_per_week_by_sender"
+	EventAttendanceReport       = "sender_event_attendance"
+	AlertsPerHourReport         = "sender_alerts_per_hour"
+)
+
+// Generate rebuilds every weekly and per sender report from the alerts and events
+// in the store. Weekly reports only cover the TwelveMonths timeframe. Older alerts
+// and events are only counted towards the alerts per hour and events per week as
+// they're read, so just the last 12 months of them are held at once. If any reports
+// fail to save, the error will be an Errors.
+func Generate(s Store, timeframes map[string][]time.Time) error {
+	since := timeframes[TwelveMonths][0]
+	var alerts []newshound.NewsAlertLite
+	hours := senderHours{}
+	err := s.EachAlert(time.Time{}, func(alert newshound.NewsAlertLite) error {
+		hours.add(alert, Location)
+		if !alert.Timestamp.Before(since) {
+			alerts = append(alerts, alert)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get alerts: %s", err)
+	}
+	var events []newshound.NewsEvent
+	eventTotals := weekEvents{}
+	err = s.EachEvent(time.Time{}, func(event newshound.NewsEvent) error {
+		eventTotals.add(event, Location)
+		if !event.EventStart.Before(since) {
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get events: %s", err)
+	}
+	senders, err := s.Senders()
+	if err != nil {
+		return fmt.Errorf("unable to get senders: %s", err)
+	}
+
+	alertWeeks := AlertsPerWeekBySender(alerts, Location)
+	eventWeeks := EventsPerWeekBySender(events, Location)
+
+	var (
+		avgAlerts  []AvgAlerts
+		avgEvents  []AvgEvents
+		attendance []Attendance
+	)
+	for _, timekey := range sortedTimeframes(timeframes) {
+		timeframe := timeframes[timekey]
+		from, to := timeframe[0], timeframe[1]
+		log.Printf("calculating reports over '%s' thru '%s' as %s", from, to, timekey)
+
+		avgAlerts = append(avgAlerts, AvgAlertsPerWeek(alertWeeks, senders, timekey, from, to)...)
+		avgEvents = append(avgEvents, AvgEventsPerWeek(eventWeeks, senders, timekey, from, to)...)
+		attendance = append(attendance, EventAttendance(eventWeeks, senders, timekey, from, to,
+			len(eventsBetween(events, from, to)))...)
+	}
+
+	reports := []struct {
+		name string
+		docs interface{}
+	}{
+		{AlertsPerWeekBySenderReport, alertWeeks},
+		{AlertsPerWeekReport, AlertsPerWeek(alertWeeks)},
+		{AvgAlertsPerWeekReport, avgAlerts},
+		{EventsPerWeekBySenderReport, eventWeeks},
+		{EventsPerWeekReport, eventTotals.report()},
+		{AvgEventsPerWeekReport, avgEvents},
+		{EventAttendanceReport, attendance},
+		{AlertsPerHourReport, hours.report()},
+	}
+	errs := Errors{}
+	for _, r := range reports {
+		log.Printf("saving %s", r.name)
+		if err := s.Replace(r.name, toDocs(r.docs)); err != nil {
+			errs[r.name] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Errors holds the error of each report that could not be saved, keyed by
+// the report's name. The other reports are still saved when one fails.
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("unable to save %s: %s", name, e[name])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func alertsSince(alerts []newshound.NewsAlertLite, since time.Time) []newshound.NewsAlertLite {
+	var recent []newshound.NewsAlertLite
+	for _, alert := range alerts {
+		if !alert.Timestamp.Before(since) {
+			recent = append(recent, alert)
+		}
+	}
+	return recent
+}
+
+func eventsSince(events []newshound.NewsEvent, since time.Time) []newshound.NewsEvent {
+	var recent []newshound.NewsEvent
+	for _, event := range events {
+		if !event.EventStart.Before(since) {
+			recent = append(recent, event)
+		}
+	}
+	return recent
+}
+
+func sortedTimeframes(timeframes map[string][]time.Time) []string {
+	keys := make([]string, 0, len(timeframes))
+	for key := range timeframes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toDocs converts a slice of any report type into a slice of documents.
+func toDocs(slice interface{}) []interface{} {
+	v := reflect.ValueOf(slice)
+	docs := make([]interface{}, v.Len())
+	for i := range docs {
+		docs[i] = v.Index(i).Interface()
+	}
+	return docs
+}
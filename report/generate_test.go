@@ -0,0 +1,330 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// testStore is an in-memory Store loaded with the fixtures in testdata.
+type testStore struct {
+	alerts  []newshound.NewsAlertLite
+	events  []newshound.NewsEvent
+	senders []string
+	reports map[string][]interface{}
+	// fail holds errors to return when saving a report
+	fail map[string]error
+}
+
+func newTestStore(t *testing.T) *testStore {
+	s := &testStore{
+		senders: []string{"cnn", "ft", "nbc"},
+		reports: map[string][]interface{}{},
+	}
+	loadFixture(t, "alerts.json", &s.alerts)
+	loadFixture(t, "events.json", &s.events)
+	return s
+}
+
+func loadFixture(t *testing.T, name string, v interface{}) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("unable to read fixture %s: %s", name, err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		t.Fatalf("unable to decode fixture %s: %s", name, err)
+	}
+}
+
+func (s *testStore) EachAlert(since time.Time, fn func(newshound.NewsAlertLite) error) error {
+	for _, alert := range s.alerts {
+		if alert.Timestamp.Before(since) {
+			continue
+		}
+		if err := fn(alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testStore) EachEvent(since time.Time, fn func(newshound.NewsEvent) error) error {
+	for _, event := range s.events {
+		if event.EventStart.Before(since) {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testStore) Senders() ([]string, error) {
+	return s.senders, nil
+}
+
+func (s *testStore) Replace(report string, docs []interface{}) error {
+	if err := s.fail[report]; err != nil {
+		return err
+	}
+	s.reports[report] = docs
+	return nil
+}
+
+var (
+	testNow   = time.Date(2018, 6, 16, 0, 0, 0, 0, time.UTC)
+	firstWeek = time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)
+	lastWeek  = time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC)
+	oldWeek   = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the number of weeks in the 12 months before testNow
+	yearWeeks = 365.0 / 7.0
+)
+
+func generateTestReports(t *testing.T) map[string][]interface{} {
+	s := newTestStore(t)
+	if err := Generate(s, Timeframes(testNow)); err != nil {
+		t.Fatalf("Generate() returned an error: %s", err)
+	}
+	return s.reports
+}
+
+func TestGenerateErrors(t *testing.T) {
+	s := newTestStore(t)
+	failure := errors.New("disk full")
+	s.fail = map[string]error{EventsPerWeekReport: failure}
+
+	err := Generate(s, Timeframes(testNow))
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("Generate() got error %#v, want Errors", err)
+	}
+	if len(errs) != 1 || errs[EventsPerWeekReport] != failure {
+		t.Errorf("Generate() got errors %v, want only %s to fail", errs, EventsPerWeekReport)
+	}
+	// the rest of the reports are still saved
+	if _, ok := s.reports[AlertsPerHourReport]; !ok {
+		t.Errorf("Generate() did not save %s after %s failed", AlertsPerHourReport, EventsPerWeekReport)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		given time.Time
+		loc   *time.Location
+		want  time.Time
+	}{
+		{time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC), time.UTC, firstWeek},
+		{time.Date(2018, 6, 9, 23, 59, 59, 0, time.UTC), time.UTC, firstWeek},
+		{time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC), time.UTC, lastWeek},
+		{time.Date(2018, 6, 10, 3, 0, 0, 0, time.FixedZone("EDT", -4*60*60)), time.UTC, lastWeek},
+		{time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC), time.UTC, time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)},
+		// still Saturday evening in New York
+		{time.Date(2018, 6, 10, 2, 0, 0, 0, time.UTC), newYork, time.Date(2018, 6, 3, 0, 0, 0, 0, newYork)},
+		{time.Date(2018, 6, 10, 5, 0, 0, 0, time.UTC), newYork, time.Date(2018, 6, 10, 0, 0, 0, 0, newYork)},
+	}
+
+	for _, test := range tests {
+		if got := WeekStart(test.given, test.loc); !got.Equal(test.want) {
+			t.Errorf("WeekStart(%s, %s) got:%s want:%s", test.given, test.loc, got, test.want)
+		}
+	}
+}
+
+func TestGenerateAlertsPerWeek(t *testing.T) {
+	reports := generateTestReports(t)
+
+	wantBySender := []interface{}{
+		AlertWeek{SenderWeek{"cnn", firstWeek}, AlertWeekValue{2, map[string]int{"trump": 2, "g&#46;o&#46;p&#46;": 1}}},
+		AlertWeek{SenderWeek{"cnn", lastWeek}, AlertWeekValue{1, map[string]int{"senate": 1, "trump": 1}}},
+		AlertWeek{SenderWeek{"nbc", firstWeek}, AlertWeekValue{1, map[string]int{"trump": 1}}},
+		AlertWeek{SenderWeek{"nbc", lastWeek}, AlertWeekValue{1, map[string]int{"senate": 1}}},
+	}
+	if got := reports[AlertsPerWeekBySenderReport]; !reflect.DeepEqual(got, wantBySender) {
+		t.Errorf("alerts per week by sender got:\n%#v\nwant:\n%#v", got, wantBySender)
+	}
+
+	wantTotals := []interface{}{
+		AlertTotal{Week{firstWeek}, AlertTotalValue{3}},
+		AlertTotal{Week{lastWeek}, AlertTotalValue{2}},
+	}
+	if got := reports[AlertsPerWeekReport]; !reflect.DeepEqual(got, wantTotals) {
+		t.Errorf("alerts per week got:\n%#v\nwant:\n%#v", got, wantTotals)
+	}
+}
+
+func TestGenerateAvgAlertsPerWeek(t *testing.T) {
+	reports := generateTestReports(t)
+
+	got := map[SenderTimeframe]AvgAlertsValue{}
+	for _, doc := range reports[AvgAlertsPerWeekReport] {
+		avg := doc.(AvgAlerts)
+		got[avg.ID] = avg.Value
+	}
+	if len(got) != 12 {
+		t.Errorf("avg alerts per week got %d results, want one per sender and timeframe", len(got))
+	}
+
+	tests := []struct {
+		id      SenderTimeframe
+		total   int
+		avg     float64
+		topTags []TagFrequency
+	}{
+		{SenderTimeframe{"cnn", LastSevenDays}, 1, 1, []TagFrequency{{"senate", 1}, {"trump", 1}}},
+		{SenderTimeframe{"nbc", LastSevenDays}, 1, 1, []TagFrequency{{"senate", 1}}},
+		{SenderTimeframe{"ft", LastSevenDays}, 0, 0, []TagFrequency{}},
+		{SenderTimeframe{"cnn", TwelveMonths}, 3, 3 / yearWeeks,
+			[]TagFrequency{{"trump", 3}, {"g&#46;o&#46;p&#46;", 1}, {"senate", 1}}},
+		{SenderTimeframe{"ft", TwelveMonths}, 0, 0, []TagFrequency{}},
+	}
+	for _, test := range tests {
+		value := got[test.id]
+		if value.TotalAlerts != test.total {
+			t.Errorf("%v total alerts got:%d want:%d", test.id, value.TotalAlerts, test.total)
+		}
+		if !floatEqual(value.AvgAlerts, test.avg) {
+			t.Errorf("%v avg alerts got:%v want:%v", test.id, value.AvgAlerts, test.avg)
+		}
+		if !reflect.DeepEqual(value.TagArray, test.topTags) {
+			t.Errorf("%v tag array got:%v want:%v", test.id, value.TagArray, test.topTags)
+		}
+	}
+}
+
+func TestGenerateEventsPerWeek(t *testing.T) {
+	reports := generateTestReports(t)
+
+	wantBySender := []interface{}{
+		EventWeek{SenderWeek{"cnn", firstWeek}, EventWeekValue{1, 1, 1, 0, 0, map[string]int{"trump": 1}}},
+		EventWeek{SenderWeek{"cnn", lastWeek}, EventWeekValue{1, 1, 1, 0, 0, map[string]int{"senate": 1}}},
+		EventWeek{SenderWeek{"ft", lastWeek}, EventWeekValue{1, 2, 2, 300, 5, map[string]int{"senate": 1}}},
+		EventWeek{SenderWeek{"nbc", firstWeek}, EventWeekValue{1, 2, 2, 600, 10, map[string]int{"trump": 1}}},
+		EventWeek{SenderWeek{"nbc", lastWeek}, EventWeekValue{2, 3, 1.5, 1200, 10, map[string]int{"senate": 2}}},
+	}
+	if got := reports[EventsPerWeekBySenderReport]; !reflect.DeepEqual(got, wantBySender) {
+		t.Errorf("events per week by sender got:\n%#v\nwant:\n%#v", got, wantBySender)
+	}
+
+	wantTotals := []interface{}{
+		EventTotal{Week{oldWeek}, EventTotalValue{1}},
+		EventTotal{Week{firstWeek}, EventTotalValue{1}},
+		EventTotal{Week{lastWeek}, EventTotalValue{2}},
+	}
+	if got := reports[EventsPerWeekReport]; !reflect.DeepEqual(got, wantTotals) {
+		t.Errorf("events per week got:\n%#v\nwant:\n%#v", got, wantTotals)
+	}
+}
+
+func TestGenerateAvgEventsPerWeek(t *testing.T) {
+	reports := generateTestReports(t)
+
+	got := map[SenderTimeframe]AvgEventsValue{}
+	for _, doc := range reports[AvgEventsPerWeekReport] {
+		avg := doc.(AvgEvents)
+		got[avg.ID] = avg.Value
+	}
+
+	tests := []struct {
+		id   SenderTimeframe
+		want AvgEventsValue
+	}{
+		{SenderTimeframe{"nbc", TwelveMonths}, AvgEventsValue{
+			AvgEvents: 3 / yearWeeks, TotalEvents: 3, AvgRank: 5.0 / 3.0, TotalRank: 5, TotalWeeks: 2,
+			TagMap: map[string]int{"trump": 1, "senate": 2}, AvgTimeLapsed: 10, TotalTimeLapsed: 1800,
+		}},
+		{SenderTimeframe{"cnn", LastSevenDays}, AvgEventsValue{
+			AvgEvents: 1, TotalEvents: 1, AvgRank: 1, TotalRank: 1, TotalWeeks: 1,
+			TagMap: map[string]int{"senate": 1},
+		}},
+		{SenderTimeframe{"ft", ThreeMonths}, AvgEventsValue{
+			AvgEvents: 1 / WeeksBetween(testNow.AddDate(0, -3, 0), testNow), TotalEvents: 1, AvgRank: 2,
+			TotalRank: 2, TotalWeeks: 1, TagMap: map[string]int{"senate": 1}, AvgTimeLapsed: 5, TotalTimeLapsed: 300,
+		}},
+	}
+	for _, test := range tests {
+		value := got[test.id]
+		if !floatEqual(value.AvgEvents, test.want.AvgEvents) || !floatEqual(value.AvgRank, test.want.AvgRank) {
+			t.Errorf("%v averages got:%#v want:%#v", test.id, value, test.want)
+		}
+		value.AvgEvents, value.AvgRank = test.want.AvgEvents, test.want.AvgRank
+		if !reflect.DeepEqual(value, test.want) {
+			t.Errorf("%v got:%#v want:%#v", test.id, value, test.want)
+		}
+	}
+}
+
+func TestGenerateEventAttendance(t *testing.T) {
+	reports := generateTestReports(t)
+
+	got := map[SenderTimeframe]AttendanceValue{}
+	for _, doc := range reports[EventAttendanceReport] {
+		attend := doc.(Attendance)
+		got[attend.ID] = attend.Value
+	}
+
+	tests := []struct {
+		id   SenderTimeframe
+		want AttendanceValue
+	}{
+		{SenderTimeframe{"nbc", TwelveMonths}, AttendanceValue{3, 100}},
+		{SenderTimeframe{"cnn", TwelveMonths}, AttendanceValue{2, 200.0 / 3.0}},
+		{SenderTimeframe{"ft", TwelveMonths}, AttendanceValue{1, 100.0 / 3.0}},
+		{SenderTimeframe{"nbc", LastSevenDays}, AttendanceValue{2, 100}},
+		{SenderTimeframe{"cnn", LastSevenDays}, AttendanceValue{1, 50}},
+	}
+	for _, test := range tests {
+		value := got[test.id]
+		if value.TotalEvents != test.want.TotalEvents || !floatEqual(value.Attendance, test.want.Attendance) {
+			t.Errorf("%v attendance got:%#v want:%#v", test.id, value, test.want)
+		}
+	}
+}
+
+func TestGenerateAlertsPerHour(t *testing.T) {
+	reports := generateTestReports(t)
+
+	got := map[string]map[string]int64{}
+	for _, doc := range reports[AlertsPerHourReport] {
+		hours := doc.(AlertsPerHour)
+		if len(hours.Value.Hours) != 24 {
+			t.Errorf("%s alerts per hour got %d hours, want 24", hours.ID.Sender, len(hours.Value.Hours))
+		}
+		got[hours.ID.Sender] = hours.Value.Hours
+	}
+
+	tests := []struct {
+		sender string
+		hour   string
+		want   int64
+	}{
+		{"cnn", "13", 2},
+		{"cnn", "9", 1},
+		{"cnn", "0", 0},
+		{"nbc", "23", 1},
+		{"nbc", "0", 1},
+		{"ft", "12", 1},
+	}
+	for _, test := range tests {
+		if value := got[test.sender][test.hour]; value != test.want {
+			t.Errorf("%s alerts in hour %s got:%d want:%d", test.sender, test.hour, value, test.want)
+		}
+	}
+}
+
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
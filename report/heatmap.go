@@ -0,0 +1,32 @@
+package report
+
+import (
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// Heatmap counts a sender's alerts by the day of the week and hour of the
+// day they were sent.
+type Heatmap struct {
+	Sender   string `json:"sender"`
+	Timezone string `json:"timezone"`
+	Alerts   int    `json:"alerts"`
+	// Counts is indexed by weekday (Sunday is 0) and then hour.
+	Counts [7][24]int `json:"counts"`
+}
+
+// SenderHeatmap builds the day of week by hour heatmap of the sender's
+// alerts within the given location.
+func SenderHeatmap(sender string, alerts []newshound.NewsAlertLite, loc *time.Location) Heatmap {
+	hm := Heatmap{Sender: sender, Timezone: loc.String()}
+	for _, alert := range alerts {
+		if alert.Sender != sender {
+			continue
+		}
+		t := alert.Timestamp.In(loc)
+		hm.Counts[t.Weekday()][t.Hour()]++
+		hm.Alerts++
+	}
+	return hm
+}
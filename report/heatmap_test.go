@@ -0,0 +1,36 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSenderHeatmap(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestStore(t)
+
+	tests := []struct {
+		loc     *time.Location
+		weekday time.Weekday
+		hour    int
+		want    int
+	}{
+		{time.UTC, time.Saturday, 23, 1},
+		{time.UTC, time.Sunday, 0, 1},
+		{newYork, time.Saturday, 19, 1},
+		{newYork, time.Saturday, 20, 1},
+		{newYork, time.Sunday, 0, 0},
+	}
+	for _, test := range tests {
+		hm := SenderHeatmap("nbc", s.alerts, test.loc)
+		if hm.Alerts != 2 {
+			t.Errorf("SenderHeatmap(%s) got %d alerts, want 2", test.loc, hm.Alerts)
+		}
+		if got := hm.Counts[test.weekday][test.hour]; got != test.want {
+			t.Errorf("SenderHeatmap(%s) %s %d:00 got:%d want:%d", test.loc, test.weekday, test.hour, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// WeeklyStore is a Store that can also read and update the reports a
+// single week at a time so they can be kept current as alerts come in.
+type WeeklyStore interface {
+	Store
+
+	// AlertsBetween returns all original alerts sent in [from, to).
+	AlertsBetween(from, to time.Time) ([]newshound.NewsAlertLite, error)
+	// EventsBetween returns all events that started in [from, to).
+	EventsBetween(from, to time.Time) ([]newshound.NewsEvent, error)
+	// CountEvents returns the number of events that started in [from, to).
+	CountEvents(from, to time.Time) (int, error)
+
+	// AlertWeeks returns the stored alerts per week by sender report.
+	AlertWeeks() ([]AlertWeek, error)
+	// EventWeeks returns the stored events per week by sender report.
+	EventWeeks() ([]EventWeek, error)
+
+	// ReplaceWeek swaps the docs for a single week of the named weekly report.
+	// Readers should never see the week without its docs.
+	ReplaceWeek(report string, weekStart time.Time, docs []interface{}) error
+	// RemoveWeeksBefore drops any weeks of the named weekly report starting before the given time.
+	RemoveWeeksBefore(report string, before time.Time) error
+	// AddHours increments the stored alerts per hour counts by the given amounts.
+	AddHours(hours []AlertsPerHour) error
+	// ReplaceTimeframe swaps the docs for a single timeframe of the named per sender report.
+	// Readers should never see the timeframe without its docs.
+	ReplaceTimeframe(report, timeframe string, docs []interface{}) error
+}
+
+// Update refreshes the weekly reports for the given weeks, adds newAlerts to the alerts
+// per hour counts and then recalculates the per sender averages and attendance of the
+// timeframes that overlap the weeks from the stored weekly reports. It is safe to update
+// a week more than once.
+func Update(s WeeklyStore, weeks []time.Time, newAlerts []newshound.NewsAlertLite, timeframes map[string][]time.Time) error {
+	cutoff := timeframes[TwelveMonths][0]
+	since := WeekStart(cutoff, Location)
+	weeks = uniqueWeeks(weeks)
+	for _, week := range weeks {
+		end := week.AddDate(0, 0, 7)
+		alerts, err := s.AlertsBetween(week, end)
+		if err != nil {
+			return fmt.Errorf("unable to get alerts for week of %s: %s", week, err)
+		}
+		events, err := s.EventsBetween(week, end)
+		if err != nil {
+			return fmt.Errorf("unable to get events for week of %s: %s", week, err)
+		}
+
+		// event totals are kept for all time
+		if err = s.ReplaceWeek(EventsPerWeekReport, week, toDocs(EventsPerWeek(events, Location))); err != nil {
+			return err
+		}
+
+		// but everything else only for the last 12 months
+		if week.Before(since) {
+			continue
+		}
+		// only part of the week the 12 months start in is counted
+		alerts, events = alertsSince(alerts, cutoff), eventsSince(events, cutoff)
+		alertWeeks := AlertsPerWeekBySender(alerts, Location)
+		if err = s.ReplaceWeek(AlertsPerWeekBySenderReport, week, toDocs(alertWeeks)); err != nil {
+			return err
+		}
+		if err = s.ReplaceWeek(AlertsPerWeekReport, week, toDocs(AlertsPerWeek(alertWeeks))); err != nil {
+			return err
+		}
+		if err = s.ReplaceWeek(EventsPerWeekBySenderReport, week, toDocs(EventsPerWeekBySender(events, Location))); err != nil {
+			return err
+		}
+	}
+
+	for _, report := range []string{AlertsPerWeekBySenderReport, AlertsPerWeekReport, EventsPerWeekBySenderReport} {
+		if err := s.RemoveWeeksBefore(report, since); err != nil {
+			return err
+		}
+	}
+
+	if len(newAlerts) > 0 {
+		if err := s.AddHours(SenderAlertsPerHour(newAlerts, Location)); err != nil {
+			return fmt.Errorf("unable to update alerts per hour: %s", err)
+		}
+	}
+
+	return updateAverages(s, weeks, timeframes)
+}
+
+// updateAverages recalculates the per sender timeframe reports for the timeframes
+// overlapping any of the weeks from the stored weekly reports. The documents of the
+// other timeframes are left alone.
+func updateAverages(s WeeklyStore, weeks []time.Time, timeframes map[string][]time.Time) error {
+	var timekeys []string
+	for _, timekey := range sortedTimeframes(timeframes) {
+		if overlapsWeeks(weeks, timeframes[timekey][0], timeframes[timekey][1]) {
+			timekeys = append(timekeys, timekey)
+		}
+	}
+	if len(timekeys) == 0 {
+		return nil
+	}
+
+	alertWeeks, err := s.AlertWeeks()
+	if err != nil {
+		return fmt.Errorf("unable to get alert weeks: %s", err)
+	}
+	eventWeeks, err := s.EventWeeks()
+	if err != nil {
+		return fmt.Errorf("unable to get event weeks: %s", err)
+	}
+	senders, err := s.Senders()
+	if err != nil {
+		return fmt.Errorf("unable to get senders: %s", err)
+	}
+
+	for _, timekey := range timekeys {
+		from, to := timeframes[timekey][0], timeframes[timekey][1]
+		totalEvents, err := s.CountEvents(from, to)
+		if err != nil {
+			return fmt.Errorf("unable to count events for %s: %s", timekey, err)
+		}
+		avgAlerts := AvgAlertsPerWeek(alertWeeks, senders, timekey, from, to)
+		if err = s.ReplaceTimeframe(AvgAlertsPerWeekReport, timekey, toDocs(avgAlerts)); err != nil {
+			return err
+		}
+		avgEvents := AvgEventsPerWeek(eventWeeks, senders, timekey, from, to)
+		if err = s.ReplaceTimeframe(AvgEventsPerWeekReport, timekey, toDocs(avgEvents)); err != nil {
+			return err
+		}
+		attendance := EventAttendance(eventWeeks, senders, timekey, from, to, totalEvents)
+		if err = s.ReplaceTimeframe(EventAttendanceReport, timekey, toDocs(attendance)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlapsWeeks reports whether any of the weeks overlap [from, to).
+func overlapsWeeks(weeks []time.Time, from, to time.Time) bool {
+	for _, week := range weeks {
+		if week.Before(to) && week.AddDate(0, 0, 7).After(from) {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueWeeks(times []time.Time) []time.Time {
+	set := map[time.Time]struct{}{}
+	for _, t := range times {
+		set[WeekStart(t, Location)] = struct{}{}
+	}
+	weeks := make([]time.Time, 0, len(set))
+	for week := range set {
+		weeks = append(weeks, week)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].Before(weeks[j]) })
+	return weeks
+}
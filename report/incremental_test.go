@@ -0,0 +1,215 @@
+package report
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func (s *testStore) AlertsBetween(from, to time.Time) ([]newshound.NewsAlertLite, error) {
+	var alerts []newshound.NewsAlertLite
+	for _, alert := range s.alerts {
+		if !alert.Timestamp.Before(from) && alert.Timestamp.Before(to) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (s *testStore) EventsBetween(from, to time.Time) ([]newshound.NewsEvent, error) {
+	var events []newshound.NewsEvent
+	for _, event := range s.events {
+		if !event.EventStart.Before(from) && event.EventStart.Before(to) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *testStore) CountEvents(from, to time.Time) (int, error) {
+	events, err := s.EventsBetween(from, to)
+	return len(events), err
+}
+
+func (s *testStore) AlertWeeks() ([]AlertWeek, error) {
+	var weeks []AlertWeek
+	for _, doc := range s.reports[AlertsPerWeekBySenderReport] {
+		weeks = append(weeks, doc.(AlertWeek))
+	}
+	return weeks, nil
+}
+
+func (s *testStore) EventWeeks() ([]EventWeek, error) {
+	var weeks []EventWeek
+	for _, doc := range s.reports[EventsPerWeekBySenderReport] {
+		weeks = append(weeks, doc.(EventWeek))
+	}
+	return weeks, nil
+}
+
+func (s *testStore) ReplaceWeek(report string, weekStart time.Time, docs []interface{}) error {
+	s.removeWeeks(report, func(week time.Time) bool { return week.Equal(weekStart) })
+	s.reports[report] = append(s.reports[report], docs...)
+	return nil
+}
+
+func (s *testStore) RemoveWeeksBefore(report string, before time.Time) error {
+	s.removeWeeks(report, func(week time.Time) bool { return week.Before(before) })
+	return nil
+}
+
+func (s *testStore) removeWeeks(report string, remove func(time.Time) bool) {
+	var kept []interface{}
+	for _, doc := range s.reports[report] {
+		if !remove(docWeek(doc)) {
+			kept = append(kept, doc)
+		}
+	}
+	s.reports[report] = kept
+}
+
+func docWeek(doc interface{}) time.Time {
+	switch d := doc.(type) {
+	case AlertWeek:
+		return d.ID.WeekStart
+	case EventWeek:
+		return d.ID.WeekStart
+	case AlertTotal:
+		return d.ID.WeekStart
+	case EventTotal:
+		return d.ID.WeekStart
+	}
+	return time.Time{}
+}
+
+func (s *testStore) ReplaceTimeframe(report, timeframe string, docs []interface{}) error {
+	var kept []interface{}
+	for _, doc := range s.reports[report] {
+		if docTimeframe(doc) != timeframe {
+			kept = append(kept, doc)
+		}
+	}
+	s.reports[report] = append(kept, docs...)
+	return nil
+}
+
+func docTimeframe(doc interface{}) string {
+	switch d := doc.(type) {
+	case AvgAlerts:
+		return d.ID.Timeframe
+	case AvgEvents:
+		return d.ID.Timeframe
+	case Attendance:
+		return d.ID.Timeframe
+	}
+	return ""
+}
+
+func (s *testStore) AddHours(hours []AlertsPerHour) error {
+	for _, add := range hours {
+		found := false
+		for i, doc := range s.reports[AlertsPerHourReport] {
+			existing := doc.(AlertsPerHour)
+			if existing.ID != add.ID {
+				continue
+			}
+			for hour, count := range add.Value.Hours {
+				existing.Value.Hours[hour] += count
+			}
+			s.reports[AlertsPerHourReport][i] = existing
+			found = true
+		}
+		if !found {
+			s.reports[AlertsPerHourReport] = append(s.reports[AlertsPerHourReport], add)
+		}
+	}
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	want := generateTestReports(t)
+
+	// add the alerts one at a time, updating every week each could touch
+	s := newTestStore(t)
+	alerts := s.alerts
+	for i, alert := range alerts {
+		s.alerts = alerts[:i+1]
+		err := Update(s, []time.Time{alert.Timestamp, alert.Timestamp.Add(-2 * time.Hour)},
+			[]newshound.NewsAlertLite{alert}, Timeframes(testNow))
+		if err != nil {
+			t.Fatalf("Update() returned an error: %s", err)
+		}
+	}
+	// and the events show up in weeks that didn't get any new alerts
+	if err := Update(s, []time.Time{oldWeek, firstWeek, lastWeek}, nil, Timeframes(testNow)); err != nil {
+		t.Fatalf("Update() returned an error: %s", err)
+	}
+
+	for _, report := range []string{
+		AlertsPerWeekBySenderReport, AlertsPerWeekReport, EventsPerWeekBySenderReport,
+		EventsPerWeekReport, AvgAlertsPerWeekReport, AvgEventsPerWeekReport,
+		EventAttendanceReport, AlertsPerHourReport,
+	} {
+		got := sortDocs(s.reports[report])
+		if !reflect.DeepEqual(got, sortDocs(want[report])) {
+			t.Errorf("Update() %s got:\n%#v\nwant:\n%#v", report, got, want[report])
+		}
+	}
+
+	// updating the same week again should change nothing
+	before := sortDocs(s.reports[AlertsPerWeekBySenderReport])
+	if err := Update(s, []time.Time{firstWeek}, nil, Timeframes(testNow)); err != nil {
+		t.Fatalf("Update() returned an error: %s", err)
+	}
+	if got := sortDocs(s.reports[AlertsPerWeekBySenderReport]); !reflect.DeepEqual(got, before) {
+		t.Errorf("Update() of the same week changed the report got:\n%#v\nwant:\n%#v", got, before)
+	}
+
+	// timeframes that don't overlap the updated weeks are left alone
+	s.ReplaceTimeframe(AvgAlertsPerWeekReport, LastSevenDays, nil)
+	if err := Update(s, []time.Time{time.Date(2018, 5, 6, 0, 0, 0, 0, time.UTC)}, nil, Timeframes(testNow)); err != nil {
+		t.Fatalf("Update() returned an error: %s", err)
+	}
+	counts := map[string]int{}
+	for _, doc := range s.reports[AvgAlertsPerWeekReport] {
+		counts[docTimeframe(doc)]++
+	}
+	if counts[LastSevenDays] != 0 || counts[ThreeMonths] == 0 {
+		t.Errorf("Update() of an older week got timeframe counts %v, want only the overlapping timeframes", counts)
+	}
+}
+
+// sortDocs orders report docs by week and sender so reports built in
+// different orders can be compared.
+func sortDocs(docs []interface{}) []interface{} {
+	key := func(doc interface{}) (time.Time, string) {
+		switch d := doc.(type) {
+		case AlertWeek:
+			return d.ID.WeekStart, d.ID.Sender
+		case EventWeek:
+			return d.ID.WeekStart, d.ID.Sender
+		case AvgAlerts:
+			return time.Time{}, d.ID.Timeframe + d.ID.Sender
+		case AvgEvents:
+			return time.Time{}, d.ID.Timeframe + d.ID.Sender
+		case Attendance:
+			return time.Time{}, d.ID.Timeframe + d.ID.Sender
+		case AlertsPerHour:
+			return time.Time{}, d.ID.Sender
+		}
+		return docWeek(doc), ""
+	}
+	sorted := append([]interface{}(nil), docs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		wi, si := key(sorted[i])
+		wj, sj := key(sorted[j])
+		if wi.Equal(wj) {
+			return si < sj
+		}
+		return wi.Before(wj)
+	})
+	return sorted
+}
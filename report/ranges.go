@@ -0,0 +1,52 @@
+package report
+
+import (
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// The stored reports only count whole weeks that start within a timeframe. The
+// functions below are for ad hoc date ranges, where everything within the range
+// is counted, even if it falls in a week that started before it. Weeks are
+// bucketed in the location of 'from'.
+
+// RangeAvgAlerts averages the alerts sent in [from, to) per week for each sender.
+func RangeAvgAlerts(alerts []newshound.NewsAlertLite, senders []string, timeframe string, from, to time.Time) []AvgAlerts {
+	weeks := AlertsPerWeekBySender(alertsBetween(alerts, from, to), from.Location())
+	return avgAlertsPerWeek(weeks, senders, timeframe, WeekStart(from, from.Location()), to, WeeksBetween(from, to))
+}
+
+// RangeAvgEvents averages the events that started in [from, to) per week for each sender.
+func RangeAvgEvents(events []newshound.NewsEvent, senders []string, timeframe string, from, to time.Time) []AvgEvents {
+	weeks := EventsPerWeekBySender(eventsBetween(events, from, to), from.Location())
+	return avgEventsPerWeek(weeks, senders, timeframe, WeekStart(from, from.Location()), to, WeeksBetween(from, to))
+}
+
+// RangeAttendance calculates the percentage of events that started in [from, to)
+// each sender took part in.
+func RangeAttendance(events []newshound.NewsEvent, senders []string, timeframe string, from, to time.Time) []Attendance {
+	events = eventsBetween(events, from, to)
+	weeks := EventsPerWeekBySender(events, from.Location())
+	return EventAttendance(weeks, senders, timeframe, WeekStart(from, from.Location()), to, len(events))
+}
+
+func alertsBetween(alerts []newshound.NewsAlertLite, from, to time.Time) []newshound.NewsAlertLite {
+	var between []newshound.NewsAlertLite
+	for _, alert := range alerts {
+		if !alert.Timestamp.Before(from) && alert.Timestamp.Before(to) {
+			between = append(between, alert)
+		}
+	}
+	return between
+}
+
+func eventsBetween(events []newshound.NewsEvent, from, to time.Time) []newshound.NewsEvent {
+	var between []newshound.NewsEvent
+	for _, event := range events {
+		if !event.EventStart.Before(from) && event.EventStart.Before(to) {
+			between = append(between, event)
+		}
+	}
+	return between
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeReports(t *testing.T) {
+	s := newTestStore(t)
+	// starts mid week, so the stored reports would skip the first week
+	from := time.Date(2018, 6, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 6, 12, 0, 0, 0, 0, time.UTC)
+
+	alerts := map[string]AvgAlertsValue{}
+	for _, avg := range RangeAvgAlerts(s.alerts, s.senders, "custom", from, to) {
+		alerts[avg.ID.Sender] = avg.Value
+	}
+	if got := alerts["cnn"].TotalAlerts; got != 1 {
+		t.Errorf("RangeAvgAlerts() cnn got %d alerts, want 1", got)
+	}
+	if got := alerts["nbc"].TotalAlerts; got != 2 {
+		t.Errorf("RangeAvgAlerts() nbc got %d alerts, want 2", got)
+	}
+	if got := alerts["nbc"].AvgAlerts; !floatEqual(got, 2) {
+		t.Errorf("RangeAvgAlerts() nbc got avg %v, want 2", got)
+	}
+	if _, ok := alerts["ft"]; !ok {
+		t.Error("RangeAvgAlerts() should include senders with no alerts")
+	}
+
+	// only the 2018-06-12 09:00 event is before the end
+	attendance := map[string]AttendanceValue{}
+	for _, attend := range RangeAttendance(s.events, s.senders, "custom", from, to.Add(12*time.Hour)) {
+		attendance[attend.ID.Sender] = attend.Value
+	}
+	if got := attendance["cnn"]; got.TotalEvents != 1 || !floatEqual(got.Attendance, 100) {
+		t.Errorf("RangeAttendance() cnn got %#v, want 1 event and 100%%", got)
+	}
+	if got := attendance["ft"]; got.TotalEvents != 0 || got.Attendance != 0 {
+		t.Errorf("RangeAttendance() ft got %#v, want no events", got)
+	}
+}
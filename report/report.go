@@ -0,0 +1,4 @@
+// Package report contains the calculations behind Newshound's reports. Everything
+// here works on plain News Alerts and News Events so the same code can be used to
+// build the stored reports in fetch and to answer ad hoc queries in the API.
+package report
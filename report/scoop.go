@@ -0,0 +1,146 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DefaultTieThreshold is the lead time under which the first sender on an
+// event is not considered to have scooped the second, unless fetchd is
+// configured with another.
+const DefaultTieThreshold = 1 * time.Minute
+
+// EventScoop describes which sender broke a News Event and by how much.
+type EventScoop struct {
+	EventID    bson.ObjectId `json:"event_id" bson:"event_id"`
+	EventStart time.Time     `json:"event_start" bson:"event_start"`
+	Sender     string        `json:"sender" bson:"sender"`
+	RunnerUp   string        `json:"runner_up" bson:"runner_up"`
+	// Lead is the number of seconds between the first
+	// alerts from the sender and the runner up.
+	Lead int64 `json:"lead" bson:"lead"`
+	// Tie is set if the lead was under the tie threshold.
+	Tie bool `json:"tie" bson:"tie"`
+}
+
+// ScoopEvent determines which sender was first to report the given event. If the
+// event has fewer than 2 senders, there was no race and false is returned.
+func ScoopEvent(event newshound.NewsEvent, tieThreshold time.Duration) (EventScoop, bool) {
+	firsts := senderFirsts(event)
+	if len(firsts) < 2 {
+		return EventScoop{}, false
+	}
+	lead := firsts[1].lapsed - firsts[0].lapsed
+	return EventScoop{
+		EventID:    event.ID,
+		EventStart: event.EventStart,
+		Sender:     firsts[0].sender,
+		RunnerUp:   firsts[1].sender,
+		Lead:       lead,
+		Tie:        time.Duration(lead)*time.Second < tieThreshold,
+	}, true
+}
+
+type senderFirst struct {
+	sender string
+	lapsed int64
+}
+
+// senderFirsts returns each sender's first alert in the event,
+// ordered by the time lapsed since the event started.
+func senderFirsts(event newshound.NewsEvent) []senderFirst {
+	first := map[string]int64{}
+	for _, alert := range event.NewsAlerts {
+		if lapsed, ok := first[alert.Sender]; !ok || alert.TimeLapsed < lapsed {
+			first[alert.Sender] = alert.TimeLapsed
+		}
+	}
+	firsts := make([]senderFirst, 0, len(first))
+	for sender, lapsed := range first {
+		firsts = append(firsts, senderFirst{sender, lapsed})
+	}
+	sort.Slice(firsts, func(i, j int) bool {
+		if firsts[i].lapsed == firsts[j].lapsed {
+			return firsts[i].sender < firsts[j].sender
+		}
+		return firsts[i].lapsed < firsts[j].lapsed
+	})
+	return firsts
+}
+
+// ScoopValue holds a sender's first-to-report stats over a timeframe.
+type ScoopValue struct {
+	// Events is the number of events with 2 or more senders the sender reported on.
+	Events int `json:"total_events" bson:"total_events"`
+	// Wins is the number of those events the sender broke by more than the tie threshold.
+	Wins int `json:"wins" bson:"wins"`
+	// Ties is the number of events the sender was first on by less than the tie threshold.
+	Ties        int     `json:"ties" bson:"ties"`
+	WinRate     float64 `json:"win_rate" bson:"win_rate"`
+	WinRateLow  float64 `json:"win_rate_low" bson:"win_rate_low"`
+	WinRateHigh float64 `json:"win_rate_high" bson:"win_rate_high"`
+	// lead times are in seconds
+	MedianLead     float64 `json:"median_lead" bson:"median_lead"`
+	MedianLeadLow  float64 `json:"median_lead_low" bson:"median_lead_low"`
+	MedianLeadHigh float64 `json:"median_lead_high" bson:"median_lead_high"`
+}
+
+// SenderScoops pairs a sender with its scoop stats for leaderboards.
+type SenderScoops struct {
+	Sender     string `json:"sender" bson:"sender"`
+	ScoopValue `bson:",inline"`
+}
+
+// ScoopLeaderboard computes the first-to-report stats for every sender in the given events.
+// Events won by less than the tie threshold are not counted as wins. The results are
+// ordered by wins and then by win rate.
+func ScoopLeaderboard(events []newshound.NewsEvent, tieThreshold time.Duration) []SenderScoops {
+	values := map[string]*ScoopValue{}
+	leads := map[string][]float64{}
+	value := func(sender string) *ScoopValue {
+		v, ok := values[sender]
+		if !ok {
+			v = &ScoopValue{}
+			values[sender] = v
+		}
+		return v
+	}
+
+	for _, event := range events {
+		scoop, ok := ScoopEvent(event, tieThreshold)
+		if !ok {
+			continue
+		}
+		for _, first := range senderFirsts(event) {
+			value(first.sender).Events++
+		}
+		if scoop.Tie {
+			value(scoop.Sender).Ties++
+			continue
+		}
+		value(scoop.Sender).Wins++
+		leads[scoop.Sender] = append(leads[scoop.Sender], float64(scoop.Lead))
+	}
+
+	board := make([]SenderScoops, 0, len(values))
+	for sender, v := range values {
+		v.WinRate = float64(v.Wins) / float64(v.Events)
+		v.WinRateLow, v.WinRateHigh = WilsonInterval(v.Wins, v.Events)
+		v.MedianLead = Median(leads[sender])
+		v.MedianLeadLow, v.MedianLeadHigh = MedianCI(leads[sender])
+		board = append(board, SenderScoops{Sender: sender, ScoopValue: *v})
+	}
+	sort.Slice(board, func(i, j int) bool {
+		if board[i].Wins == board[j].Wins {
+			if board[i].WinRate == board[j].WinRate {
+				return board[i].Sender < board[j].Sender
+			}
+			return board[i].WinRate > board[j].WinRate
+		}
+		return board[i].Wins > board[j].Wins
+	})
+	return board
+}
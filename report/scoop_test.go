@@ -0,0 +1,101 @@
+package report
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func testEvent(alerts ...newshound.NewsEventAlert) newshound.NewsEvent {
+	return newshound.NewsEvent{NewsAlerts: alerts}
+}
+
+func ea(sender string, lapsed int64) newshound.NewsEventAlert {
+	return newshound.NewsEventAlert{Sender: sender, TimeLapsed: lapsed}
+}
+
+func TestScoopEvent(t *testing.T) {
+	tests := []struct {
+		given  newshound.NewsEvent
+		want   EventScoop
+		wantOK bool
+	}{
+		{
+			testEvent(ea("CNN", 0), ea("CNN", 30), ea("NBC", 400), ea("FT", 900)),
+			EventScoop{Sender: "CNN", RunnerUp: "NBC", Lead: 400},
+			true,
+		},
+		{
+			testEvent(ea("CNN", 0), ea("NBC", 20), ea("FT", 900)),
+			EventScoop{Sender: "CNN", RunnerUp: "NBC", Lead: 20, Tie: true},
+			true,
+		},
+		{
+			testEvent(ea("CNN", 0), ea("CNN", 300)),
+			EventScoop{},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		got, ok := ScoopEvent(test.given, time.Minute)
+		if ok != test.wantOK || got != test.want {
+			t.Errorf("ScoopEvent(%v) got:%+v, %v want:%+v, %v", test.given.NewsAlerts, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestScoopLeaderboard(t *testing.T) {
+	events := []newshound.NewsEvent{
+		testEvent(ea("CNN", 0), ea("NBC", 600)),
+		testEvent(ea("CNN", 0), ea("NBC", 120)),
+		testEvent(ea("NBC", 0), ea("CNN", 300)),
+		testEvent(ea("NBC", 0), ea("CNN", 10)),
+		testEvent(ea("FT", 0)),
+	}
+
+	board := ScoopLeaderboard(events, time.Minute)
+	if len(board) != 2 {
+		t.Fatalf("ScoopLeaderboard() got %d senders want 2: %+v", len(board), board)
+	}
+
+	cnn := board[0]
+	if cnn.Sender != "CNN" || cnn.Events != 4 || cnn.Wins != 2 || cnn.Ties != 0 {
+		t.Errorf("ScoopLeaderboard() CNN got:%+v", cnn)
+	}
+	if cnn.MedianLead != 360 || cnn.WinRate != 0.5 {
+		t.Errorf("ScoopLeaderboard() CNN median/rate got:%v/%v want:360/0.5", cnn.MedianLead, cnn.WinRate)
+	}
+	if cnn.WinRateLow > cnn.WinRate || cnn.WinRateHigh < cnn.WinRate {
+		t.Errorf("ScoopLeaderboard() CNN win rate interval %v-%v excludes %v", cnn.WinRateLow, cnn.WinRateHigh, cnn.WinRate)
+	}
+
+	nbc := board[1]
+	if nbc.Sender != "NBC" || nbc.Events != 4 || nbc.Wins != 1 || nbc.Ties != 1 || nbc.MedianLead != 300 {
+		t.Errorf("ScoopLeaderboard() NBC got:%+v", nbc)
+	}
+}
+
+func TestStats(t *testing.T) {
+	if got := Median([]float64{5, 1, 3}); got != 3 {
+		t.Errorf("Median() got:%v want:3", got)
+	}
+	if got := Median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("Median() got:%v want:2.5", got)
+	}
+
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i + 1)
+	}
+	if low, high := MedianCI(values); low != 40 || high != 61 {
+		t.Errorf("MedianCI(1..100) got:%v-%v want:40-61", low, high)
+	}
+
+	low, high := WilsonInterval(50, 100)
+	if math.Abs(low-0.4038) > 0.001 || math.Abs(high-0.5962) > 0.001 {
+		t.Errorf("WilsonInterval(50,100) got:%v-%v want:0.4038-0.5962", low, high)
+	}
+}
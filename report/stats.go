@@ -0,0 +1,57 @@
+package report
+
+import (
+	"math"
+	"sort"
+)
+
+// z95 is the z-score for a 95% confidence interval.
+const z95 = 1.959964
+
+// Median returns the median of the given values. The values will be sorted in place.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// MedianCI returns a distribution free 95% confidence interval for the median
+// of the given values using the binomial order statistics. The values will be sorted in place.
+func MedianCI(values []float64) (low, high float64) {
+	n := len(values)
+	if n == 0 {
+		return 0, 0
+	}
+	sort.Float64s(values)
+	spread := z95 * math.Sqrt(float64(n)) / 2
+	lo := int(math.Floor(float64(n)/2 - spread))
+	hi := int(math.Ceil(float64(n)/2 + 1 + spread))
+	// ranks are 1 indexed
+	if lo < 1 {
+		lo = 1
+	}
+	if hi > n {
+		hi = n
+	}
+	return values[lo-1], values[hi-1]
+}
+
+// WilsonInterval returns the 95% Wilson score interval for a proportion
+// of 'successes' out of 'total'.
+func WilsonInterval(successes, total int) (low, high float64) {
+	if total == 0 {
+		return 0, 0
+	}
+	n := float64(total)
+	p := float64(successes) / n
+	z2 := z95 * z95
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z95 * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
@@ -0,0 +1,268 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// Periods tag trends can be counted by.
+const (
+	Daily  = "day"
+	Weekly = "week"
+)
+
+// ValidPeriod returns true if the period is Daily or Weekly.
+func ValidPeriod(period string) bool {
+	return period == Daily || period == Weekly
+}
+
+// PeriodStart returns midnight of the day or week t falls in within the
+// given location.
+func PeriodStart(t time.Time, period string, loc *time.Location) time.Time {
+	if period == Weekly {
+		return WeekStart(t, loc)
+	}
+	t = t.In(loc)
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// nextPeriod returns the start of the period after the one starting at start.
+func nextPeriod(start time.Time, period string) time.Time {
+	if period == Weekly {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// TermOptions control how the tags of alerts are counted for the tag trend reports.
+type TermOptions struct {
+	// Period is the size of each count, Daily or Weekly.
+	Period string
+	// Entities will count the alerts' entity names instead of their tags.
+	Entities bool
+}
+
+// terms returns the distinct tags or entity names of the alert.
+func (o TermOptions) terms(alert newshound.NewsAlertLite) []string {
+	seen := map[string]struct{}{}
+	var terms []string
+	add := func(term string) {
+		if _, ok := seen[term]; !ok && term != "" {
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	if o.Entities {
+		for _, e := range alert.Entities {
+			add(e.Name)
+		}
+		return terms
+	}
+	for _, tag := range alert.Tags {
+		add(tag)
+	}
+	return terms
+}
+
+// has returns true if the alert mentions the term.
+func (o TermOptions) has(alert newshound.NewsAlertLite, term string) bool {
+	for _, t := range o.terms(alert) {
+		if t == term {
+			return true
+		}
+	}
+	return false
+}
+
+// PeriodCount is the number of alerts in the period beginning at Start.
+type PeriodCount struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// TagTrend holds the number of alerts mentioning a tag in each period of
+// a date range. Sender is empty for the trend across all senders.
+type TagTrend struct {
+	Tag    string        `json:"tag"`
+	Sender string        `json:"sender,omitempty"`
+	Period string        `json:"period"`
+	Total  int           `json:"total"`
+	Counts []PeriodCount `json:"counts"`
+}
+
+// TagTrends counts the alerts mentioning the tag in every period between from
+// and to, bucketed in from's location. The first trend is across all of the
+// given alerts and, if bySender is set, one trend per sender follows it.
+func TagTrends(alerts []newshound.NewsAlertLite, tag string, opts TermOptions, from, to time.Time, bySender bool) []TagTrend {
+	loc := from.Location()
+	var periods []time.Time
+	for start := PeriodStart(from, opts.Period, loc); start.Before(to); start = nextPeriod(start, opts.Period) {
+		periods = append(periods, start)
+	}
+	index := make(map[int64]int, len(periods))
+	for i, start := range periods {
+		index[start.Unix()] = i
+	}
+	newTrend := func(sender string) *TagTrend {
+		trend := &TagTrend{Tag: tag, Sender: sender, Period: opts.Period,
+			Counts: make([]PeriodCount, len(periods))}
+		for i, start := range periods {
+			trend.Counts[i].Start = start
+		}
+		return trend
+	}
+
+	all := newTrend("")
+	senders := map[string]*TagTrend{}
+	for _, alert := range alerts {
+		if alert.Timestamp.Before(from) || !alert.Timestamp.Before(to) || !opts.has(alert, tag) {
+			continue
+		}
+		i, ok := index[PeriodStart(alert.Timestamp, opts.Period, loc).Unix()]
+		if !ok {
+			continue
+		}
+		all.Counts[i].Count++
+		all.Total++
+		if !bySender {
+			continue
+		}
+		trend, ok := senders[alert.Sender]
+		if !ok {
+			trend = newTrend(alert.Sender)
+			senders[alert.Sender] = trend
+		}
+		trend.Counts[i].Count++
+		trend.Total++
+	}
+
+	results := []TagTrend{*all}
+	for _, trend := range senders {
+		results = append(results, *trend)
+	}
+	sort.Slice(results[1:], func(i, j int) bool {
+		return results[i+1].Sender < results[j+1].Sender
+	})
+	return results
+}
+
+// minMoverCount is the fewest alerts a tag needs in either period
+// to be considered a rising or falling tag.
+var minMoverCount = 2
+
+// TagChange is the change in a tag's alerts from one period to the next.
+type TagChange struct {
+	Tag      string `json:"tag"`
+	Count    int    `json:"count"`
+	Previous int    `json:"previous"`
+	Change   int    `json:"change"`
+}
+
+// TagMovement holds the tags that rose and fell the most in the period
+// beginning at Start compared with the period before it.
+type TagMovement struct {
+	Start   time.Time   `json:"start"`
+	Period  string      `json:"period"`
+	Rising  []TagChange `json:"rising"`
+	Falling []TagChange `json:"falling"`
+}
+
+// TagMovers finds the top n rising and falling tags of every period between
+// from and to, bucketed in from's location. The alerts should include those
+// from the period before from so the first period has something to compare to.
+func TagMovers(alerts []newshound.NewsAlertLite, opts TermOptions, from, to time.Time, n int) []TagMovement {
+	loc := from.Location()
+	first := PeriodStart(from, opts.Period, loc)
+	prev := PeriodStart(first.Add(-time.Hour), opts.Period, loc)
+
+	counts := map[int64]map[string]int{}
+	for _, alert := range alerts {
+		if alert.Timestamp.Before(prev) || !alert.Timestamp.Before(to) {
+			continue
+		}
+		start := PeriodStart(alert.Timestamp, opts.Period, loc).Unix()
+		tags, ok := counts[start]
+		if !ok {
+			tags = map[string]int{}
+			counts[start] = tags
+		}
+		for _, term := range opts.terms(alert) {
+			tags[term]++
+		}
+	}
+
+	var results []TagMovement
+	for start := first; start.Before(to); start = nextPeriod(start, opts.Period) {
+		current, previous := counts[start.Unix()], counts[prev.Unix()]
+		movement := TagMovement{Start: start, Period: opts.Period}
+		var changes []TagChange
+		for tag, count := range current {
+			changes = append(changes, TagChange{Tag: tag, Count: count, Previous: previous[tag], Change: count - previous[tag]})
+		}
+		for tag, count := range previous {
+			if _, ok := current[tag]; !ok {
+				changes = append(changes, TagChange{Tag: tag, Previous: count, Change: -count})
+			}
+		}
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Change == changes[j].Change {
+				return changes[i].Tag < changes[j].Tag
+			}
+			return changes[i].Change > changes[j].Change
+		})
+		for _, change := range changes {
+			if change.Change > 0 && change.Count >= minMoverCount && len(movement.Rising) < n {
+				movement.Rising = append(movement.Rising, change)
+			}
+		}
+		for i := len(changes) - 1; i >= 0; i-- {
+			change := changes[i]
+			if change.Change < 0 && change.Previous >= minMoverCount && len(movement.Falling) < n {
+				movement.Falling = append(movement.Falling, change)
+			}
+		}
+		results = append(results, movement)
+		prev = start
+	}
+	return results
+}
+
+// CoOccurrence is how often another tag was used alongside a tag.
+type CoOccurrence struct {
+	Tag    string `json:"tag"`
+	Alerts int    `json:"alerts"`
+	// Share is the fraction of the tag's alerts that also used this one.
+	Share float64 `json:"share"`
+}
+
+// TagCoOccurrences returns the top n tags used on the same alerts as the
+// given tag along with the number of alerts mentioning the tag.
+func TagCoOccurrences(alerts []newshound.NewsAlertLite, tag string, opts TermOptions, n int) ([]CoOccurrence, int) {
+	var total int
+	others := map[string]int{}
+	for _, alert := range alerts {
+		if !opts.has(alert, tag) {
+			continue
+		}
+		total++
+		for _, term := range opts.terms(alert) {
+			if term != tag {
+				others[term]++
+			}
+		}
+	}
+
+	top := topTags(others, n)
+	results := make([]CoOccurrence, 0, len(top))
+	for _, t := range top {
+		results = append(results, CoOccurrence{
+			Tag:    t.Tag,
+			Alerts: t.Frequency,
+			Share:  float64(t.Frequency) / float64(total),
+		})
+	}
+	return results, total
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+func TestTagTrends(t *testing.T) {
+	s := newTestStore(t)
+	end := lastWeek.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name     string
+		opts     TermOptions
+		from, to time.Time
+		bySender bool
+		want     map[string][]int
+	}{
+		{
+			"weekly",
+			TermOptions{Period: Weekly},
+			firstWeek, end, false,
+			map[string][]int{"": {3, 1}},
+		},
+		{
+			"weekly by sender",
+			TermOptions{Period: Weekly},
+			firstWeek, end, true,
+			map[string][]int{"": {3, 1}, "cnn": {2, 1}, "nbc": {1, 0}},
+		},
+		{
+			"daily",
+			TermOptions{Period: Daily},
+			time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC), time.Date(2018, 6, 7, 0, 0, 0, 0, time.UTC), false,
+			map[string][]int{"": {1, 1, 0}},
+		},
+		{
+			"entities",
+			TermOptions{Period: Weekly, Entities: true},
+			firstWeek, end, false,
+			map[string][]int{"": {0, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		got := map[string][]int{}
+		for _, trend := range TagTrends(s.alerts, "trump", test.opts, test.from, test.to, test.bySender) {
+			var counts []int
+			for _, c := range trend.Counts {
+				counts = append(counts, c.Count)
+			}
+			got[trend.Sender] = counts
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TagTrends(%s) got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTagMovers(t *testing.T) {
+	s := newTestStore(t)
+
+	got := TagMovers(s.alerts, TermOptions{Period: Weekly}, lastWeek, lastWeek.AddDate(0, 0, 7), 10)
+	if len(got) != 1 {
+		t.Fatalf("TagMovers() got %d periods, want 1", len(got))
+	}
+	if !got[0].Start.Equal(lastWeek) {
+		t.Errorf("TagMovers() got period %s, want %s", got[0].Start, lastWeek)
+	}
+	wantRising := []TagChange{{Tag: "senate", Count: 2, Previous: 0, Change: 2}}
+	if !reflect.DeepEqual(got[0].Rising, wantRising) {
+		t.Errorf("TagMovers() rising got:%#v want:%#v", got[0].Rising, wantRising)
+	}
+	// g.o.p. only had a single alert the week before
+	wantFalling := []TagChange{{Tag: "trump", Count: 1, Previous: 3, Change: -2}}
+	if !reflect.DeepEqual(got[0].Falling, wantFalling) {
+		t.Errorf("TagMovers() falling got:%#v want:%#v", got[0].Falling, wantFalling)
+	}
+}
+
+func TestTagCoOccurrences(t *testing.T) {
+	s := newTestStore(t)
+
+	got, total := TagCoOccurrences(s.alerts, "trump", TermOptions{}, 10)
+	if total != 4 {
+		t.Errorf("TagCoOccurrences() got %d alerts, want 4", total)
+	}
+	want := []CoOccurrence{{Tag: "g.o.p.", Alerts: 1, Share: 0.25}, {Tag: "senate", Alerts: 1, Share: 0.25}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TagCoOccurrences() got:%#v want:%#v", got, want)
+	}
+
+	// entities mentioned twice in one alert only count once
+	alerts := []newshound.NewsAlertLite{{Entities: []newshound.Entity{
+		{Name: "donald trump", Type: newshound.EntityPerson},
+		{Name: "white house", Type: newshound.EntityLocation},
+		{Name: "white house", Type: newshound.EntityOrganization},
+	}}}
+	got, total = TagCoOccurrences(alerts, "donald trump", TermOptions{Entities: true}, 10)
+	want = []CoOccurrence{{Tag: "white house", Alerts: 1, Share: 1}}
+	if total != 1 || !reflect.DeepEqual(got, want) {
+		t.Errorf("TagCoOccurrences(entities) got:%#v, %d want:%#v, 1", got, total, want)
+	}
+}
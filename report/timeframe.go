@@ -0,0 +1,240 @@
+package report
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	LastSevenDays = "7days"
+	ThreeMonths   = "3months"
+	SixMonths     = "6months"
+	TwelveMonths  = "12months"
+)
+
+// Timeframes returns the start and end of each report timeframe
+// ending at the given time.
+func Timeframes(now time.Time) map[string][]time.Time {
+	return map[string][]time.Time{
+		LastSevenDays: []time.Time{now.AddDate(0, 0, -7), now},
+		ThreeMonths:   []time.Time{now.AddDate(0, -3, 0), now},
+		SixMonths:     []time.Time{now.AddDate(0, -6, 0), now},
+		TwelveMonths:  []time.Time{now.AddDate(0, -12, 0), now},
+	}
+}
+
+// SenderTimeframe identifies a sender's activity over a single timeframe.
+type SenderTimeframe struct {
+	Sender    string `json:"sender" bson:"sender"`
+	Timeframe string `json:"timeframe" bson:"timeframe"`
+}
+
+// TagFrequency is the number of times a tag was used.
+type TagFrequency struct {
+	Tag       string `json:"tag" bson:"tag"`
+	Frequency int    `json:"frequency" bson:"frequency"`
+}
+
+// topTagCount is the number of tags kept in a sender's tag array.
+const topTagCount = 16
+
+// AvgAlerts is a sender's average alerts per week over a timeframe.
+type AvgAlerts struct {
+	ID    SenderTimeframe `json:"_id" bson:"_id"`
+	Value AvgAlertsValue  `json:"value" bson:"value"`
+}
+
+type AvgAlertsValue struct {
+	AvgAlerts   float64        `json:"avg_alerts" bson:"avg_alerts"`
+	TagArray    []TagFrequency `json:"tag_array" bson:"tag_array"`
+	TagMap      map[string]int `json:"tag_map" bson:"tag_map"`
+	TotalAlerts int            `json:"total_alerts" bson:"total_alerts"`
+}
+
+// AvgEvents is a sender's average events per week over a timeframe.
+type AvgEvents struct {
+	ID    SenderTimeframe `json:"_id" bson:"_id"`
+	Value AvgEventsValue  `json:"value" bson:"value"`
+}
+
+type AvgEventsValue struct {
+	AvgEvents       float64        `json:"avg_events" bson:"avg_events"`
+	TotalEvents     int64          `json:"total_events" bson:"total_events"`
+	AvgRank         float64        `json:"avg_rank" bson:"avg_rank"`
+	TotalRank       int64          `json:"total_rank" bson:"total_rank"`
+	TotalWeeks      int            `json:"total_weeks" bson:"total_weeks"`
+	TagMap          map[string]int `json:"tag_map" bson:"tag_map"`
+	AvgTimeLapsed   float64        `json:"avg_time_lapsed" bson:"avg_time_lapsed"`
+	TotalTimeLapsed int64          `json:"total_time_lapsed" bson:"total_time_lapsed"`
+}
+
+// Attendance is the percentage of all events in a timeframe a sender took part in.
+type Attendance struct {
+	ID    SenderTimeframe `json:"_id" bson:"_id"`
+	Value AttendanceValue `json:"value" bson:"value"`
+}
+
+type AttendanceValue struct {
+	TotalEvents int64   `json:"total_events" bson:"total_events"`
+	Attendance  float64 `json:"attendance" bson:"attendance"`
+}
+
+var hoursPerWeek = float64(24 * 7)
+
+// WeeksBetween returns the fractional number of weeks between two times.
+func WeeksBetween(from, to time.Time) float64 {
+	dur := to.Sub(from)
+	weeks := 0.0
+	if hours := dur.Hours(); hours > 0 {
+		weeks = hours / hoursPerWeek
+	}
+	return weeks
+}
+
+// inTimeframe reports whether a week bucket falls in the timeframe.
+func inTimeframe(weekStart, from, to time.Time) bool {
+	return !weekStart.Before(from) && weekStart.Before(to)
+}
+
+// AvgAlertsPerWeek averages the weekly alert counts that fall within the timeframe for
+// each sender. Every given sender gets a result, even if they sent no alerts.
+func AvgAlertsPerWeek(weeks []AlertWeek, senders []string, timeframe string, from, to time.Time) []AvgAlerts {
+	return avgAlertsPerWeek(weeks, senders, timeframe, from, to, WeeksBetween(from, to))
+}
+
+func avgAlertsPerWeek(weeks []AlertWeek, senders []string, timeframe string, from, to time.Time, numWeeks float64) []AvgAlerts {
+	values := map[string]*AvgAlertsValue{}
+	for _, sender := range senders {
+		values[sender] = &AvgAlertsValue{TagMap: map[string]int{}}
+	}
+	for _, week := range weeks {
+		if !inTimeframe(week.ID.WeekStart, from, to) {
+			continue
+		}
+		value, ok := values[week.ID.Sender]
+		if !ok {
+			value = &AvgAlertsValue{TagMap: map[string]int{}}
+			values[week.ID.Sender] = value
+		}
+		value.TotalAlerts += week.Value.Alerts
+		for tag, count := range week.Value.TagMap {
+			value.TagMap[tag] += count
+		}
+	}
+
+	results := make([]AvgAlerts, 0, len(values))
+	for sender, value := range values {
+		if numWeeks > 0 {
+			value.AvgAlerts = float64(value.TotalAlerts) / numWeeks
+		}
+		value.TagArray = topTags(value.TagMap, topTagCount)
+		results = append(results, AvgAlerts{
+			ID:    SenderTimeframe{Sender: sender, Timeframe: timeframe},
+			Value: *value,
+		})
+	}
+	sortBySender(results, func(i int) string { return results[i].ID.Sender })
+	return results
+}
+
+// AvgEventsPerWeek averages the weekly event participation that falls within the timeframe
+// for each sender. Every given sender gets a result, even if they were in no events.
+func AvgEventsPerWeek(weeks []EventWeek, senders []string, timeframe string, from, to time.Time) []AvgEvents {
+	return avgEventsPerWeek(weeks, senders, timeframe, from, to, WeeksBetween(from, to))
+}
+
+func avgEventsPerWeek(weeks []EventWeek, senders []string, timeframe string, from, to time.Time, numWeeks float64) []AvgEvents {
+	values := map[string]*AvgEventsValue{}
+	for _, sender := range senders {
+		values[sender] = &AvgEventsValue{TagMap: map[string]int{}}
+	}
+	for _, week := range weeks {
+		if !inTimeframe(week.ID.WeekStart, from, to) {
+			continue
+		}
+		value, ok := values[week.ID.Sender]
+		if !ok {
+			value = &AvgEventsValue{TagMap: map[string]int{}}
+			values[week.ID.Sender] = value
+		}
+		value.TotalWeeks++
+		value.TotalEvents += week.Value.TotalEvents
+		value.TotalRank += week.Value.TotalRank
+		value.TotalTimeLapsed += week.Value.TotalTimeLapsed
+		for tag, count := range week.Value.TagMap {
+			value.TagMap[tag] += count
+		}
+	}
+
+	results := make([]AvgEvents, 0, len(values))
+	for sender, value := range values {
+		if numWeeks > 0 {
+			value.AvgEvents = float64(value.TotalEvents) / numWeeks
+		}
+		if value.TotalEvents != 0 {
+			value.AvgRank = float64(value.TotalRank) / float64(value.TotalEvents)
+			value.AvgTimeLapsed = float64(value.TotalTimeLapsed) / float64(value.TotalEvents) / 60
+		}
+		results = append(results, AvgEvents{
+			ID:    SenderTimeframe{Sender: sender, Timeframe: timeframe},
+			Value: *value,
+		})
+	}
+	sortBySender(results, func(i int) string { return results[i].ID.Sender })
+	return results
+}
+
+// EventAttendance calculates the percentage of the timeframe's totalEvents each sender
+// took part in. Every given sender gets a result, even if they were in no events.
+func EventAttendance(weeks []EventWeek, senders []string, timeframe string, from, to time.Time, totalEvents int) []Attendance {
+	values := map[string]*AttendanceValue{}
+	for _, sender := range senders {
+		values[sender] = &AttendanceValue{}
+	}
+	for _, week := range weeks {
+		if !inTimeframe(week.ID.WeekStart, from, to) {
+			continue
+		}
+		value, ok := values[week.ID.Sender]
+		if !ok {
+			value = &AttendanceValue{}
+			values[week.ID.Sender] = value
+		}
+		value.TotalEvents += week.Value.TotalEvents
+	}
+
+	results := make([]Attendance, 0, len(values))
+	for sender, value := range values {
+		if value.TotalEvents != 0 && totalEvents != 0 {
+			value.Attendance = float64(value.TotalEvents) / float64(totalEvents) * 100.0
+		}
+		results = append(results, Attendance{
+			ID:    SenderTimeframe{Sender: sender, Timeframe: timeframe},
+			Value: *value,
+		})
+	}
+	sortBySender(results, func(i int) string { return results[i].ID.Sender })
+	return results
+}
+
+// topTags returns the n most frequently used tags, most frequent first.
+func topTags(tagMap map[string]int, n int) []TagFrequency {
+	tags := make([]TagFrequency, 0, len(tagMap))
+	for tag, freq := range tagMap {
+		tags = append(tags, TagFrequency{Tag: tag, Frequency: freq})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Frequency == tags[j].Frequency {
+			return tags[i].Tag < tags[j].Tag
+		}
+		return tags[i].Frequency > tags[j].Frequency
+	})
+	if len(tags) > n {
+		tags = tags[:n]
+	}
+	return tags
+}
+
+func sortBySender(results interface{}, sender func(int) string) {
+	sort.Slice(results, func(i, j int) bool { return sender(i) < sender(j) })
+}
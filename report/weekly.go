@@ -0,0 +1,271 @@
+package report
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// Location is the time zone the stored reports bucket alerts and
+// events into weeks and hours of the day with.
+var Location = time.UTC
+
+// WeekStart returns midnight of the Sunday starting the week t falls in
+// within the given location.
+func WeekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	return time.Date(y, m, d-int(t.Weekday()), 0, 0, 0, 0, loc)
+}
+
+// SenderWeek identifies a sender's activity in a single week.
+type SenderWeek struct {
+	Sender    string    `json:"sender" bson:"sender"`
+	WeekStart time.Time `json:"week_start" bson:"week_start"`
+}
+
+// Week identifies all activity in a single week.
+type Week struct {
+	WeekStart time.Time `json:"week_start" bson:"week_start"`
+}
+
+// AlertWeek is the number of alerts a sender sent in a week and the tags used in them.
+type AlertWeek struct {
+	ID    SenderWeek     `json:"_id" bson:"_id"`
+	Value AlertWeekValue `json:"value" bson:"value"`
+}
+
+type AlertWeekValue struct {
+	Alerts int            `json:"alerts" bson:"alerts"`
+	TagMap map[string]int `json:"tag_map" bson:"tag_map"`
+}
+
+// AlertTotal is the number of alerts sent by all senders in a week.
+type AlertTotal struct {
+	ID    Week            `json:"_id" bson:"_id"`
+	Value AlertTotalValue `json:"value" bson:"value"`
+}
+
+type AlertTotalValue struct {
+	Alerts int `json:"alerts" bson:"alerts"`
+}
+
+// EventWeek holds how many events a sender took part in during a week
+// and how quickly they showed up to them.
+type EventWeek struct {
+	ID    SenderWeek     `json:"_id" bson:"_id"`
+	Value EventWeekValue `json:"value" bson:"value"`
+}
+
+type EventWeekValue struct {
+	TotalEvents int64 `json:"total_events" bson:"total_events"`
+	// TotalRank is the sum of the sender's first alert position in each event.
+	TotalRank int64   `json:"total_rank" bson:"total_rank"`
+	AvgRank   float64 `json:"avg_rank" bson:"avg_rank"`
+	// TotalTimeLapsed is the sum of seconds between the start of each
+	// event and the sender's first alert in it.
+	TotalTimeLapsed int64 `json:"total_time_lapsed" bson:"total_time_lapsed"`
+	// AvgTimeLapsed is in minutes.
+	AvgTimeLapsed float64        `json:"avg_time_lapsed" bson:"avg_time_lapsed"`
+	TagMap        map[string]int `json:"tag_map" bson:"tag_map"`
+}
+
+// EventTotal is the number of events that started in a week.
+type EventTotal struct {
+	ID    Week            `json:"_id" bson:"_id"`
+	Value EventTotalValue `json:"value" bson:"value"`
+}
+
+type EventTotalValue struct {
+	Events int `json:"events" bson:"events"`
+}
+
+// AlertsPerWeekBySender counts each sender's alerts by their week in the given location.
+// The results are ordered by sender and week.
+func AlertsPerWeekBySender(alerts []newshound.NewsAlertLite, loc *time.Location) []AlertWeek {
+	weeks := map[SenderWeek]*AlertWeekValue{}
+	for _, alert := range alerts {
+		if alert.Timestamp.IsZero() {
+			continue
+		}
+		key := SenderWeek{Sender: alert.Sender, WeekStart: WeekStart(alert.Timestamp, loc)}
+		value, ok := weeks[key]
+		if !ok {
+			value = &AlertWeekValue{TagMap: map[string]int{}}
+			weeks[key] = value
+		}
+		value.Alerts++
+		for _, tag := range alert.Tags {
+			value.TagMap[tagKey(tag)]++
+		}
+	}
+
+	results := make([]AlertWeek, 0, len(weeks))
+	for key, value := range weeks {
+		results = append(results, AlertWeek{ID: key, Value: *value})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return senderWeekLess(results[i].ID, results[j].ID)
+	})
+	return results
+}
+
+// AlertsPerWeek totals the given sender weeks across all senders.
+func AlertsPerWeek(weeks []AlertWeek) []AlertTotal {
+	totals := map[time.Time]int{}
+	for _, week := range weeks {
+		totals[week.ID.WeekStart] += week.Value.Alerts
+	}
+
+	results := make([]AlertTotal, 0, len(totals))
+	for start, alerts := range totals {
+		results = append(results, AlertTotal{
+			ID:    Week{WeekStart: start},
+			Value: AlertTotalValue{Alerts: alerts},
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID.WeekStart.Before(results[j].ID.WeekStart)
+	})
+	return results
+}
+
+// EventsPerWeekBySender tallies each sender's event participation by the week
+// the events started in. Only a sender's first alert in each event is counted.
+func EventsPerWeekBySender(events []newshound.NewsEvent, loc *time.Location) []EventWeek {
+	weeks := map[SenderWeek]*EventWeekValue{}
+	for _, event := range events {
+		start := WeekStart(event.EventStart, loc)
+		seen := map[string]struct{}{}
+		for _, alert := range event.NewsAlerts {
+			if _, ok := seen[alert.Sender]; ok {
+				continue
+			}
+			seen[alert.Sender] = struct{}{}
+
+			key := SenderWeek{Sender: alert.Sender, WeekStart: start}
+			value, ok := weeks[key]
+			if !ok {
+				value = &EventWeekValue{TagMap: map[string]int{}}
+				weeks[key] = value
+			}
+			value.TotalEvents++
+			value.TotalRank += alert.Order
+			value.TotalTimeLapsed += alert.TimeLapsed
+			for _, tag := range alert.Tags {
+				value.TagMap[tagKey(tag)]++
+			}
+		}
+	}
+
+	results := make([]EventWeek, 0, len(weeks))
+	for key, value := range weeks {
+		value.AvgRank = float64(value.TotalRank) / float64(value.TotalEvents)
+		value.AvgTimeLapsed = float64(value.TotalTimeLapsed) / float64(value.TotalEvents) / 60
+		results = append(results, EventWeek{ID: key, Value: *value})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return senderWeekLess(results[i].ID, results[j].ID)
+	})
+	return results
+}
+
+// EventsPerWeek counts the events that started in each week.
+func EventsPerWeek(events []newshound.NewsEvent, loc *time.Location) []EventTotal {
+	totals := weekEvents{}
+	for _, event := range events {
+		totals.add(event, loc)
+	}
+	return totals.report()
+}
+
+// weekEvents counts the events that started in each week as they're added.
+type weekEvents map[time.Time]int
+
+func (w weekEvents) add(event newshound.NewsEvent, loc *time.Location) {
+	w[WeekStart(event.EventStart, loc)]++
+}
+
+func (w weekEvents) report() []EventTotal {
+	results := make([]EventTotal, 0, len(w))
+	for start, count := range w {
+		results = append(results, EventTotal{
+			ID:    Week{WeekStart: start},
+			Value: EventTotalValue{Events: count},
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID.WeekStart.Before(results[j].ID.WeekStart)
+	})
+	return results
+}
+
+// SenderKey identifies a report that covers all of a sender's history.
+type SenderKey struct {
+	Sender string `json:"sender" bson:"sender"`
+}
+
+// AlertsPerHour is the number of alerts a sender has sent in each hour of the day.
+type AlertsPerHour struct {
+	ID    SenderKey  `json:"_id" bson:"_id"`
+	Value HoursValue `json:"value" bson:"value"`
+}
+
+type HoursValue struct {
+	// Hours is keyed by the hour of the day, "0" through "23".
+	Hours map[string]int64 `json:"hours" bson:"hours"`
+}
+
+// SenderAlertsPerHour counts each sender's alerts by the hour of the day
+// they were sent in the given location.
+func SenderAlertsPerHour(alerts []newshound.NewsAlertLite, loc *time.Location) []AlertsPerHour {
+	counts := senderHours{}
+	for _, alert := range alerts {
+		counts.add(alert, loc)
+	}
+	return counts.report()
+}
+
+// senderHours counts each sender's alerts by hour of the day as they're added.
+type senderHours map[string]map[string]int64
+
+func (s senderHours) add(alert newshound.NewsAlertLite, loc *time.Location) {
+	hours, ok := s[alert.Sender]
+	if !ok {
+		hours = make(map[string]int64, 24)
+		for hour := 0; hour < 24; hour++ {
+			hours[strconv.Itoa(hour)] = 0
+		}
+		s[alert.Sender] = hours
+	}
+	hours[strconv.Itoa(alert.Timestamp.In(loc).Hour())]++
+}
+
+func (s senderHours) report() []AlertsPerHour {
+	results := make([]AlertsPerHour, 0, len(s))
+	for sender, hours := range s {
+		results = append(results, AlertsPerHour{
+			ID:    SenderKey{Sender: sender},
+			Value: HoursValue{Hours: hours},
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID.Sender < results[j].ID.Sender
+	})
+	return results
+}
+
+// tagKey makes a tag safe to use as a key in a stored document.
+func tagKey(tag string) string {
+	return strings.Replace(tag, ".", "&#46;", -1)
+}
+
+func senderWeekLess(a, b SenderWeek) bool {
+	if a.Sender == b.Sender {
+		return a.WeekStart.Before(b.WeekStart)
+	}
+	return a.Sender < b.Sender
+}
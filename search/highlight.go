@@ -0,0 +1,152 @@
+package search
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fragmentContext is about how many bytes of text are kept on either
+// side of a match in a highlighted fragment.
+var fragmentContext = 60
+
+// maxFragments is the most highlighted fragments returned for a field.
+var maxFragments = 3
+
+// Terms breaks search text into the lowercased words and quoted phrases it
+// will match. Excluded terms, prefixed with a '-', are dropped.
+func Terms(text string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	add := func(term string) {
+		term = strings.ToLower(strings.Join(strings.FieldsFunc(term, notWordRune), " "))
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for i, part := range strings.Split(text, `"`) {
+		// odd parts were inside quotes
+		if i%2 == 1 {
+			add(part)
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			if !strings.HasPrefix(word, "-") {
+				add(word)
+			}
+		}
+	}
+	return terms
+}
+
+func notWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+}
+
+// termPattern matches any of the terms at the start of a word. Words may have
+// extra characters on the end so "hike" will also highlight "hikes".
+func termPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	alts := make([]string, len(terms))
+	for i, term := range terms {
+		words := strings.Fields(term)
+		for j, word := range words {
+			words[j] = regexp.QuoteMeta(word)
+		}
+		alts[i] = strings.Join(words, `\W+`)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alts, "|") + `)[\pL\pN]*`)
+}
+
+// Highlight returns up to maxFragments fragments of text around the matches
+// for the terms. Matches are wrapped in <em> tags and the rest of the text
+// is HTML escaped.
+func Highlight(text string, terms []string) []string {
+	return highlight(text, termPattern(terms))
+}
+
+func highlight(text string, pattern *regexp.Regexp) []string {
+	if pattern == nil {
+		return nil
+	}
+	matches := pattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var fragments []string
+	for i := 0; i < len(matches) && len(fragments) < maxFragments; {
+		start := wordStart(text, matches[i][0]-fragmentContext)
+		end := wordEnd(text, matches[i][1]+fragmentContext)
+		// pull any nearby matches into the same fragment
+		j := i
+		for j+1 < len(matches) && matches[j+1][0] < end {
+			j++
+			if matches[j][1] > end {
+				end = wordEnd(text, matches[j][1])
+			}
+		}
+
+		var b strings.Builder
+		if start > 0 {
+			b.WriteString("…")
+		}
+		pos := start
+		for _, m := range matches[i : j+1] {
+			b.WriteString(html.EscapeString(text[pos:m[0]]))
+			b.WriteString("<em>")
+			b.WriteString(html.EscapeString(text[m[0]:m[1]]))
+			b.WriteString("</em>")
+			pos = m[1]
+		}
+		b.WriteString(html.EscapeString(text[pos:end]))
+		if end < len(text) {
+			b.WriteString("…")
+		}
+		fragments = append(fragments, strings.TrimSpace(b.String()))
+		i = j + 1
+	}
+	return fragments
+}
+
+// wordStart backs i up to the start of the word it falls in.
+func wordStart(text string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:i])
+		if unicode.IsSpace(r) {
+			break
+		}
+		i -= size
+	}
+	return i
+}
+
+// wordEnd moves i forward to the end of the word it falls in.
+func wordEnd(text string, i int) int {
+	if i >= len(text) {
+		return len(text)
+	}
+	for i < len(text) && !utf8.RuneStart(text[i]) {
+		i++
+	}
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
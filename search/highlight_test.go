@@ -0,0 +1,79 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Fed rate hike", []string{"fed", "rate", "hike"}},
+		{`"rate hike" Fed -trump`, []string{"rate hike", "fed"}},
+		{"Fed, fed! FED?", []string{"fed"}},
+		{`"unclosed quote`, []string{"unclosed quote"}},
+		{"-only -excluded", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		if got := Terms(test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Terms(%q) got:%#v want:%#v", test.text, got, test.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	long := "The Federal Reserve said on Wednesday that it would leave interest rates unchanged " +
+		"for now, but officials signaled that a rate hike could come as soon as next month if " +
+		"inflation continues to rise faster than expected across the economy this summer."
+
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  []string
+	}{
+		{
+			"short",
+			"Fed raises rates",
+			[]string{"fed", "rate"},
+			[]string{"<em>Fed</em> raises <em>rates</em>"},
+		},
+		{
+			"escaped",
+			"S&P <500> falls as Fed speaks",
+			[]string{"fed"},
+			[]string{"S&amp;P &lt;500&gt; falls as <em>Fed</em> speaks"},
+		},
+		{
+			"phrase",
+			"A rate  hike is coming, not a rate cut",
+			[]string{"rate hike"},
+			[]string{"A <em>rate  hike</em> is coming, not a rate cut"},
+		},
+		{
+			"fragments",
+			long,
+			[]string{"federal", "summer"},
+			[]string{
+				"The <em>Federal</em> Reserve said on Wednesday that it would leave interest rates…",
+				"…continues to rise faster than expected across the economy this <em>summer</em>.",
+			},
+		},
+		{
+			"no match",
+			"Senate passes tax bill",
+			[]string{"fed"},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		if got := Highlight(test.text, test.terms); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Highlight(%s) got:%#v want:%#v", test.name, got, test.want)
+		}
+	}
+}
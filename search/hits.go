@@ -0,0 +1,63 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jprobinson/newshound"
+)
+
+func alertHit(alert newshound.NewsAlert, score float64, pattern *regexp.Regexp) Hit {
+	hit := Hit{
+		ID:     alert.ID,
+		Score:  score,
+		Time:   alert.Timestamp,
+		Sender: alert.Sender,
+		Title:  alert.Subject,
+		Tags:   alert.Tags,
+	}
+	body := alert.Body
+	if body == "" {
+		sentences := make([]string, len(alert.Sentences))
+		for i, s := range alert.Sentences {
+			sentences[i] = s.Value
+		}
+		body = strings.Join(sentences, " ")
+	}
+	hit.Highlights = highlights(pattern, map[string]string{
+		"subject":      alert.Subject,
+		"top_sentence": alert.TopSentence,
+		"body":         body,
+	})
+	return hit
+}
+
+func eventHit(event newshound.NewsEvent, score float64, pattern *regexp.Regexp) Hit {
+	hit := Hit{
+		ID:    event.ID,
+		Score: score,
+		Time:  event.EventStart,
+		Title: event.Headline,
+		Tags:  event.Tags,
+	}
+	hit.Highlights = highlights(pattern, map[string]string{
+		"headline":     event.Headline,
+		"top_sentence": event.TopSentence,
+		"summary":      strings.Join(event.Summary, " "),
+	})
+	return hit
+}
+
+// highlights returns the highlighted fragments of each field with a match.
+func highlights(pattern *regexp.Regexp, fields map[string]string) map[string][]string {
+	hl := map[string][]string{}
+	for name, text := range fields {
+		if fragments := highlight(text, pattern); len(fragments) > 0 {
+			hl[name] = fragments
+		}
+	}
+	if len(hl) == 0 {
+		return nil
+	}
+	return hl
+}
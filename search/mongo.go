@@ -0,0 +1,151 @@
+package search
+
+import (
+	"context"
+
+	"github.com/jprobinson/newshound"
+	"go.opencensus.io/trace"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Indices are the text indices the Mongo backend relies on, keyed by collection.
+// Fields are weighted so matches in titles count for more than matches in bodies.
+var Indices = map[string]mgo.Index{
+	"news_alerts": {
+		Name: "search",
+		Key: []string{"$text:subject", "$text:top_sentence", "$text:tags",
+			"$text:sentences.sentence", "$text:body"},
+		Weights: map[string]int{"subject": 10, "top_sentence": 5, "tags": 5,
+			"sentences.sentence": 2, "body": 1},
+	},
+	"news_events": {
+		Name: "search",
+		Key: []string{"$text:headline", "$text:top_sentence", "$text:tags",
+			"$text:summary", "$text:news_alerts.subject"},
+		Weights: map[string]int{"headline": 10, "top_sentence": 5, "tags": 5,
+			"summary": 2, "news_alerts.subject": 2},
+	},
+}
+
+// EnsureIndices creates the Indices if they don't already exist.
+func EnsureIndices(sess *mgo.Session) error {
+	db := sess.DB("newshound")
+	for colName, indx := range Indices {
+		if err := db.C(colName).EnsureIndex(indx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hitFields are the only fields hits are built from. Raw bodies and the
+// rest of the documents are left in the database.
+var hitFields = map[string]bson.M{
+	Alerts: {"timestamp": 1, "sender": 1, "subject": 1, "top_sentence": 1, "tags": 1,
+		"body": 1, "sentences.sentence": 1},
+	Events: {"event_start": 1, "headline": 1, "top_sentence": 1, "tags": 1, "summary": 1},
+}
+
+// Mongo searches with MongoDB's text indices.
+type Mongo struct {
+	sess *mgo.Session
+}
+
+// NewMongo returns a Backend that searches the newshound database with
+// the Indices, which must already exist. See EnsureIndices.
+func NewMongo(sess *mgo.Session) *Mongo {
+	return &Mongo{sess: sess}
+}
+
+var _ Backend = &Mongo{}
+
+// Search runs the query as a $text search.
+func (m *Mongo) Search(ctx context.Context, q Query) (Results, error) {
+	ctx, span := trace.StartSpan(ctx, "newshound/mongodb/search-"+q.Kind)
+	defer span.End()
+
+	if err := q.Validate(); err != nil {
+		return Results{}, err
+	}
+
+	sess := m.sess.Copy()
+	defer sess.Close()
+	db := sess.DB("newshound")
+
+	query := bson.M{"$text": bson.M{"$search": q.Text}}
+	timeField, senderField := "timestamp", "sender"
+	if q.Kind == Events {
+		timeField, senderField = "event_start", "news_alerts.sender"
+	}
+	if !q.Start.IsZero() || !q.End.IsZero() {
+		dates := bson.M{}
+		if !q.Start.IsZero() {
+			dates["$gte"] = q.Start
+		}
+		if !q.End.IsZero() {
+			dates["$lt"] = q.End
+		}
+		query[timeField] = dates
+	}
+	if len(q.Senders) > 0 {
+		query[senderField] = bson.M{"$in": q.Senders}
+	}
+	if q.EventID != "" {
+		var event newshound.NewsEvent
+		err := db.C("news_events").FindId(q.EventID).Select(bson.M{"news_alerts.alert_id": 1}).One(&event)
+		if err != nil {
+			return Results{}, err
+		}
+		ids := make([]bson.ObjectId, len(event.NewsAlerts))
+		for i, alert := range event.NewsAlerts {
+			ids[i] = alert.AlertID
+		}
+		query["_id"] = bson.M{"$in": ids}
+	}
+
+	fields := bson.M{"score": bson.M{"$meta": "textScore"}}
+	for field := range hitFields[q.Kind] {
+		fields[field] = 1
+	}
+	sort := []string{"$textScore:score", "-" + timeField}
+	if q.Sort == ByDate {
+		sort = []string{"-" + timeField, "$textScore:score"}
+	}
+
+	c := db.C("news_alerts")
+	if q.Kind == Events {
+		c = db.C("news_events")
+	}
+	find := c.Find(query)
+	total, err := find.Count()
+	if err != nil {
+		return Results{}, err
+	}
+
+	results := Results{Total: total, Offset: q.Offset, Limit: q.Limit, Hits: []Hit{}}
+	pattern := termPattern(Terms(q.Text))
+	iter := find.Select(fields).Sort(sort...).Skip(q.Offset).Limit(q.Limit).Iter()
+	for q.Kind == Alerts {
+		var doc struct {
+			newshound.NewsAlert `bson:",inline"`
+			Score               float64 `bson:"score"`
+		}
+		if !iter.Next(&doc) {
+			break
+		}
+		results.Hits = append(results.Hits, alertHit(doc.NewsAlert, doc.Score, pattern))
+	}
+	for q.Kind == Events {
+		var doc struct {
+			newshound.NewsEvent `bson:",inline"`
+			Score               float64 `bson:"score"`
+		}
+		if !iter.Next(&doc) {
+			break
+		}
+		results.Hits = append(results.Hits, eventHit(doc.NewsEvent, doc.Score, pattern))
+	}
+	return results, iter.Close()
+}
@@ -0,0 +1,96 @@
+// Package search finds News Alerts and News Events by their text. Searches
+// go through a Backend so the index behind them can be swapped out.
+package search
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// The kinds of documents that can be searched.
+const (
+	Alerts = "alerts"
+	Events = "events"
+)
+
+// The orders results can be sorted in.
+const (
+	ByRelevance = "relevance"
+	// ByDate sorts the newest results first.
+	ByDate = "date"
+)
+
+// MaxLimit is the most results that can be returned at once.
+const MaxLimit = 100
+
+// ErrBadQuery is returned for queries that cannot be run.
+var ErrBadQuery = errors.New("bad search query")
+
+// Query describes a search.
+type Query struct {
+	Kind string
+	Text string
+
+	// Senders limits results to alerts from or events including
+	// any of the senders.
+	Senders []string
+	// Start and End limit results to a date range. End is exclusive.
+	// Alerts use their timestamp and events use their start.
+	Start time.Time
+	End   time.Time
+	// EventID limits alerts to the members of a single event.
+	EventID bson.ObjectId
+
+	Sort   string
+	Offset int
+	Limit  int
+}
+
+// Validate returns ErrBadQuery if the query cannot be run.
+func (q Query) Validate() error {
+	switch {
+	case q.Kind != Alerts && q.Kind != Events:
+		return ErrBadQuery
+	case len(Terms(q.Text)) == 0:
+		return ErrBadQuery
+	case q.Sort != ByRelevance && q.Sort != ByDate:
+		return ErrBadQuery
+	case q.Offset < 0 || q.Limit < 1 || q.Limit > MaxLimit:
+		return ErrBadQuery
+	case q.EventID != "" && q.Kind != Alerts:
+		return ErrBadQuery
+	}
+	return nil
+}
+
+// Hit is a single search result.
+type Hit struct {
+	ID    bson.ObjectId `json:"id"`
+	Score float64       `json:"score"`
+	// Time is the alert's timestamp or the event's start.
+	Time time.Time `json:"time"`
+	// Sender is only set for alerts.
+	Sender string `json:"sender,omitempty"`
+	// Title is the alert's subject or the event's headline.
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+	// Highlights holds fragments of each matching field with the
+	// matched terms wrapped in <em> tags.
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// Results are a page of hits along with the total number of matches.
+type Results struct {
+	Total  int   `json:"total"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+	Hits   []Hit `json:"hits"`
+}
+
+// Backend runs searches against an index of alerts and events.
+type Backend interface {
+	Search(ctx context.Context, q Query) (Results, error)
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jprobinson/newshound"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Query{Kind: Alerts, Text: "fed", Sort: ByRelevance, Limit: 20}
+	tests := []struct {
+		name    string
+		modify  func(*Query)
+		wantErr bool
+	}{
+		{"valid", func(q *Query) {}, false},
+		{"events by date", func(q *Query) { q.Kind, q.Sort = Events, ByDate }, false},
+		{"unknown kind", func(q *Query) { q.Kind = "storylines" }, true},
+		{"no text", func(q *Query) { q.Text = "  " }, true},
+		{"only exclusions", func(q *Query) { q.Text = "-fed" }, true},
+		{"unknown sort", func(q *Query) { q.Sort = "popularity" }, true},
+		{"negative offset", func(q *Query) { q.Offset = -1 }, true},
+		{"no limit", func(q *Query) { q.Limit = 0 }, true},
+		{"big limit", func(q *Query) { q.Limit = MaxLimit + 1 }, true},
+		{"event members", func(q *Query) { q.EventID = bson.NewObjectId() }, false},
+		{"event on events", func(q *Query) { q.Kind, q.EventID = Events, bson.NewObjectId() }, true},
+	}
+
+	for _, test := range tests {
+		q := valid
+		test.modify(&q)
+		if err := q.Validate(); (err != nil) != test.wantErr {
+			t.Errorf("Validate(%s) got error %v, wantErr %t", test.name, err, test.wantErr)
+		}
+	}
+}
+
+func TestAlertHit(t *testing.T) {
+	alert := newshound.NewsAlert{
+		NewsAlertLite: newshound.NewsAlertLite{
+			ID:          bson.NewObjectId(),
+			Sender:      "cnn",
+			Timestamp:   time.Date(2018, 6, 13, 18, 0, 0, 0, time.UTC),
+			Subject:     "Fed raises rates",
+			TopSentence: "The Federal Reserve raised interest rates.",
+			Tags:        []string{"federal reserve"},
+		},
+		Body: "The Federal Reserve raised interest rates on Wednesday.",
+	}
+
+	hit := alertHit(alert, 1.5, termPattern(Terms("fed")))
+	if hit.ID != alert.ID || hit.Sender != "cnn" || hit.Title != alert.Subject || !hit.Time.Equal(alert.Timestamp) {
+		t.Errorf("alertHit() got %#v", hit)
+	}
+	if got := hit.Highlights["subject"]; len(got) != 1 || got[0] != "<em>Fed</em> raises rates" {
+		t.Errorf("alertHit() subject highlights got:%#v", got)
+	}
+	if got := hit.Highlights["body"]; len(got) != 1 || got[0] != "The <em>Federal</em> Reserve raised interest rates on Wednesday." {
+		t.Errorf("alertHit() body highlights got:%#v", got)
+	}
+
+	if hit = alertHit(alert, 1, regexp.MustCompile("nomatch")); hit.Highlights != nil {
+		t.Errorf("alertHit() without matches got highlights %#v", hit.Highlights)
+	}
+}
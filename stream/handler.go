@@ -0,0 +1,158 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// KeepAlive is how often an idle Server-Sent Events stream gets a comment so
+// proxies don't close the connection.
+var KeepAlive = 30 * time.Second
+
+// Handler returns an http.Handler that streams the hub's messages. Requests to
+// upgrade to a WebSocket get a JSON message per frame and all others get a
+// Server-Sent Events stream.
+//
+// Clients filter the stream with 'sender' and 'tag' parameters, either
+// repeated or comma separated, and 'events_only=true'. They resume from the
+// last message they saw with the 'last_id' parameter or, for Server-Sent
+// Events, the Last-Event-ID header browsers send when reconnecting.
+func Handler(h *Hub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, lastID, err := parseRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			// any origin may connect since clients pass their API keys
+			// themselves rather than relying on cookies, so another site
+			// can't borrow a visitor's access
+			websocket.Server{Handler: func(conn *websocket.Conn) {
+				serveWebSocket(h, conn, f, lastID)
+			}}.ServeHTTP(w, r)
+			return
+		}
+		serveSSE(h, w, r, f, lastID)
+	})
+}
+
+func parseRequest(r *http.Request) (Filter, uint64, error) {
+	var (
+		f      Filter
+		lastID uint64
+		err    error
+	)
+	q := r.URL.Query()
+	f.Senders = listParam(q["sender"])
+	f.Tags = listParam(q["tag"])
+	if v := q.Get("events_only"); v != "" {
+		if f.EventsOnly, err = strconv.ParseBool(v); err != nil {
+			return f, 0, fmt.Errorf("invalid events_only: %q", v)
+		}
+	}
+
+	id := q.Get("last_id")
+	if id == "" {
+		id = r.Header.Get("Last-Event-ID")
+	}
+	if id != "" {
+		if lastID, err = strconv.ParseUint(id, 10, 64); err != nil {
+			return f, 0, fmt.Errorf("invalid last ID: %q", id)
+		}
+	}
+	return f, lastID, nil
+}
+
+func listParam(params []string) []string {
+	var values []string
+	for _, param := range params {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func serveSSE(h *Hub, w http.ResponseWriter, r *http.Request, f Filter, lastID uint64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	sub := h.Subscribe(f, lastID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(KeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case m, ok := <-sub.Messages():
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, m); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes a message in the Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, m Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", m.ID, m.Kind, data)
+	return err
+}
+
+func serveWebSocket(h *Hub, conn *websocket.Conn, f Filter, lastID uint64) {
+	sub := h.Subscribe(f, lastID)
+	defer sub.Close()
+
+	// clients don't send anything, so a failed read means they've gone away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case m, ok := <-sub.Messages():
+			if !ok {
+				return
+			}
+			if err := websocket.JSON.Send(conn, m); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"log"
+
+	"github.com/NYTimes/gizmo/pubsub"
+	"github.com/NYTimes/gizmo/pubsub/gcp"
+	"github.com/jprobinson/newshound"
+)
+
+// Publisher returns a publisher that streams everything published through
+// pub to the hub's subscribers. Raw messages are expected to be gob encoded
+// and keyed by their newshound topic, the way the fetch pipeline sends them.
+func (h *Hub) Publisher(pub pubsub.MultiPublisher) pubsub.MultiPublisher {
+	return &publisher{MultiPublisher: pub, hub: h}
+}
+
+type publisher struct {
+	pubsub.MultiPublisher
+	hub *Hub
+}
+
+func (p *publisher) PublishRaw(ctx context.Context, key string, m []byte) error {
+	p.hub.publishRaw(key, m)
+	return p.MultiPublisher.PublishRaw(ctx, key, m)
+}
+
+func (p *publisher) PublishMultiRaw(ctx context.Context, keys []string, ms [][]byte) error {
+	for i, m := range ms {
+		if i < len(keys) {
+			p.hub.publishRaw(keys[i], m)
+		}
+	}
+	return p.MultiPublisher.PublishMultiRaw(ctx, keys, ms)
+}
+
+// Consume streams the alerts and events received by sub until it stops. The
+// messages are expected to be published by a Publisher's wrapped publisher.
+func (h *Hub) Consume(sub pubsub.Subscriber) error {
+	for msg := range sub.Start() {
+		// the fetch pipeline's publish keys are sent as GCP message attributes
+		var key string
+		if gmsg, ok := msg.(*gcp.SubMessage); ok {
+			key = gmsg.Attributes["key"]
+		}
+		h.publishRaw(key, msg.Message())
+		if err := msg.Done(); err != nil {
+			log.Print("unable to ack streamed message: ", err)
+		}
+	}
+	return sub.Err()
+}
+
+// publishRaw decodes a gob encoded message published under key and sends it
+// to the hub's subscribers. Messages with any other key are ignored.
+func (h *Hub) publishRaw(key string, m []byte) {
+	var err error
+	switch key {
+	case newshound.NewsAlertTopic:
+		var alert newshound.NewsAlertLite
+		if err = gob.NewDecoder(bytes.NewReader(m)).Decode(&alert); err == nil {
+			h.PublishAlert(alert)
+		}
+	case newshound.NewsEventTopic, newshound.NewsEventUpdateTopic:
+		var event newshound.NewsEvent
+		if err = gob.NewDecoder(bytes.NewReader(m)).Decode(&event); err == nil {
+			h.PublishEvent(event, key == newshound.NewsEventUpdateTopic)
+		}
+	}
+	if err != nil {
+		log.Printf("unable to stream %s message: %s", key, err)
+	}
+}
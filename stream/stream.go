@@ -0,0 +1,191 @@
+// Package stream fans new News Alerts, new News Events and News Event updates
+// out to clients connected over Server-Sent Events or WebSockets.
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jprobinson/newshound"
+)
+
+// The kinds of messages sent on a stream.
+const (
+	KindAlert       = "alert"
+	KindEvent       = "event"
+	KindEventUpdate = "event_update"
+)
+
+var (
+	// BufferSize is the number of the most recent messages a Hub keeps so
+	// reconnecting clients can resume where they left off.
+	BufferSize = 1000
+
+	// SubscriberBuffer is the number of messages a client may fall behind
+	// before it is disconnected. Disconnected clients can reconnect and resume
+	// from the last message they received.
+	SubscriberBuffer = 100
+)
+
+// Message is a single item on a stream. Only one of Alert or Event is set.
+type Message struct {
+	// ID increases with every message sent, even across restarts, so clients
+	// can resume from the last ID they saw.
+	ID    uint64                   `json:"id"`
+	Kind  string                   `json:"kind"`
+	Time  time.Time                `json:"time"`
+	Alert *newshound.NewsAlertLite `json:"alert,omitempty"`
+	Event *newshound.NewsEvent     `json:"event,omitempty"`
+}
+
+// Filter selects the messages a client receives. Empty lists match everything.
+type Filter struct {
+	Senders    []string
+	Tags       []string
+	EventsOnly bool
+}
+
+// Match reports whether the message passes the filter. Events match a sender
+// if any of their alerts came from it.
+func (f Filter) Match(m Message) bool {
+	var senders, tags []string
+	switch {
+	case m.Alert != nil:
+		if f.EventsOnly {
+			return false
+		}
+		senders, tags = []string{m.Alert.Sender}, m.Alert.Tags
+	case m.Event != nil:
+		for _, alert := range m.Event.NewsAlerts {
+			senders = append(senders, alert.Sender)
+		}
+		tags = m.Event.Tags
+	default:
+		return false
+	}
+	return matchAny(f.Senders, senders) && matchAny(f.Tags, tags)
+}
+
+func matchAny(want, have []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, w := range want {
+		for _, h := range have {
+			if w == h {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Hub keeps the recent messages and the clients subscribed to them.
+type Hub struct {
+	mu     sync.Mutex
+	lastID uint64
+	recent []Message
+	subs   map[*Subscription]struct{}
+}
+
+// NewHub returns an empty Hub. Message IDs start from the current time in
+// milliseconds so that IDs from before a restart are always older.
+func NewHub() *Hub {
+	return &Hub{
+		lastID: uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+		subs:   map[*Subscription]struct{}{},
+	}
+}
+
+// PublishAlert sends a new News Alert to the hub's subscribers.
+func (h *Hub) PublishAlert(alert newshound.NewsAlertLite) {
+	h.publish(Message{Kind: KindAlert, Alert: &alert})
+}
+
+// PublishEvent sends a new or updated News Event to the hub's subscribers.
+func (h *Hub) PublishEvent(event newshound.NewsEvent, update bool) {
+	kind := KindEvent
+	if update {
+		kind = KindEventUpdate
+	}
+	h.publish(Message{Kind: kind, Event: &event})
+}
+
+func (h *Hub) publish(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	m.ID = h.lastID
+	m.Time = time.Now().UTC()
+
+	h.recent = append(h.recent, m)
+	if over := len(h.recent) - BufferSize; over > 0 {
+		h.recent = append(h.recent[:0], h.recent[over:]...)
+	}
+
+	for sub := range h.subs {
+		if !sub.filter.Match(m) {
+			continue
+		}
+		select {
+		case sub.c <- m:
+		default:
+			// too slow to keep up, the client will have to reconnect
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe registers a new client. Any recent messages after lastID that
+// match the filter are sent first, so a lastID of 0 replays all of the
+// buffered messages.
+func (h *Hub) Subscribe(f Filter, lastID uint64) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var backlog []Message
+	for _, m := range h.recent {
+		if m.ID > lastID && f.Match(m) {
+			backlog = append(backlog, m)
+		}
+	}
+	sub := &Subscription{
+		hub:    h,
+		filter: f,
+		c:      make(chan Message, len(backlog)+SubscriberBuffer),
+	}
+	for _, m := range backlog {
+		sub.c <- m
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// remove must be called with h.mu held.
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.c)
+	}
+}
+
+// Subscription is a single client's view of a Hub.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	c      chan Message
+}
+
+// Messages returns the channel the client's messages are sent on. It is
+// closed when the subscription is closed or the client falls too far behind.
+func (s *Subscription) Messages() <-chan Message {
+	return s.c
+}
+
+// Close unsubscribes the client from the hub.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
@@ -0,0 +1,253 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NYTimes/gizmo/pubsub/pubsubtest"
+	"github.com/jprobinson/newshound"
+	"golang.org/x/net/websocket"
+)
+
+var (
+	testAlert = newshound.NewsAlertLite{Sender: "cnn.com", Tags: []string{"senate", "congress"}}
+	testEvent = newshound.NewsEvent{
+		Tags: []string{"senate"},
+		NewsAlerts: []newshound.NewsEventAlert{
+			{Sender: "nytimes.com"}, {Sender: "foxnews.com"},
+		},
+	}
+)
+
+func TestFilterMatch(t *testing.T) {
+	alert := Message{Kind: KindAlert, Alert: &testAlert}
+	event := Message{Kind: KindEvent, Event: &testEvent}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		msg    Message
+		want   bool
+	}{
+		{"no filter alert", Filter{}, alert, true},
+		{"no filter event", Filter{}, event, true},
+		{"empty message", Filter{}, Message{}, false},
+		{"events only alert", Filter{EventsOnly: true}, alert, false},
+		{"events only event", Filter{EventsOnly: true}, event, true},
+		{"alert sender", Filter{Senders: []string{"bbc.com", "cnn.com"}}, alert, true},
+		{"alert other sender", Filter{Senders: []string{"bbc.com"}}, alert, false},
+		{"event alert sender", Filter{Senders: []string{"foxnews.com"}}, event, true},
+		{"event other sender", Filter{Senders: []string{"cnn.com"}}, event, false},
+		{"alert tag", Filter{Tags: []string{"congress"}}, alert, true},
+		{"event tag", Filter{Tags: []string{"congress"}}, event, false},
+		{"sender and tag", Filter{Senders: []string{"cnn.com"}, Tags: []string{"senate"}}, alert, true},
+		{"sender not tag", Filter{Senders: []string{"cnn.com"}, Tags: []string{"obama"}}, alert, false},
+	}
+
+	for _, test := range tests {
+		if got := test.filter.Match(test.msg); got != test.want {
+			t.Errorf("%s: Match got:%t want:%t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHubResume(t *testing.T) {
+	defer func(size int) { BufferSize = size }(BufferSize)
+	BufferSize = 3
+
+	h := NewHub()
+	for i := 0; i < 4; i++ {
+		h.PublishAlert(testAlert)
+	}
+	h.PublishEvent(testEvent, true)
+	first := h.recent[0].ID
+
+	tests := []struct {
+		name   string
+		filter Filter
+		lastID uint64
+		want   []string
+	}{
+		{"everything buffered", Filter{}, 0, []string{KindAlert, KindAlert, KindEventUpdate}},
+		{"resume", Filter{}, first, []string{KindAlert, KindEventUpdate}},
+		{"resume filtered", Filter{EventsOnly: true}, first, []string{KindEventUpdate}},
+		{"up to date", Filter{}, first + 2, nil},
+	}
+
+	for _, test := range tests {
+		sub := h.Subscribe(test.filter, test.lastID)
+		var got []string
+		for len(sub.Messages()) > 0 {
+			got = append(got, (<-sub.Messages()).Kind)
+		}
+		sub.Close()
+		if strings.Join(got, ",") != strings.Join(test.want, ",") {
+			t.Errorf("%s: got backlog:%v want:%v", test.name, got, test.want)
+		}
+	}
+
+	// IDs keep increasing after a restart
+	if next := NewHub(); next.lastID < h.lastID-10 {
+		t.Errorf("new hub starts at ID %d, before the old hub's %d", next.lastID, h.lastID)
+	}
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	defer func(size int) { SubscriberBuffer = size }(SubscriberBuffer)
+	SubscriberBuffer = 2
+
+	h := NewHub()
+	slow := h.Subscribe(Filter{}, 0)
+	filtered := h.Subscribe(Filter{Senders: []string{"bbc.com"}}, 0)
+	for i := 0; i < 3; i++ {
+		h.PublishAlert(testAlert)
+	}
+
+	var got int
+	for range slow.Messages() {
+		got++
+	}
+	if got != 2 {
+		t.Errorf("slow subscriber got %d messages before being closed, want 2", got)
+	}
+	if _, ok := h.subs[filtered]; !ok {
+		t.Error("subscriber that filtered out the messages was closed")
+	}
+	// closing twice is safe
+	slow.Close()
+	filtered.Close()
+	filtered.Close()
+	if len(h.subs) != 0 {
+		t.Errorf("hub still has %d subscribers", len(h.subs))
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	h := NewHub()
+	sub := h.Subscribe(Filter{}, 0)
+	defer sub.Close()
+
+	gcp := &pubsubtest.TestPublisher{}
+	pub := h.Publisher(gcp)
+	ctx := context.Background()
+
+	for _, msg := range []struct {
+		key     string
+		payload interface{}
+	}{
+		{newshound.NewsAlertTopic, &testAlert},
+		{newshound.NewsEventTopic, &testEvent},
+		{newshound.NewsEventUpdateTopic, &testEvent},
+		{"trending", &testAlert},
+	} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(msg.payload); err != nil {
+			t.Fatal(err)
+		}
+		if err := pub.PublishRaw(ctx, msg.key, buf.Bytes()); err != nil {
+			t.Fatalf("PublishRaw returned an error: %s", err)
+		}
+	}
+	// a bad payload is still published but not streamed
+	if err := pub.PublishRaw(ctx, newshound.NewsAlertTopic, []byte("nope")); err != nil {
+		t.Fatalf("PublishRaw returned an error: %s", err)
+	}
+
+	if len(gcp.Published) != 5 {
+		t.Errorf("published %d messages want 5", len(gcp.Published))
+	}
+	var got []string
+	for len(sub.Messages()) > 0 {
+		m := <-sub.Messages()
+		got = append(got, m.Kind)
+	}
+	if want := []string{KindAlert, KindEvent, KindEventUpdate}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("streamed:%v want:%v", got, want)
+	}
+}
+
+func TestHandlerSSE(t *testing.T) {
+	h := NewHub()
+	h.PublishAlert(testAlert)
+	h.PublishEvent(testEvent, false)
+	alertID := h.recent[0].ID
+
+	srv := httptest.NewServer(Handler(h))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"?tag=senate", nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(alertID, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("got content type %q", ct)
+	}
+
+	// the backlog only has the event, then the live update follows
+	h.PublishEvent(testEvent, true)
+	lines := bufio.NewScanner(resp.Body)
+	for _, want := range []struct {
+		id   uint64
+		kind string
+	}{{alertID + 1, KindEvent}, {alertID + 2, KindEventUpdate}} {
+		var got []string
+		for lines.Scan() && lines.Text() != "" {
+			got = append(got, lines.Text())
+		}
+		if len(got) != 3 || got[0] != "id: "+strconv.FormatUint(want.id, 10) || got[1] != "event: "+want.kind {
+			t.Fatalf("got event:%q want id:%d kind:%s", got, want.id, want.kind)
+		}
+		var m Message
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(got[2], "data: ")), &m); err != nil || m.Event == nil {
+			t.Errorf("got invalid data %q: %v", got[2], err)
+		}
+	}
+}
+
+func TestHandlerWebSocket(t *testing.T) {
+	h := NewHub()
+	h.PublishAlert(testAlert)
+
+	srv := httptest.NewServer(Handler(h))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?sender=cnn.com,bbc.com"
+	conn, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	h.PublishEvent(testEvent, false)
+	h.PublishAlert(testAlert)
+	for i := 0; i < 2; i++ {
+		var m Message
+		if err := websocket.JSON.Receive(conn, &m); err != nil {
+			t.Fatal(err)
+		}
+		if m.Kind != KindAlert || m.Alert == nil || m.Alert.Sender != "cnn.com" {
+			t.Errorf("got message %d:%#v", i, m)
+		}
+	}
+}
+
+func TestHandlerBadRequest(t *testing.T) {
+	for _, query := range []string{"?events_only=maybe", "?last_id=-1"} {
+		w := httptest.NewRecorder()
+		Handler(NewHub()).ServeHTTP(w, httptest.NewRequest("GET", "/"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s got status:%d want:400", query, w.Code)
+		}
+	}
+}